@@ -31,6 +31,22 @@ var showRepoCmd = &cobra.Command{
 	RunE:  runShowRepo,
 }
 
+var unquarantineRepoCmd = &cobra.Command{
+	Use:   "unquarantine <repository-name>",
+	Short: "Resume polling a quarantined repository",
+	Long:  "Resume polling a repository that was quarantined after repeated poll failures, starting with an immediate poll",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUnquarantineRepo,
+}
+
+var pollRepoCmd = &cobra.Command{
+	Use:   "poll <repository-name>",
+	Short: "Poll a repository now",
+	Long:  "Poll a repository immediately instead of waiting for its next scheduled poll, and show the changes and events it produced",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runPollRepo,
+}
+
 var (
 	repoPort   int
 	repoHost   string
@@ -46,8 +62,17 @@ func init() {
 	showRepoCmd.Flags().StringVar(&repoHost, "host", "localhost", "RepoSentry host")
 	showRepoCmd.Flags().StringVar(&repoFormat, "format", "text", "Output format (text, json)")
 
+	unquarantineRepoCmd.Flags().IntVar(&repoPort, "port", 8080, "RepoSentry API port")
+	unquarantineRepoCmd.Flags().StringVar(&repoHost, "host", "localhost", "RepoSentry host")
+
+	pollRepoCmd.Flags().IntVar(&repoPort, "port", 8080, "RepoSentry API port")
+	pollRepoCmd.Flags().StringVar(&repoHost, "host", "localhost", "RepoSentry host")
+	pollRepoCmd.Flags().StringVar(&repoFormat, "format", "text", "Output format (text, json)")
+
 	repoCmd.AddCommand(listReposCmd)
 	repoCmd.AddCommand(showRepoCmd)
+	repoCmd.AddCommand(pollRepoCmd)
+	repoCmd.AddCommand(unquarantineRepoCmd)
 
 	rootCmd.AddCommand(repoCmd)
 }
@@ -83,6 +108,103 @@ func runShowRepo(cmd *cobra.Command, args []string) error {
 	return printRepositoryText(repo)
 }
 
+func runUnquarantineRepo(cmd *cobra.Command, args []string) error {
+	baseURL := fmt.Sprintf("http://%s:%d", repoHost, repoPort)
+	repoName := args[0]
+
+	resp, err := http.Post(fmt.Sprintf("%s/api/repositories/%s/unquarantine", baseURL, repoName), "application/json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to un-quarantine repository: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to un-quarantine repository: %s", getStringValue(result, "error"))
+	}
+
+	fmt.Printf("✅ Repository %s un-quarantined, polling resumes now\n", repoName)
+	return nil
+}
+
+func runPollRepo(cmd *cobra.Command, args []string) error {
+	baseURL := fmt.Sprintf("http://%s:%d", repoHost, repoPort)
+	repoName := args[0]
+
+	resp, err := http.Post(fmt.Sprintf("%s/api/repositories/%s/poll", baseURL, repoName), "application/json", nil)
+	if err != nil {
+		return fmt.Errorf("failed to poll repository: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			return fmt.Errorf("failed to poll repository: %s (retry in %ss)", getStringValue(result, "error"), retryAfter)
+		}
+		return fmt.Errorf("failed to poll repository: %s", getStringValue(result, "error"))
+	}
+
+	if repoFormat == "json" {
+		return printRepositoryJSON(result)
+	}
+
+	return printPollResultText(result)
+}
+
+func printPollResultText(result map[string]interface{}) error {
+	data, ok := result["data"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("invalid response format")
+	}
+
+	repoName := getStringValue(data, "repository")
+	if success, _ := data["success"].(bool); !success {
+		return fmt.Errorf("poll of %s failed: %s", repoName, getStringValue(data, "error"))
+	}
+
+	if blackout, ok := data["blackout"].(map[string]interface{}); ok && getStringValue(blackout, "action") == "queue" {
+		fmt.Printf("⏸️  %s is in blackout window %s until %s, changes will be picked up then\n",
+			repoName, getStringValue(blackout, "window"), getStringValue(blackout, "ends"))
+		return nil
+	}
+
+	changes, _ := data["changes"].([]interface{})
+	pullRequests, _ := data["pull_request_changes"].([]interface{})
+	eventIDs, _ := data["event_ids"].([]interface{})
+
+	fmt.Printf("✅ Polled %s: %d change(s), %d pull request change(s), %d event(s)\n",
+		repoName, len(changes), len(pullRequests), len(eventIDs))
+
+	for _, change := range changes {
+		if changeMap, ok := change.(map[string]interface{}); ok {
+			fmt.Printf("  %-8s %s %s\n", getStringValue(changeMap, "change_type"), getStringValue(changeMap, "branch"), getStringValue(changeMap, "new_commit_sha"))
+		}
+	}
+	for _, change := range pullRequests {
+		if changeMap, ok := change.(map[string]interface{}); ok {
+			number, _ := changeMap["number"].(float64)
+			fmt.Printf("  %-8s #%.0f %s\n", getStringValue(changeMap, "change_type"), number, getStringValue(changeMap, "title"))
+		}
+	}
+	if len(eventIDs) > 0 {
+		fmt.Printf("\nEvents:\n")
+		for _, id := range eventIDs {
+			fmt.Printf("  %v\n", id)
+		}
+	}
+
+	return nil
+}
+
 func getRepositories(baseURL string) (map[string]interface{}, error) {
 	resp, err := http.Get(baseURL + "/api/repositories")
 	if err != nil {
@@ -155,7 +277,7 @@ func printRepositoriesTable(repos map[string]interface{}) error {
 			url := getStringValue(repoMap, "url")
 			branchRegex := getStringValue(repoMap, "branch_regex")
 			pollInterval := getStringValue(repoMap, "polling_interval")
-			status := "Active" // TODO: Get actual status
+			status := repositoryState(repoMap)
 
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
 				name, provider, url, branchRegex, pollInterval, status)
@@ -203,11 +325,31 @@ func printRepositoryText(repo map[string]interface{}) error {
 		fmt.Printf("Token: not configured\n")
 	}
 
-	// TODO: Add status information, last poll time, etc.
+	fmt.Printf("Status: %s\n", repositoryState(data))
+	if lastError := getStringValue(data, "last_error"); lastError != "" {
+		fmt.Printf("Last Error: %s\n", lastError)
+	}
+	if nextPoll := getStringValue(data, "next_poll_time"); nextPoll != "" {
+		fmt.Printf("Next Poll: %s\n", nextPoll)
+	}
 
 	return nil
 }
 
+// repositoryState summarizes a repository's polling state as reported by the API
+func repositoryState(repo map[string]interface{}) string {
+	if quarantined, _ := repo["quarantined"].(bool); quarantined {
+		return fmt.Sprintf("Quarantined (%s)", getStringValue(repo, "failure_reason"))
+	}
+	if failures, _ := repo["consecutive_failures"].(float64); failures > 0 {
+		return fmt.Sprintf("Failing x%.0f (%s)", failures, getStringValue(repo, "failure_reason"))
+	}
+	if enabled, ok := repo["enabled"].(bool); ok && !enabled {
+		return "Disabled"
+	}
+	return "Active"
+}
+
 func getStringValue(m map[string]interface{}, key string) string {
 	if value, ok := m[key].(string); ok {
 		return value
@@ -81,12 +81,21 @@ func runStatusOnce() error {
 		}
 	}
 
+	// Get repository polling states
+	repositories, err := getRepositories(baseURL)
+	if err != nil {
+		repositories = map[string]interface{}{
+			"status": "error",
+			"error":  err.Error(),
+		}
+	}
+
 	// Print results
 	if statusFormat == "json" {
-		return printStatusJSON(health, systemStatus, metrics)
+		return printStatusJSON(health, systemStatus, metrics, repositories)
 	}
 
-	return printStatusText(health, systemStatus, metrics)
+	return printStatusText(health, systemStatus, metrics, repositories)
 }
 
 func runStatusWatch() error {
@@ -151,11 +160,12 @@ func getMetrics(baseURL string) (map[string]interface{}, error) {
 	return result, nil
 }
 
-func printStatusJSON(health, systemStatus, metrics map[string]interface{}) error {
+func printStatusJSON(health, systemStatus, metrics, repositories map[string]interface{}) error {
 	combined := map[string]interface{}{
-		"health":  health,
-		"system":  systemStatus,
-		"metrics": metrics,
+		"health":       health,
+		"system":       systemStatus,
+		"metrics":      metrics,
+		"repositories": repositories,
 	}
 
 	jsonBytes, err := json.MarshalIndent(combined, "", "  ")
@@ -167,7 +177,7 @@ func printStatusJSON(health, systemStatus, metrics map[string]interface{}) error
 	return nil
 }
 
-func printStatusText(health, systemStatus, metrics map[string]interface{}) error {
+func printStatusText(health, systemStatus, metrics, repositories map[string]interface{}) error {
 	// Print health status
 	fmt.Printf("🏥 Health Status\n")
 	fmt.Printf("================\n")
@@ -227,9 +237,56 @@ func printStatusText(health, systemStatus, metrics map[string]interface{}) error
 		fmt.Printf("Metrics not available\n")
 	}
 
+	fmt.Printf("\n")
+
+	// Print repositories that are failing or quarantined
+	fmt.Printf("📁 Repositories\n")
+	fmt.Printf("===============\n")
+
+	if data, ok := repositories["data"].(map[string]interface{}); ok {
+		printRepositoryProblems(data)
+	} else {
+		fmt.Printf("Repository status not available\n")
+	}
+
 	return nil
 }
 
+func printRepositoryProblems(data map[string]interface{}) {
+	repos, _ := data["repositories"].([]interface{})
+
+	var failing, quarantined int
+	var problems []map[string]interface{}
+	for _, repo := range repos {
+		repoMap, ok := repo.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		isQuarantined, _ := repoMap["quarantined"].(bool)
+		failures, _ := repoMap["consecutive_failures"].(float64)
+		switch {
+		case isQuarantined:
+			quarantined++
+		case failures > 0:
+			failing++
+		default:
+			continue
+		}
+		problems = append(problems, repoMap)
+	}
+
+	fmt.Printf("Total: %d, Failing: %d, Quarantined: %d\n", len(repos), failing, quarantined)
+	for _, repo := range problems {
+		fmt.Printf("  - %-24s %s\n", getStringValue(repo, "name")+":", repositoryState(repo))
+		if lastError := getStringValue(repo, "last_error"); lastError != "" {
+			fmt.Printf("    %s\n", lastError)
+		}
+	}
+	if quarantined > 0 {
+		fmt.Printf("\n💡 Resume polling with: reposentry repo unquarantine <repository-name>\n")
+	}
+}
+
 func formatHealthStatus(status interface{}) string {
 	if str, ok := status.(string); ok {
 		switch str {
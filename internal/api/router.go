@@ -85,6 +85,16 @@ func (s *Server) handleAPIDocumentation(w http.ResponseWriter, r *http.Request)
 					"parameters":  "name: repository name",
 					"returns":     "Single repository configuration",
 				},
+				"POST /api/repositories/{name}/poll": map[string]string{
+					"description": "Poll a repository now instead of waiting for its schedule",
+					"parameters":  "name: repository name",
+					"returns":     "Poll result with detected changes and generated event IDs",
+				},
+				"POST /api/repositories/{name}/unquarantine": map[string]string{
+					"description": "Resume polling a repository quarantined after repeated failures",
+					"parameters":  "name: repository name",
+					"returns":     "Repository polling state",
+				},
 			},
 			"events": map[string]interface{}{
 				"GET /api/events": map[string]string{
@@ -2,9 +2,11 @@ package api
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/johnnynv/RepoSentry/internal/config"
@@ -224,6 +226,15 @@ func (s *Server) handleRepository(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if name, ok := strings.CutSuffix(path, "/unquarantine"); ok && name != "" {
+		s.handleUnquarantine(w, r, name)
+		return
+	}
+	if name, ok := strings.CutSuffix(path, "/poll"); ok && name != "" {
+		s.handlePollRepository(w, r, name)
+		return
+	}
+
 	repo, found := s.configManager.GetRepository(path)
 	if !found {
 		response := NewErrorResponse("Repository not found")
@@ -238,6 +249,131 @@ func (s *Server) handleRepository(w http.ResponseWriter, r *http.Request) {
 	response.Write(w)
 }
 
+// handleUnquarantine resumes polling a quarantined repository
+// @Summary Un-quarantine repository
+// @Description Resume polling a repository that was quarantined after repeated poll failures
+// @Tags Repositories
+// @Accept json
+// @Produce json
+// @Param name path string true "Repository name"
+// @Success 200 {object} JSONResponse{data=object} "Repository polling state"
+// @Failure 404 {object} JSONResponse "Repository not found"
+// @Failure 405 {object} JSONResponse "Method not allowed"
+// @Router /api/repositories/{name}/unquarantine [post]
+func (s *Server) handleUnquarantine(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodPost {
+		response := NewErrorResponse("Method not allowed, use POST")
+		response.WriteWithStatus(w, http.StatusMethodNotAllowed)
+		return
+	}
+
+	manager, ok := s.runtime.(RepositoryQuarantineManager)
+	statusProvider, hasStatus := s.runtime.(RepositoryStatusProvider)
+	if !ok || !hasStatus {
+		response := NewErrorResponse("Runtime does not support quarantine")
+		response.WriteWithStatus(w, http.StatusServiceUnavailable)
+		return
+	}
+
+	if _, found := statusProvider.GetRepositoryStatus(name); !found {
+		response := NewErrorResponse("Repository not found")
+		response.WriteWithStatus(w, http.StatusNotFound)
+		return
+	}
+
+	if err := manager.UnquarantineRepository(name); err != nil {
+		response := NewErrorResponse(fmt.Sprintf("Failed to un-quarantine repository: %v", err))
+		response.WriteWithStatus(w, http.StatusInternalServerError)
+		return
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"operation":  "unquarantine",
+		"repository": name,
+	}).Info("Repository un-quarantined through the API")
+
+	status, _ := statusProvider.GetRepositoryStatus(name)
+	response := NewJSONResponse(map[string]interface{}{
+		"name":           name,
+		"quarantined":    status.Quarantined,
+		"next_poll_time": status.NextPollTime,
+	})
+	response.Write(w)
+}
+
+// onDemandPollTimeout bounds on-demand polls so the result is written before
+// the server's write timeout
+const onDemandPollTimeout = 25 * time.Second
+
+// handlePollRepository polls a repository immediately, outside its schedule
+// @Summary Poll repository now
+// @Description Poll a repository immediately instead of waiting for its next scheduled poll, within its API rate limit
+// @Tags Repositories
+// @Accept json
+// @Produce json
+// @Param name path string true "Repository name"
+// @Success 200 {object} JSONResponse{data=RepositoryPollResult} "Poll result"
+// @Failure 404 {object} JSONResponse "Repository not found"
+// @Failure 405 {object} JSONResponse "Method not allowed"
+// @Failure 409 {object} JSONResponse "Repository is already being polled"
+// @Failure 429 {object} JSONResponse "API rate limit exhausted"
+// @Router /api/repositories/{name}/poll [post]
+func (s *Server) handlePollRepository(w http.ResponseWriter, r *http.Request, name string) {
+	if r.Method != http.MethodPost {
+		response := NewErrorResponse("Method not allowed, use POST")
+		response.WriteWithStatus(w, http.StatusMethodNotAllowed)
+		return
+	}
+
+	repoPoller, ok := s.runtime.(RepositoryPoller)
+	statusProvider, hasStatus := s.runtime.(RepositoryStatusProvider)
+	if !ok || !hasStatus {
+		response := NewErrorResponse("Runtime does not support on-demand polls")
+		response.WriteWithStatus(w, http.StatusServiceUnavailable)
+		return
+	}
+
+	if _, found := statusProvider.GetRepositoryStatus(name); !found {
+		response := NewErrorResponse("Repository not found")
+		response.WriteWithStatus(w, http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), onDemandPollTimeout)
+	defer cancel()
+
+	result, err := repoPoller.PollRepositoryNow(ctx, name)
+	if err != nil {
+		var inProgress *PollInProgressError
+		var rateLimited *PollRateLimitedError
+		switch {
+		case errors.As(err, &inProgress):
+			response := NewErrorResponse(err.Error())
+			response.WriteWithStatus(w, http.StatusConflict)
+		case errors.As(err, &rateLimited):
+			if wait := time.Until(rateLimited.ResetTime); wait > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+			}
+			response := NewErrorResponse(err.Error())
+			response.WriteWithStatus(w, http.StatusTooManyRequests)
+		default:
+			response := NewErrorResponse(fmt.Sprintf("Failed to poll repository: %v", err))
+			response.WriteWithStatus(w, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"operation":   "poll_repository",
+		"repository":  name,
+		"success":     result.Success,
+		"event_count": len(result.EventIDs),
+	}).Info("Repository polled through the API")
+
+	response := NewJSONResponse(result)
+	response.Write(w)
+}
+
 // convertRepositoryToAPI converts a Repository to API format with time in seconds
 func (s *Server) convertRepositoryToAPI(repo types.Repository) map[string]interface{} {
 	apiRepo := map[string]interface{}{
@@ -255,11 +391,55 @@ func (s *Server) convertRepositoryToAPI(repo types.Repository) map[string]interf
 		apiRepo["polling_interval"] = fmt.Sprintf("%ds", seconds)
 	}
 
+	if repo.Branches != "" {
+		apiRepo["branches"] = repo.Branches
+	}
+	if repo.DefaultBranch != "" {
+		apiRepo["default_branch"] = repo.DefaultBranch
+	}
+
 	// Add API base URL if present
 	if repo.APIBaseURL != "" {
 		apiRepo["api_base_url"] = repo.APIBaseURL
 	}
 
+	// Add cron schedule and blackout settings if present
+	if repo.Schedule != nil {
+		apiRepo["schedule"] = repo.Schedule
+	}
+	if len(repo.BlackoutWindows) > 0 {
+		apiRepo["blackout_windows"] = repo.BlackoutWindows
+	}
+	if repo.BlackoutAction != "" {
+		apiRepo["blackout_action"] = repo.BlackoutAction
+	}
+
+	// Add polling state if the runtime reports it
+	if provider, ok := s.runtime.(RepositoryStatusProvider); ok {
+		if status, found := provider.GetRepositoryStatus(repo.Name); found {
+			if !status.LastPollTime.IsZero() {
+				apiRepo["last_poll_time"] = status.LastPollTime
+			}
+			if !status.NextPollTime.IsZero() {
+				apiRepo["next_poll_time"] = status.NextPollTime
+			}
+			if status.Blackout != nil {
+				apiRepo["blackout"] = status.Blackout
+			}
+
+			apiRepo["last_success"] = status.LastSuccess
+			apiRepo["consecutive_failures"] = status.ConsecutiveFailures
+			apiRepo["quarantined"] = status.Quarantined
+			if status.LastError != "" {
+				apiRepo["last_error"] = status.LastError
+				apiRepo["failure_reason"] = status.FailureReason
+			}
+			if status.Quarantined {
+				apiRepo["quarantined_at"] = status.QuarantinedAt
+			}
+		}
+	}
+
 	return apiRepo
 }
 
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/johnnynv/RepoSentry/internal/config"
 	"github.com/johnnynv/RepoSentry/internal/testutils"
 	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
 )
 
 // Use existing mock from mocks_test.go
@@ -200,6 +202,203 @@ func TestServer_SetRuntime(t *testing.T) {
 	}
 }
 
+// mockStatusRuntime is a runtime provider that also reports repository polling state
+type mockStatusRuntime struct {
+	MockRuntimeProvider
+	statuses map[string]RepositoryPollStatus
+	pollErr  error
+}
+
+func (m *mockStatusRuntime) GetRepositoryStatus(name string) (RepositoryPollStatus, bool) {
+	status, ok := m.statuses[name]
+	return status, ok
+}
+
+func (m *mockStatusRuntime) PollRepositoryNow(ctx context.Context, name string) (*RepositoryPollResult, error) {
+	if m.pollErr != nil {
+		return nil, m.pollErr
+	}
+	return &RepositoryPollResult{
+		Repository: name,
+		Success:    true,
+		Changes:    []PolledChange{{Branch: "main", ChangeType: "updated", NewCommitSHA: "def456"}},
+		EventIDs:   []string{"event-1"},
+	}, nil
+}
+
+func (m *mockStatusRuntime) UnquarantineRepository(name string) error {
+	status := m.statuses[name]
+	status.Quarantined = false
+	status.ConsecutiveFailures = 0
+	m.statuses[name] = status
+	return nil
+}
+
+func TestServer_ConvertRepositoryToAPI(t *testing.T) {
+	server := NewServer(8080, &config.Manager{}, testutils.NewMockStorage(), logger.GetDefaultLogger().WithField("test", "api"))
+
+	ends := time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)
+	server.SetRuntime(&mockStatusRuntime{statuses: map[string]RepositoryPollStatus{
+		"frozen": {
+			NextPollTime: ends,
+			Blackout:     &BlackoutStatus{Window: "release-freeze", Action: types.BlackoutActionQueue, Ends: ends},
+		},
+	}})
+
+	repo := types.Repository{
+		Name:            "frozen",
+		Schedule:        &types.PollSchedule{Cron: "0 9-17 * * MON-FRI"},
+		BlackoutWindows: []types.BlackoutWindow{{Name: "release-freeze", Start: ends.Add(-24 * time.Hour), End: ends}},
+		BlackoutAction:  types.BlackoutActionQueue,
+	}
+	apiRepo := server.convertRepositoryToAPI(repo)
+
+	if apiRepo["schedule"] != repo.Schedule {
+		t.Errorf("Expected schedule %v, got %v", repo.Schedule, apiRepo["schedule"])
+	}
+	if apiRepo["blackout_action"] != types.BlackoutActionQueue {
+		t.Errorf("Expected blackout action queue, got %v", apiRepo["blackout_action"])
+	}
+	if apiRepo["next_poll_time"] != ends {
+		t.Errorf("Expected next poll time %v, got %v", ends, apiRepo["next_poll_time"])
+	}
+	if blackout, ok := apiRepo["blackout"].(*BlackoutStatus); !ok || blackout.Window != "release-freeze" {
+		t.Errorf("Expected blackout window release-freeze, got %v", apiRepo["blackout"])
+	}
+
+	// Repositories the runtime does not know have no polling state
+	apiRepo = server.convertRepositoryToAPI(types.Repository{Name: "other"})
+	if _, ok := apiRepo["next_poll_time"]; ok {
+		t.Error("Expected no next poll time for an unknown repository")
+	}
+}
+
+func TestServer_HandleUnquarantine(t *testing.T) {
+	server := NewServer(8080, &config.Manager{}, testutils.NewMockStorage(), logger.GetDefaultLogger().WithField("test", "api"))
+	runtime := &mockStatusRuntime{statuses: map[string]RepositoryPollStatus{
+		"org/broken": {Quarantined: true, ConsecutiveFailures: 10, FailureReason: "auth", LastError: "authentication failed"},
+	}}
+	server.SetRuntime(runtime)
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+	}{
+		{"GET", "/api/repositories/org/broken/unquarantine", http.StatusMethodNotAllowed},
+		{"POST", "/api/repositories/missing/unquarantine", http.StatusNotFound},
+		{"POST", "/api/repositories/org/broken/unquarantine", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		w := httptest.NewRecorder()
+		server.handleRepository(w, req)
+
+		if w.Code != tt.expected {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.expected, w.Code)
+		}
+	}
+
+	if runtime.statuses["org/broken"].Quarantined {
+		t.Error("Expected repository to be un-quarantined")
+	}
+
+	// Runtimes without quarantine support cannot un-quarantine
+	server.SetRuntime(&MockRuntimeProvider{})
+	req := httptest.NewRequest("POST", "/api/repositories/org/broken/unquarantine", nil)
+	w := httptest.NewRecorder()
+	server.handleRepository(w, req)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+}
+
+func TestServer_HandlePollRepository(t *testing.T) {
+	server := NewServer(8080, &config.Manager{}, testutils.NewMockStorage(), logger.GetDefaultLogger().WithField("test", "api"))
+	runtime := &mockStatusRuntime{statuses: map[string]RepositoryPollStatus{"org/hotfix": {}}}
+	server.SetRuntime(runtime)
+
+	req := httptest.NewRequest("POST", "/api/repositories/org/hotfix/poll", nil)
+	w := httptest.NewRecorder()
+	server.handleRepository(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status %d, got %d", http.StatusOK, w.Code)
+	}
+
+	var response struct {
+		Data RepositoryPollResult `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Data.Repository != "org/hotfix" || len(response.Data.Changes) != 1 {
+		t.Errorf("Expected one change for org/hotfix, got %+v", response.Data)
+	}
+	if len(response.Data.EventIDs) != 1 || response.Data.EventIDs[0] != "event-1" {
+		t.Errorf("Expected event ID event-1, got %v", response.Data.EventIDs)
+	}
+
+	reset := time.Now().Add(time.Minute)
+	tests := []struct {
+		method   string
+		path     string
+		pollErr  error
+		expected int
+	}{
+		{"GET", "/api/repositories/org/hotfix/poll", nil, http.StatusMethodNotAllowed},
+		{"POST", "/api/repositories/missing/poll", nil, http.StatusNotFound},
+		{"POST", "/api/repositories/org/hotfix/poll", &PollInProgressError{Repository: "org/hotfix"}, http.StatusConflict},
+		{"POST", "/api/repositories/org/hotfix/poll", &PollRateLimitedError{Provider: "github", ResetTime: reset}, http.StatusTooManyRequests},
+	}
+
+	for _, tt := range tests {
+		runtime.pollErr = tt.pollErr
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		w := httptest.NewRecorder()
+		server.handleRepository(w, req)
+
+		if w.Code != tt.expected {
+			t.Errorf("%s %s: expected status %d, got %d", tt.method, tt.path, tt.expected, w.Code)
+		}
+		if tt.expected == http.StatusTooManyRequests && w.Header().Get("Retry-After") == "" {
+			t.Error("Expected a Retry-After header for rate limited polls")
+		}
+	}
+}
+
+func TestServer_ConvertRepositoryToAPI_Failures(t *testing.T) {
+	server := NewServer(8080, &config.Manager{}, testutils.NewMockStorage(), logger.GetDefaultLogger().WithField("test", "api"))
+
+	quarantinedAt := time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)
+	server.SetRuntime(&mockStatusRuntime{statuses: map[string]RepositoryPollStatus{
+		"broken":  {Quarantined: true, QuarantinedAt: quarantinedAt, ConsecutiveFailures: 10, FailureReason: "not_found", LastError: "repository not found"},
+		"healthy": {LastSuccess: true},
+	}})
+
+	apiRepo := server.convertRepositoryToAPI(types.Repository{Name: "broken"})
+	if apiRepo["quarantined"] != true {
+		t.Errorf("Expected repository to be quarantined, got %v", apiRepo["quarantined"])
+	}
+	if apiRepo["failure_reason"] != "not_found" {
+		t.Errorf("Expected failure reason not_found, got %v", apiRepo["failure_reason"])
+	}
+	if apiRepo["consecutive_failures"] != 10 {
+		t.Errorf("Expected 10 consecutive failures, got %v", apiRepo["consecutive_failures"])
+	}
+	if apiRepo["quarantined_at"] != quarantinedAt {
+		t.Errorf("Expected quarantined at %v, got %v", quarantinedAt, apiRepo["quarantined_at"])
+	}
+
+	apiRepo = server.convertRepositoryToAPI(types.Repository{Name: "healthy"})
+	if _, ok := apiRepo["last_error"]; ok {
+		t.Error("Expected no last error for a healthy repository")
+	}
+	if _, ok := apiRepo["quarantined_at"]; ok {
+		t.Error("Expected no quarantine time for a healthy repository")
+	}
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || contains(s[1:], substr)))
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -23,6 +24,8 @@ type HealthCheck struct {
 	Name     string        `json:"name"`
 	Status   string        `json:"status"`
 	Duration time.Duration `json:"duration"`
+	Message  string        `json:"message,omitempty"`
+	Error    string        `json:"error,omitempty"`
 }
 
 // RuntimeStatus represents runtime status
@@ -41,6 +44,7 @@ type ComponentStatus struct {
 	StartedAt time.Time     `json:"started_at"`
 	Uptime    time.Duration `json:"uptime"`
 	Health    string        `json:"health"`
+	Metrics   interface{}   `json:"metrics,omitempty"`
 }
 
 // RuntimeProvider interface for runtime operations
@@ -48,3 +52,96 @@ type RuntimeProvider interface {
 	Health(ctx context.Context) RuntimeHealthStatus
 	GetStatus() *RuntimeStatus
 }
+
+// RepositoryStatusProvider is implemented by runtime providers that can report
+// the polling state of individual repositories
+type RepositoryStatusProvider interface {
+	GetRepositoryStatus(name string) (RepositoryPollStatus, bool)
+}
+
+// RepositoryQuarantineManager is implemented by runtime providers that can
+// resume polling quarantined repositories
+type RepositoryQuarantineManager interface {
+	UnquarantineRepository(name string) error
+}
+
+// RepositoryPoller is implemented by runtime providers that can poll a
+// repository on demand
+type RepositoryPoller interface {
+	// PollRepositoryNow polls a repository immediately and waits for the
+	// result. It returns a PollInProgressError or PollRateLimitedError when the
+	// poll cannot start.
+	PollRepositoryNow(ctx context.Context, name string) (*RepositoryPollResult, error)
+}
+
+// PollInProgressError is returned for on-demand polls of a repository that is
+// already being polled
+type PollInProgressError struct {
+	Repository string
+}
+
+func (e *PollInProgressError) Error() string {
+	return fmt.Sprintf("repository %s is already being polled", e.Repository)
+}
+
+// PollRateLimitedError is returned for on-demand polls that the repository's
+// API rate limit cannot cover before it resets
+type PollRateLimitedError struct {
+	Provider  string
+	ResetTime time.Time
+}
+
+func (e *PollRateLimitedError) Error() string {
+	return fmt.Sprintf("rate limit for %s is exhausted until %s", e.Provider, e.ResetTime.Format(time.RFC3339))
+}
+
+// RepositoryPollResult represents the outcome of an on-demand repository poll
+type RepositoryPollResult struct {
+	Repository   string              `json:"repository"`
+	Success      bool                `json:"success"`
+	Error        string              `json:"error,omitempty"`
+	Changes      []PolledChange      `json:"changes"`
+	PullRequests []PolledPullRequest `json:"pull_request_changes,omitempty"`
+	EventIDs     []string            `json:"event_ids"`
+	Duration     time.Duration       `json:"duration"`
+	Timestamp    time.Time           `json:"timestamp"`
+	Blackout     *BlackoutStatus     `json:"blackout,omitempty"` // The poll fell in a blackout window
+}
+
+// PolledChange represents a branch or tag change detected by a poll
+type PolledChange struct {
+	Branch       string `json:"branch"`
+	RefType      string `json:"ref_type,omitempty"`
+	ChangeType   string `json:"change_type"`
+	OldCommitSHA string `json:"old_commit_sha,omitempty"`
+	NewCommitSHA string `json:"new_commit_sha"`
+}
+
+// PolledPullRequest represents a pull request change detected by a poll
+type PolledPullRequest struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	ChangeType string `json:"change_type"`
+	HeadSHA    string `json:"head_sha"`
+}
+
+// RepositoryPollStatus represents the polling state of a repository
+type RepositoryPollStatus struct {
+	LastPollTime time.Time       `json:"last_poll_time,omitempty"`
+	NextPollTime time.Time       `json:"next_poll_time,omitempty"` // Held until the end of a queueing blackout
+	Blackout     *BlackoutStatus `json:"blackout,omitempty"`
+
+	LastSuccess         bool      `json:"last_success"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	FailureReason       string    `json:"failure_reason,omitempty"` // auth, not_found, network, rate_limit or unknown
+	Quarantined         bool      `json:"quarantined"`
+	QuarantinedAt       time.Time `json:"quarantined_at,omitempty"`
+}
+
+// BlackoutStatus represents the blackout window a repository is in
+type BlackoutStatus struct {
+	Window string    `json:"window"`
+	Action string    `json:"action"`
+	Ends   time.Time `json:"ends"`
+}
@@ -193,6 +193,11 @@ func (m *Manager) CheckPermissions() error {
 			continue
 		}
 
+		// GitHub repositories can authenticate as a GitHub App instead of with a token
+		if repo.Token == "" && repo.Provider == "github" && (repo.GitHubApp != nil || m.config.GitHubApp != nil) {
+			continue
+		}
+
 		// Check if token is an environment variable reference
 		if repo.Token == "" || (len(repo.Token) > 3 && repo.Token[:2] == "${" && repo.Token[len(repo.Token)-1:] == "}") {
 			// Extract variable name and check if it's set
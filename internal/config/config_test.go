@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -145,6 +147,313 @@ func (s *ConfigTestSuite) TestConfigManager_Validation() {
 	assert.Error(s.T(), err)
 }
 
+// TestValidator_GitHubApp tests that GitHub App credentials can replace repository tokens
+func (s *ConfigTestSuite) TestValidator_GitHubApp() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	config.Repositories[0].Token = ""
+	config.Repositories[0].Provider = "github"
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "repository token is required")
+
+	// A global app covers GitHub repositories without a token
+	config.GitHubApp = &types.GitHubAppConfig{AppID: 12345, PrivateKeyPath: "/etc/reposentry/app.pem"}
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	// Incomplete app credentials are rejected
+	config.GitHubApp = nil
+	config.Repositories[0].GitHubApp = &types.GitHubAppConfig{AppID: 12345}
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "private key path is required")
+}
+
+// TestValidator_PathFilters tests validation of changed-path glob patterns
+func (s *ConfigTestSuite) TestValidator_PathFilters() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	config.Repositories[0].IncludePaths = []string{"services/api/**", "**/*.go"}
+	config.Repositories[0].ExcludePaths = []string{"**/*.md"}
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Repositories[0].ExcludePaths = []string{"docs/[a-z.md"}
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "repositories[0].exclude_paths[0]")
+}
+
+func (s *ConfigTestSuite) TestValidator_SSHURLs() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	config.Repositories[0].URL = "git@github.com:owner/repo.git"
+	config.Repositories[0].SSHKeyFile = "/etc/reposentry/deploy_key"
+	config.Repositories[0].SSHKnownHosts = "/etc/reposentry/known_hosts"
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Repositories[0].URL = "ssh://git@gitlab.example.com:2222/group/project.git"
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Repositories[0].URL = "https://github.com/owner/repo"
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "repositories[0].ssh_key_file")
+}
+
+func (s *ConfigTestSuite) TestValidator_Priority() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	config.Repositories[0].Priority = types.PriorityCritical
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Repositories[0].Priority = "urgent"
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "repositories[0].priority")
+}
+
+func (s *ConfigTestSuite) TestValidator_CircuitBreaker() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	assert.Equal(s.T(), 5, config.Polling.CircuitBreaker.FailureThreshold)
+	assert.Equal(s.T(), time.Minute, config.Polling.CircuitBreaker.OpenTimeout)
+	assert.Equal(s.T(), 1, config.Polling.CircuitBreaker.HalfOpenRequests)
+
+	config.Polling.CircuitBreaker.FailureThreshold = -1
+	config.Polling.CircuitBreaker.OpenTimeout = -time.Second
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "polling.circuit_breaker.failure_threshold")
+	assert.Contains(s.T(), err.Error(), "polling.circuit_breaker.open_timeout")
+}
+
+func (s *ConfigTestSuite) TestValidator_Jitter() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	assert.Equal(s.T(), 0.1, config.Polling.Jitter)
+	assert.Equal(s.T(), time.Duration(0), config.Polling.Splay)
+
+	// Negative jitter disables it
+	config.Polling.Jitter = -1
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Polling.Jitter = 1.5
+	config.Polling.Splay = -time.Second
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "polling.jitter")
+	assert.Contains(s.T(), err.Error(), "polling.splay")
+}
+
+func (s *ConfigTestSuite) TestValidator_PollWindows() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	start := time.Date(2024, 12, 20, 18, 0, 0, 0, time.UTC)
+	config.Polling.Schedule = &types.PollSchedule{Cron: "*/10 8-18 * * MON-FRI", Timezone: "Europe/Berlin"}
+	config.Polling.BlackoutWindows = []types.BlackoutWindow{
+		{Name: "year-end", Start: start, End: start.Add(14 * 24 * time.Hour)},
+		{Name: "maintenance", Cron: "0 2 * * SUN", Duration: 2 * time.Hour},
+	}
+	config.Polling.BlackoutAction = types.BlackoutActionDrop
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Polling.Schedule = &types.PollSchedule{Cron: "*/10 8-18 * *", Timezone: "Nowhere/City"}
+	config.Polling.BlackoutWindows = []types.BlackoutWindow{
+		{Name: "reversed", Start: start, End: start.Add(-time.Hour)},
+		{Name: "no-duration", Cron: "@daily"},
+	}
+	config.Repositories[0].BlackoutAction = "pause"
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "polling.schedule.timezone")
+	assert.Contains(s.T(), err.Error(), "polling.blackout_windows[0].end")
+	assert.Contains(s.T(), err.Error(), "polling.blackout_windows[1].duration")
+	assert.Contains(s.T(), err.Error(), "repositories[0].blackout_action")
+}
+
+func (s *ConfigTestSuite) TestLoader_PollWindows() {
+	config, err := NewLoader().LoadFromBytes([]byte(`
+polling:
+  schedule:
+    cron: "0 9-17 * * MON-FRI"
+    timezone: Asia/Shanghai
+  blackout_windows:
+    - name: release-freeze
+      start: 2024-12-20T18:00:00+08:00
+      end: 2025-01-03T09:00:00+08:00
+    - name: maintenance
+      cron: "0 2 * * SUN"
+      duration: 2h
+  blackout_action: drop
+`))
+	s.RequireNoError(err)
+
+	assert.Equal(s.T(), "0 9-17 * * MON-FRI", config.Polling.Schedule.Cron)
+	assert.Equal(s.T(), "Asia/Shanghai", config.Polling.Schedule.Timezone)
+	require.Len(s.T(), config.Polling.BlackoutWindows, 2)
+	assert.True(s.T(), time.Date(2024, 12, 20, 10, 0, 0, 0, time.UTC).Equal(config.Polling.BlackoutWindows[0].Start))
+	assert.Equal(s.T(), 2*time.Hour, config.Polling.BlackoutWindows[1].Duration)
+	assert.Equal(s.T(), types.BlackoutActionDrop, config.Polling.BlackoutAction)
+}
+
+func (s *ConfigTestSuite) TestValidator_FailureBackoff() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	assert.Equal(s.T(), time.Hour, config.Polling.FailureBackoff.MaxBackoff)
+	assert.Equal(s.T(), 10, config.Polling.FailureBackoff.QuarantineAfter)
+
+	// A negative quarantine_after never quarantines
+	config.Polling.FailureBackoff.QuarantineAfter = -1
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Polling.FailureBackoff.MaxBackoff = -time.Minute
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "polling.failure_backoff.max_backoff")
+}
+
+func (s *ConfigTestSuite) TestValidator_HTTPCache() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	assert.Equal(s.T(), 7*24*time.Hour, config.Polling.HTTPCache.MaxAge)
+
+	config.Polling.HTTPCache.MaxAge = -time.Hour
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "polling.http_cache.max_age")
+}
+
+func (s *ConfigTestSuite) TestValidator_BlobCache() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	assert.False(s.T(), config.Tekton.BlobCache.Disabled)
+	assert.Equal(s.T(), 64, config.Tekton.BlobCache.MaxMemoryMB)
+
+	config.Tekton.BlobCache.MaxMemoryMB = -1
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "tekton.blob_cache.max_memory_mb")
+}
+
+func (s *ConfigTestSuite) TestValidator_Discovery() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	// Discovery sources can replace configured repositories entirely
+	config.Repositories = nil
+	config.Discovery = []types.DiscoverySource{
+		{Name: "acme", Provider: "github", Organization: "acme", Token: "token", RefreshInterval: time.Hour, BranchRegex: "^main$", Exclude: []string{"-sandbox$"}},
+		{Name: "platform", Provider: "gitlab", Group: "platform/backend", IncludeSubgroups: true, Token: "token", RefreshInterval: 30 * time.Minute, BranchRegex: ".*"},
+	}
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Discovery[0].User = "octocat"
+	config.Discovery[0].Exclude = []string{"("}
+	config.Discovery[1].Group = ""
+	config.Discovery[1].RefreshInterval = time.Second
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "discovery[0].organization")
+	assert.Contains(s.T(), err.Error(), "discovery[0].exclude[0]")
+	assert.Contains(s.T(), err.Error(), "discovery[1].group")
+	assert.Contains(s.T(), err.Error(), "discovery[1].refresh_interval")
+
+	config.Discovery = nil
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "at least one repository is required")
+}
+
+func (s *ConfigTestSuite) TestValidator_DefaultBranchOnly() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	// branches: default stands in for a branch regex
+	config.Repositories[0].BranchRegex = ""
+	config.Repositories[0].Branches = types.BranchesDefault
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.Repositories[0].Branches = "all"
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "repositories[0].branches")
+
+	config.Repositories[0].Branches = ""
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "repositories[0].branch_regex")
+}
+
+func (s *ConfigTestSuite) TestLoader_DiscoveryDefaults() {
+	tempDir := s.T().TempDir()
+	configPath := filepath.Join(tempDir, "config.yaml")
+	s.RequireNoError(os.WriteFile(configPath, []byte(`
+app:
+  name: reposentry
+polling:
+  interval: 10m
+discovery:
+  - name: acme
+    provider: github
+    organization: acme
+    token: token
+    branch_regex: "^main$"
+`), 0644))
+
+	config, err := NewLoader().LoadFromFile(configPath)
+	s.RequireNoError(err)
+	s.Require().Len(config.Discovery, 1)
+	assert.Equal(s.T(), time.Hour, config.Discovery[0].RefreshInterval)
+	assert.Equal(s.T(), 10*time.Minute, config.Discovery[0].PollingInterval)
+	assert.Equal(s.T(), "https://github.com/acme", config.Discovery[0].OwnerURL())
+}
+
+func (s *ConfigTestSuite) TestValidator_HTTPClient() {
+	loader := NewLoader()
+	config, err := loader.LoadFromFile("../../test/fixtures/test-config.yaml")
+	s.RequireNoError(err)
+
+	config.HTTP = types.HTTPClientConfig{
+		ProxyURL:      "http://proxy.corp.example.com:3128",
+		NoProxy:       []string{".corp.example.com", "10.0.0.0/8"},
+		CAFile:        "/etc/ssl/corp-ca.pem",
+		TLSMinVersion: "1.2",
+	}
+	config.Repositories[0].HTTP = types.HTTPClientConfig{CertFile: "/etc/reposentry/client.pem", KeyFile: "/etc/reposentry/client-key.pem"}
+	assert.NoError(s.T(), NewValidator().Validate(config))
+
+	config.HTTP.ProxyURL = "ftp://proxy.corp.example.com"
+	config.HTTP.TLSMinVersion = "1.4"
+	config.Repositories[0].HTTP.KeyFile = ""
+	err = NewValidator().Validate(config)
+	require.Error(s.T(), err)
+	assert.Contains(s.T(), err.Error(), "http.proxy_url")
+	assert.Contains(s.T(), err.Error(), "http.tls_min_version")
+	assert.Contains(s.T(), err.Error(), "repositories[0].http.cert_file")
+}
+
 // TestConfigManager_ThreadSafety tests concurrent access
 func (s *ConfigTestSuite) TestConfigManager_ThreadSafety() {
 	err := s.manager.Load("../../test/fixtures/test-config.yaml")
@@ -151,6 +151,39 @@ func (l *Loader) applyDefaults(config *types.Config) {
 	if config.Polling.BatchSize == 0 {
 		config.Polling.BatchSize = 10
 	}
+	if config.Polling.PageSize == 0 {
+		config.Polling.PageSize = 100
+	}
+	if config.Polling.MaxPages == 0 {
+		config.Polling.MaxPages = 50
+	}
+	if config.Polling.GraphQLBatchSize == 0 {
+		config.Polling.GraphQLBatchSize = 50
+	}
+	if config.Polling.MaxCommits == 0 {
+		config.Polling.MaxCommits = 20
+	}
+	if config.Polling.Jitter == 0 {
+		config.Polling.Jitter = 0.1
+	}
+	if config.Polling.HTTPCache.MaxAge == 0 {
+		config.Polling.HTTPCache.MaxAge = 7 * 24 * time.Hour
+	}
+	if config.Polling.FailureBackoff.MaxBackoff == 0 {
+		config.Polling.FailureBackoff.MaxBackoff = time.Hour
+	}
+	if config.Polling.FailureBackoff.QuarantineAfter == 0 {
+		config.Polling.FailureBackoff.QuarantineAfter = 10
+	}
+	if config.Polling.CircuitBreaker.FailureThreshold == 0 {
+		config.Polling.CircuitBreaker.FailureThreshold = 5
+	}
+	if config.Polling.CircuitBreaker.OpenTimeout == 0 {
+		config.Polling.CircuitBreaker.OpenTimeout = time.Minute
+	}
+	if config.Polling.CircuitBreaker.HalfOpenRequests == 0 {
+		config.Polling.CircuitBreaker.HalfOpenRequests = 1
+	}
 
 	// Storage defaults
 	if config.Storage.Type == "" {
@@ -176,6 +209,9 @@ func (l *Loader) applyDefaults(config *types.Config) {
 	if config.Tekton.RetryBackoff == 0 {
 		config.Tekton.RetryBackoff = 1 * time.Second
 	}
+	if config.Tekton.BlobCache.MaxMemoryMB == 0 {
+		config.Tekton.BlobCache.MaxMemoryMB = 64
+	}
 	if config.Tekton.Headers == nil {
 		config.Tekton.Headers = make(map[string]string)
 	}
@@ -221,6 +257,21 @@ func (l *Loader) applyDefaults(config *types.Config) {
 			repo.Enabled = true
 		}
 	}
+
+	applyDiscoveryDefaults(config.Discovery, config.Polling.Interval)
+}
+
+// applyDiscoveryDefaults fills in the refresh and polling intervals of discovery sources
+func applyDiscoveryDefaults(sources []types.DiscoverySource, pollingInterval time.Duration) {
+	for i := range sources {
+		source := &sources[i]
+		if source.RefreshInterval == 0 {
+			source.RefreshInterval = time.Hour
+		}
+		if source.PollingInterval == 0 {
+			source.PollingInterval = pollingInterval
+		}
+	}
 }
 
 // LoadWithDefaults loads configuration with fallback to defaults
@@ -265,6 +316,10 @@ func (l *Loader) loadRepositoriesConfig(config *types.Config, mainConfigPath str
 
 	// Merge repositories into main config
 	config.Repositories = repoConfig.Repositories
+	if len(repoConfig.Discovery) > 0 {
+		applyDiscoveryDefaults(repoConfig.Discovery, config.Polling.Interval)
+		config.Discovery = repoConfig.Discovery
+	}
 
 	return nil
 }
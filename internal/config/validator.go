@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // ValidationError represents a configuration validation error
@@ -58,7 +59,15 @@ func (v *Validator) Validate(config *types.Config) error {
 	v.validateTekton(&config.Tekton)
 	v.validateRateLimit(&config.RateLimit)
 	v.validateSecurity(&config.Security)
-	v.validateRepositories(config.Repositories)
+	if config.GitHubApp != nil {
+		v.validateGitHubApp("github_app", config.GitHubApp)
+	}
+	v.validateHTTPClient("http", config.HTTP)
+	// Repositories may all come from discovery sources
+	if len(config.Repositories) > 0 || len(config.Discovery) == 0 {
+		v.validateRepositories(config.Repositories, config.GitHubApp)
+	}
+	v.validateDiscovery(config.Discovery, config.GitHubApp)
 
 	if len(v.errors) > 0 {
 		return v.errors
@@ -113,6 +122,57 @@ func (v *Validator) validatePolling(polling *types.PollingConfig) {
 	if polling.BatchSize <= 0 {
 		v.addError("polling.batch_size", fmt.Sprintf("%d", polling.BatchSize), "batch size must be positive")
 	}
+
+	// GitHub and GitLab both cap list endpoints at 100 items per page
+	if polling.PageSize < 0 || polling.PageSize > 100 {
+		v.addError("polling.page_size", fmt.Sprintf("%d", polling.PageSize), "page size must be between 0 (default) and 100")
+	}
+
+	if polling.MaxPages < 0 {
+		v.addError("polling.max_pages", fmt.Sprintf("%d", polling.MaxPages), "max pages must not be negative")
+	}
+
+	// Large batches risk GraphQL node limits and query timeouts
+	if polling.GraphQLBatchSize < 0 || polling.GraphQLBatchSize > 100 {
+		v.addError("polling.graphql_batch_size", fmt.Sprintf("%d", polling.GraphQLBatchSize), "GraphQL batch size must be between 0 (default) and 100")
+	}
+
+	// GitHub's compare API returns at most 250 commits
+	if polling.MaxCommits > 250 {
+		v.addError("polling.max_commits", fmt.Sprintf("%d", polling.MaxCommits), "max commits must not exceed 250")
+	}
+
+	// A jitter above 1 could schedule polls in the past
+	if polling.Jitter > 1 {
+		v.addError("polling.jitter", fmt.Sprintf("%g", polling.Jitter), "jitter must not exceed 1")
+	}
+
+	if polling.Splay < 0 {
+		v.addError("polling.splay", polling.Splay.String(), "splay must not be negative")
+	}
+
+	// Validate cron schedule and blackout windows
+	v.validatePollWindows("polling", polling.Schedule, polling.BlackoutWindows, polling.BlackoutAction)
+
+	if polling.FailureBackoff.MaxBackoff < 0 {
+		v.addError("polling.failure_backoff.max_backoff", polling.FailureBackoff.MaxBackoff.String(), "max backoff must not be negative")
+	}
+
+	if polling.HTTPCache.MaxAge < 0 {
+		v.addError("polling.http_cache.max_age", polling.HTTPCache.MaxAge.String(), "max age must not be negative")
+	}
+
+	// Validate circuit breaker thresholds
+	breaker := polling.CircuitBreaker
+	if breaker.FailureThreshold < 0 {
+		v.addError("polling.circuit_breaker.failure_threshold", fmt.Sprintf("%d", breaker.FailureThreshold), "failure threshold must not be negative")
+	}
+	if breaker.OpenTimeout < 0 {
+		v.addError("polling.circuit_breaker.open_timeout", breaker.OpenTimeout.String(), "open timeout must not be negative")
+	}
+	if breaker.HalfOpenRequests < 0 {
+		v.addError("polling.circuit_breaker.half_open_requests", fmt.Sprintf("%d", breaker.HalfOpenRequests), "half-open requests must not be negative")
+	}
 }
 
 // validateStorage validates storage configuration
@@ -184,6 +244,11 @@ func (v *Validator) validateTekton(tekton *types.TektonConfig) {
 		v.addError("tekton.retry_attempts", fmt.Sprintf("%d", tekton.RetryAttempts), "retry attempts cannot be negative")
 	}
 
+	// Validate blob cache size
+	if tekton.BlobCache.MaxMemoryMB < 0 {
+		v.addError("tekton.blob_cache.max_memory_mb", fmt.Sprintf("%d", tekton.BlobCache.MaxMemoryMB), "blob cache size cannot be negative")
+	}
+
 	// Validate retry backoff
 	if tekton.RetryBackoff <= 0 {
 		v.addError("tekton.retry_backoff", tekton.RetryBackoff.String(), "retry backoff must be positive")
@@ -216,8 +281,89 @@ func (v *Validator) validateSecurity(security *types.SecurityConfig) {
 	}
 }
 
+// validateGitHubApp validates GitHub App credentials
+func (v *Validator) validateGitHubApp(prefix string, app *types.GitHubAppConfig) {
+	if app.AppID <= 0 {
+		v.addError(prefix+".app_id", fmt.Sprintf("%d", app.AppID), "GitHub App ID must be positive")
+	}
+	if app.PrivateKeyPath == "" {
+		v.addError(prefix+".private_key_path", app.PrivateKeyPath, "GitHub App private key path is required")
+	}
+	if app.InstallationID < 0 {
+		v.addError(prefix+".installation_id", fmt.Sprintf("%d", app.InstallationID), "GitHub App installation ID cannot be negative")
+	}
+}
+
+// validatePollWindows validates a cron polling schedule, blackout windows and
+// what happens to changes made during a blackout
+func (v *Validator) validatePollWindows(prefix string, schedule *types.PollSchedule, windows []types.BlackoutWindow, action string) {
+	if schedule != nil {
+		if schedule.Cron == "" {
+			v.addError(prefix+".schedule.cron", schedule.Cron, "schedule cron expression is required")
+		} else {
+			v.validateCron(prefix+".schedule", schedule.Cron, schedule.Timezone)
+		}
+	}
+
+	for i, window := range windows {
+		windowPrefix := fmt.Sprintf("%s.blackout_windows[%d]", prefix, i)
+		if window.Cron != "" {
+			if !window.Start.IsZero() || !window.End.IsZero() {
+				v.addError(windowPrefix+".cron", window.Cron, "set either cron and duration or start and end, not both")
+			}
+			if window.Duration <= 0 {
+				v.addError(windowPrefix+".duration", window.Duration.String(), "recurring blackout windows need a positive duration")
+			}
+			v.validateCron(windowPrefix, window.Cron, window.Timezone)
+			continue
+		}
+
+		if window.Start.IsZero() || window.End.IsZero() {
+			v.addError(windowPrefix+".start", window.Start.String(), "blackout windows need either cron and duration or start and end")
+		} else if !window.End.After(window.Start) {
+			v.addError(windowPrefix+".end", window.End.Format(time.RFC3339), "blackout window end must be after start")
+		}
+	}
+
+	if action != "" && action != types.BlackoutActionQueue && action != types.BlackoutActionDrop {
+		v.addError(prefix+".blackout_action", action, "invalid blackout action, must be one of: queue, drop")
+	}
+}
+
+// validateCron validates a cron expression and the time zone it is evaluated in
+func (v *Validator) validateCron(prefix, expr, timezone string) {
+	location := time.UTC
+	if timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			v.addError(prefix+".timezone", timezone, "invalid time zone: "+err.Error())
+			return
+		}
+		location = loaded
+	}
+
+	if _, err := utils.ParseCron(expr, location); err != nil {
+		v.addError(prefix+".cron", expr, err.Error())
+	}
+}
+
+// validateHTTPClient validates proxy and TLS settings for outbound HTTP clients
+func (v *Validator) validateHTTPClient(prefix string, httpConfig types.HTTPClientConfig) {
+	if httpConfig.ProxyURL != "" {
+		if err := utils.ValidateProxyURL(httpConfig.ProxyURL); err != nil {
+			v.addError(prefix+".proxy_url", httpConfig.ProxyURL, err.Error())
+		}
+	}
+	if (httpConfig.CertFile == "") != (httpConfig.KeyFile == "") {
+		v.addError(prefix+".cert_file", httpConfig.CertFile, "client certificate and key must be configured together")
+	}
+	if httpConfig.TLSMinVersion != "" && !utils.ValidTLSVersion(httpConfig.TLSMinVersion) {
+		v.addError(prefix+".tls_min_version", httpConfig.TLSMinVersion, "TLS version must be one of 1.0, 1.1, 1.2, 1.3")
+	}
+}
+
 // validateRepositories validates repository configurations
-func (v *Validator) validateRepositories(repositories []types.Repository) {
+func (v *Validator) validateRepositories(repositories []types.Repository, githubApp *types.GitHubAppConfig) {
 	if len(repositories) == 0 {
 		v.addError("repositories", "[]", "at least one repository is required")
 		return
@@ -246,32 +392,69 @@ func (v *Validator) validateRepositories(repositories []types.Repository) {
 			}
 		}
 
+		// Deploy keys only apply to SSH URLs
+		if (repo.SSHKeyFile != "" || repo.SSHKnownHosts != "") && !utils.IsSSHURL(repo.URL) {
+			v.addError(prefix+".ssh_key_file", repo.SSHKeyFile, "ssh_key_file and ssh_known_hosts_file require an SSH repository URL")
+		}
+
 		// Validate provider
-		validProviders := []string{"github", "gitlab"}
+		validProviders := []string{"github", "gitlab", "bitbucket", "gitea", "forgejo"}
 		if !v.contains(validProviders, repo.Provider) {
-			v.addError(prefix+".provider", repo.Provider, "invalid provider, must be 'github' or 'gitlab'")
+			v.addError(prefix+".provider", repo.Provider, "invalid provider, must be one of: "+strings.Join(validProviders, ", "))
 		}
 
-		// Validate token (should be set or be an env var reference)
-		if repo.Token == "" {
+		// Validate token (should be set or be an env var reference); GitHub
+		// repositories may authenticate as a GitHub App instead
+		if repo.GitHubApp != nil {
+			if repo.Provider != "github" {
+				v.addError(prefix+".github_app", repo.Provider, "GitHub App authentication is only supported for github repositories")
+			}
+			v.validateGitHubApp(prefix+".github_app", repo.GitHubApp)
+		}
+		usesGitHubApp := repo.Provider == "github" && (repo.GitHubApp != nil || githubApp != nil)
+		if repo.Token == "" && !usesGitHubApp {
 			v.addError(prefix+".token", repo.Token, "repository token is required")
 		}
 
-		// Validate branch regex
-		if repo.BranchRegex == "" {
-			v.addError(prefix+".branch_regex", repo.BranchRegex, "branch regex is required")
-		} else {
-			if _, err := regexp.Compile(repo.BranchRegex); err != nil {
-				v.addError(prefix+".branch_regex", repo.BranchRegex, "invalid regular expression: "+err.Error())
+		// Validate branch selection
+		v.validateBranches(prefix, repo.Branches, repo.BranchRegex)
+
+		// Validate tag regex if set; tags are not monitored without one
+		if repo.TagRegex != "" {
+			if _, err := regexp.Compile(repo.TagRegex); err != nil {
+				v.addError(prefix+".tag_regex", repo.TagRegex, "invalid regular expression: "+err.Error())
+			}
+		}
+
+		// Validate changed-path filters
+		for j, pattern := range repo.IncludePaths {
+			if err := utils.ValidateGlob(pattern); err != nil {
+				v.addError(fmt.Sprintf("%s.include_paths[%d]", prefix, j), pattern, err.Error())
+			}
+		}
+		for j, pattern := range repo.ExcludePaths {
+			if err := utils.ValidateGlob(pattern); err != nil {
+				v.addError(fmt.Sprintf("%s.exclude_paths[%d]", prefix, j), pattern, err.Error())
 			}
 		}
 
+		// Validate proxy and TLS overrides
+		v.validateHTTPClient(prefix+".http", repo.HTTP)
+
 		// Validate polling interval if set
 		if repo.PollingInterval > 0 && repo.PollingInterval < time.Minute {
 			v.addError(prefix+".polling_interval", repo.PollingInterval.String(),
 				"polling interval cannot be less than 1 minute (to protect against API rate limits and avoid service abuse)")
 		}
 
+		// Validate priority if set
+		if repo.Priority != "" && repo.Priority != types.PriorityCritical && repo.Priority != types.PriorityNormal {
+			v.addError(prefix+".priority", repo.Priority, "invalid priority, must be one of: critical, normal")
+		}
+
+		// Validate cron schedule and blackout windows if set
+		v.validatePollWindows(prefix, repo.Schedule, repo.BlackoutWindows, repo.BlackoutAction)
+
 		// Validate API base URL if set
 		if repo.APIBaseURL != "" {
 			// Trim whitespace for robustness
@@ -283,6 +466,109 @@ func (v *Validator) validateRepositories(repositories []types.Repository) {
 	}
 }
 
+// validateBranches validates which branches are monitored: either every branch
+// matching branch_regex, or only the default branch with branches: default
+func (v *Validator) validateBranches(prefix, branches, branchRegex string) {
+	if branches != "" && branches != types.BranchesDefault {
+		v.addError(prefix+".branches", branches, "invalid branches setting, must be: default")
+		return
+	}
+
+	if branchRegex == "" {
+		if branches != types.BranchesDefault {
+			v.addError(prefix+".branch_regex", branchRegex, "branch regex is required")
+		}
+	} else if _, err := regexp.Compile(branchRegex); err != nil {
+		v.addError(prefix+".branch_regex", branchRegex, "invalid regular expression: "+err.Error())
+	}
+}
+
+// validateDiscovery validates organization, user and group discovery sources
+func (v *Validator) validateDiscovery(sources []types.DiscoverySource, githubApp *types.GitHubAppConfig) {
+	names := make(map[string]bool)
+
+	for i, source := range sources {
+		prefix := fmt.Sprintf("discovery[%d]", i)
+
+		if source.Name == "" {
+			v.addError(prefix+".name", source.Name, "discovery source name is required")
+		} else if names[source.Name] {
+			v.addError(prefix+".name", source.Name, "discovery source name must be unique")
+		} else {
+			names[source.Name] = true
+		}
+
+		// Exactly one owner, matching the provider
+		owners := 0
+		for _, owner := range []string{source.Organization, source.User, source.Group} {
+			if owner != "" {
+				owners++
+			}
+		}
+		switch source.Provider {
+		case "github":
+			if owners != 1 || source.Group != "" {
+				v.addError(prefix+".organization", source.Organization, "github sources need exactly one of organization or user")
+			}
+		case "gitlab":
+			if owners != 1 || source.Group == "" {
+				v.addError(prefix+".group", source.Group, "gitlab sources need a group")
+			}
+		default:
+			v.addError(prefix+".provider", source.Provider, "invalid provider, must be one of: github, gitlab")
+		}
+		if source.IncludeSubgroups && source.Provider != "gitlab" {
+			v.addError(prefix+".include_subgroups", source.Provider, "include_subgroups only applies to gitlab groups")
+		}
+
+		if source.URL != "" {
+			if err := v.validateURL(source.URL); err != nil {
+				v.addError(prefix+".url", source.URL, err.Error())
+			}
+		}
+		if source.APIBaseURL != "" {
+			if _, err := url.Parse(strings.TrimSpace(source.APIBaseURL)); err != nil {
+				v.addError(prefix+".api_base_url", source.APIBaseURL, "invalid API base URL format")
+			}
+		}
+
+		usesGitHubApp := source.Provider == "github" && githubApp != nil
+		if source.Token == "" && !usesGitHubApp {
+			v.addError(prefix+".token", source.Token, "discovery source token is required")
+		}
+
+		for j, pattern := range source.Include {
+			if _, err := regexp.Compile(pattern); err != nil {
+				v.addError(fmt.Sprintf("%s.include[%d]", prefix, j), pattern, "invalid regular expression: "+err.Error())
+			}
+		}
+		for j, pattern := range source.Exclude {
+			if _, err := regexp.Compile(pattern); err != nil {
+				v.addError(fmt.Sprintf("%s.exclude[%d]", prefix, j), pattern, "invalid regular expression: "+err.Error())
+			}
+		}
+
+		if source.RefreshInterval < time.Minute {
+			v.addError(prefix+".refresh_interval", source.RefreshInterval.String(), "refresh interval cannot be less than 1 minute")
+		}
+
+		// Settings passed on to discovered repositories
+		v.validateBranches(prefix, source.Branches, source.BranchRegex)
+		if source.TagRegex != "" {
+			if _, err := regexp.Compile(source.TagRegex); err != nil {
+				v.addError(prefix+".tag_regex", source.TagRegex, "invalid regular expression: "+err.Error())
+			}
+		}
+		if source.PollingInterval > 0 && source.PollingInterval < time.Minute {
+			v.addError(prefix+".polling_interval", source.PollingInterval.String(),
+				"polling interval cannot be less than 1 minute (to protect against API rate limits and avoid service abuse)")
+		}
+		if source.Priority != "" && source.Priority != types.PriorityCritical && source.Priority != types.PriorityNormal {
+			v.addError(prefix+".priority", source.Priority, "invalid priority, must be one of: critical, normal")
+		}
+	}
+}
+
 // isValidKubernetesName validates Kubernetes resource names
 func (v *Validator) isValidKubernetesName(name string) bool {
 	if name == "" {
@@ -301,14 +587,15 @@ func (v *Validator) validateURL(repoURL string) error {
 	// Trim whitespace for robustness
 	repoURL = strings.TrimSpace(repoURL)
 
-	parsedURL, err := url.Parse(repoURL)
+	// Accepts git@host:owner/repo.git as well as URLs
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		return fmt.Errorf("invalid URL format: %w", err)
 	}
 
 	// Check scheme
-	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" {
-		return fmt.Errorf("URL scheme must be http or https")
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != "http" && parsedURL.Scheme != "ssh" {
+		return fmt.Errorf("URL scheme must be http, https or ssh")
 	}
 
 	// Check host
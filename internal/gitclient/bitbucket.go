@@ -0,0 +1,1155 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
+)
+
+// bitbucketCloudAPIURL is the API root for bitbucket.org
+const bitbucketCloudAPIURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketClient implements GitClient for Bitbucket Cloud (API 2.0) and
+// Bitbucket Data Center / Server (REST API 1.0)
+type BitbucketClient struct {
+	config      ClientConfig
+	httpClient  *http.Client
+	rateLimiter RateLimiter
+	fallback    *FallbackClient
+	baseURL     string
+	breaker     *CircuitBreaker // Shared by clients of the same API, nil when disabled
+	cloud       bool            // true for bitbucket.org, false for Data Center
+	logger      *logger.Entry
+}
+
+// BitbucketCloudBranch represents a branch in Bitbucket Cloud API response
+type BitbucketCloudBranch struct {
+	Name   string `json:"name"`
+	Target struct {
+		Hash string `json:"hash"`
+	} `json:"target"`
+}
+
+// BitbucketCloudRepository represents a repository in Bitbucket Cloud API response
+type BitbucketCloudRepository struct {
+	FullName   string `json:"full_name"`
+	MainBranch *struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"` // Unset for empty repositories
+}
+
+// BitbucketServerBranch represents a branch in Bitbucket Data Center API response
+type BitbucketServerBranch struct {
+	ID           string `json:"id"`
+	DisplayID    string `json:"displayId"`
+	LatestCommit string `json:"latestCommit"`
+	IsDefault    bool   `json:"isDefault"`
+}
+
+// BitbucketCloudPullRequest represents a pull request in Bitbucket Cloud API response.
+// Bitbucket Cloud has no pull request labels.
+type BitbucketCloudPullRequest struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	State  string `json:"state"` // OPEN, MERGED, DECLINED or SUPERSEDED
+	Draft  bool   `json:"draft"`
+	Source struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Commit struct {
+			Hash string `json:"hash"` // Abbreviated to 12 characters by the API
+		} `json:"commit"`
+	} `json:"source"`
+	Destination struct {
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+	} `json:"destination"`
+	Author struct {
+		Nickname string `json:"nickname"`
+	} `json:"author"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// BitbucketServerPullRequest represents a pull request in Bitbucket Data Center API response
+type BitbucketServerPullRequest struct {
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	State   string `json:"state"` // OPEN, MERGED or DECLINED
+	Draft   bool   `json:"draft"`
+	FromRef struct {
+		DisplayID    string `json:"displayId"`
+		LatestCommit string `json:"latestCommit"`
+	} `json:"fromRef"`
+	ToRef struct {
+		DisplayID string `json:"displayId"`
+	} `json:"toRef"`
+	Author struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	} `json:"author"`
+	Links struct {
+		Self []struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
+// bitbucketPullRequestState maps Bitbucket pull request states to ours
+func bitbucketPullRequestState(state string) string {
+	switch state {
+	case "MERGED":
+		return types.PullRequestStateMerged
+	case "OPEN":
+		return types.PullRequestStateOpen
+	default:
+		return types.PullRequestStateClosed
+	}
+}
+
+func (pr BitbucketCloudPullRequest) toPullRequest() types.PullRequest {
+	return types.PullRequest{
+		Number:       pr.ID,
+		Title:        pr.Title,
+		State:        bitbucketPullRequestState(pr.State),
+		HeadSHA:      pr.Source.Commit.Hash,
+		SourceBranch: pr.Source.Branch.Name,
+		TargetBranch: pr.Destination.Branch.Name,
+		Author:       pr.Author.Nickname,
+		Labels:       []string{},
+		Draft:        pr.Draft,
+		URL:          pr.Links.HTML.Href,
+	}
+}
+
+func (pr BitbucketServerPullRequest) toPullRequest() types.PullRequest {
+	pullRequest := types.PullRequest{
+		Number:       pr.ID,
+		Title:        pr.Title,
+		State:        bitbucketPullRequestState(pr.State),
+		HeadSHA:      pr.FromRef.LatestCommit,
+		SourceBranch: pr.FromRef.DisplayID,
+		TargetBranch: pr.ToRef.DisplayID,
+		Author:       pr.Author.User.Name,
+		Labels:       []string{},
+		Draft:        pr.Draft,
+	}
+	if len(pr.Links.Self) > 0 {
+		pullRequest.URL = pr.Links.Self[0].Href
+	}
+	return pullRequest
+}
+
+// BitbucketCloudCommit represents a commit in Bitbucket Cloud API response
+type BitbucketCloudCommit struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Date    time.Time `json:"date"`
+	Author  struct {
+		Raw string `json:"raw"` // "Name <email>"
+	} `json:"author"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+// BitbucketCloudDiffStat represents a changed file in Bitbucket Cloud's diffstat API response
+type BitbucketCloudDiffStat struct {
+	Status string `json:"status"` // added, removed, modified or renamed
+	Old    *struct {
+		Path string `json:"path"`
+	} `json:"old"` // Unset for added files
+	New *struct {
+		Path string `json:"path"`
+	} `json:"new"` // Unset for removed files
+}
+
+// BitbucketServerCommit represents a commit in Bitbucket Data Center API response
+type BitbucketServerCommit struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+	Author  struct {
+		Name         string `json:"name"`
+		EmailAddress string `json:"emailAddress"`
+	} `json:"author"`
+	AuthorTimestamp int64 `json:"authorTimestamp"` // Milliseconds since the epoch
+}
+
+// BitbucketServerChange represents a changed file in Bitbucket Data Center's compare API response
+type BitbucketServerChange struct {
+	Type string `json:"type"` // ADD, DELETE, MODIFY, MOVE or COPY
+	Path struct {
+		ToString string `json:"toString"`
+	} `json:"path"`
+	SrcPath *struct {
+		ToString string `json:"toString"`
+	} `json:"srcPath"` // Set for moved and copied files
+}
+
+func (commit BitbucketCloudCommit) toCommit() types.Commit {
+	name, email := splitGitAuthor(commit.Author.Raw)
+	return types.Commit{
+		SHA:         commit.Hash,
+		Message:     commit.Message,
+		AuthorName:  name,
+		AuthorEmail: email,
+		Timestamp:   commit.Date,
+		URL:         commit.Links.HTML.Href,
+	}
+}
+
+func (commit BitbucketServerCommit) toCommit() types.Commit {
+	return types.Commit{
+		SHA:         commit.ID,
+		Message:     commit.Message,
+		AuthorName:  commit.Author.Name,
+		AuthorEmail: commit.Author.EmailAddress,
+		Timestamp:   time.UnixMilli(commit.AuthorTimestamp).UTC(),
+	}
+}
+
+// splitGitAuthor splits a "Name <email>" author into its name and email
+func splitGitAuthor(raw string) (string, string) {
+	raw = strings.TrimSpace(raw)
+	open := strings.LastIndex(raw, "<")
+	if open < 0 || !strings.HasSuffix(raw, ">") {
+		return raw, ""
+	}
+	return strings.TrimSpace(raw[:open]), raw[open+1 : len(raw)-1]
+}
+
+// BitbucketCloudTreeEntry represents an entry of Bitbucket Cloud's src API response
+type BitbucketCloudTreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"` // commit_file or commit_directory
+}
+
+// bitbucketCloudPage is the paging envelope used by Bitbucket Cloud
+type bitbucketCloudPage struct {
+	Values json.RawMessage `json:"values"`
+	Next   string          `json:"next"`
+}
+
+// bitbucketServerPage is the paging envelope used by Bitbucket Data Center
+type bitbucketServerPage struct {
+	Values        json.RawMessage `json:"values"`
+	IsLastPage    bool            `json:"isLastPage"`
+	NextPageStart int             `json:"nextPageStart"`
+}
+
+// NewBitbucketClient creates a new Bitbucket client
+func NewBitbucketClient(config ClientConfig, rateLimiter RateLimiter, fallback *FallbackClient, parentLogger *logger.Entry) (*BitbucketClient, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("Bitbucket token is required")
+	}
+
+	baseURL := strings.TrimSuffix(config.BaseURL, "/")
+	cloud := true
+	if baseURL != "" {
+		baseURL, cloud = resolveBitbucketAPIURL(baseURL)
+	} else if config.RepositoryURL != "" {
+		baseURL, cloud = extractBitbucketAPIURL(config.RepositoryURL)
+	} else {
+		baseURL = bitbucketCloudAPIURL
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	flavor := "cloud"
+	if !cloud {
+		flavor = "datacenter"
+	}
+
+	clientLogger := parentLogger.WithFields(logger.Fields{
+		"component": "gitclient",
+		"provider":  "bitbucket",
+		"flavor":    flavor,
+		"base_url":  baseURL,
+	})
+
+	clientLogger.Info("Initializing Bitbucket client")
+
+	return &BitbucketClient{
+		config:      config,
+		httpClient:  httpClient,
+		rateLimiter: rateLimiter,
+		fallback:    fallback,
+		baseURL:     baseURL,
+		cloud:       cloud,
+		logger:      clientLogger,
+	}, nil
+}
+
+// GetBranches retrieves all branches for a repository
+func (c *BitbucketClient) GetBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetBranches(ctx, repo)
+		}
+		return nil, err
+	}
+
+	var branches []types.Branch
+	if c.cloud {
+		err = c.paginate(ctx, repoAPI+"/refs/branches", func(values json.RawMessage) error {
+			var page []BitbucketCloudBranch
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, b := range page {
+				branches = append(branches, types.Branch{Name: b.Name, CommitSHA: b.Target.Hash})
+			}
+			return nil
+		})
+	} else {
+		err = c.paginate(ctx, repoAPI+"/branches", func(values json.RawMessage) error {
+			var page []BitbucketServerBranch
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, b := range page {
+				branches = append(branches, types.Branch{Name: b.DisplayID, CommitSHA: b.LatestCommit})
+			}
+			return nil
+		})
+	}
+	if IsIncompleteListing(err) {
+		// The branches listed before the page cap are still current
+		return branches, err
+	}
+	if err != nil {
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "get_branches",
+			"repository": repo.Name,
+		}).Error("API request failed")
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetBranches(ctx, repo)
+		}
+		return nil, err
+	}
+
+	c.logger.WithFields(logger.Fields{
+		"operation":    "get_branches",
+		"repository":   repo.Name,
+		"branch_count": len(branches),
+	}).Info("Successfully retrieved branches")
+
+	return branches, nil
+}
+
+// GetTags retrieves all tags for a repository. Both API flavors return tags
+// in the same shape as branches.
+func (c *BitbucketClient) GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetTags(ctx, repo)
+		}
+		return nil, err
+	}
+
+	tags := []types.Tag{}
+	if c.cloud {
+		err = c.paginate(ctx, repoAPI+"/refs/tags", func(values json.RawMessage) error {
+			var page []BitbucketCloudBranch
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, t := range page {
+				tags = append(tags, types.Tag{Name: t.Name, CommitSHA: t.Target.Hash})
+			}
+			return nil
+		})
+	} else {
+		err = c.paginate(ctx, repoAPI+"/tags", func(values json.RawMessage) error {
+			var page []BitbucketServerBranch
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, t := range page {
+				tags = append(tags, types.Tag{Name: t.DisplayID, CommitSHA: t.LatestCommit})
+			}
+			return nil
+		})
+	}
+	if IsIncompleteListing(err) {
+		// The tags listed before the page cap are still current
+		return tags, err
+	}
+	if err != nil {
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "get_tags",
+			"repository": repo.Name,
+		}).Error("API request failed")
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetTags(ctx, repo)
+		}
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// CompareCommits retrieves the commits reachable from head but not from base,
+// and the files they change. Bitbucket does not count the commits in a range,
+// so a commit list cut short by the page cap keeps the newest commits and
+// TotalCommits counts only those. A file list cut short is marked truncated.
+func (c *BitbucketClient) CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &types.CommitComparison{}
+	var commits []types.Commit // Newest first, as Bitbucket lists them
+	if c.cloud {
+		endpoint := fmt.Sprintf("%s/commits?include=%s&exclude=%s", repoAPI, url.QueryEscape(head), url.QueryEscape(base))
+		err = c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []BitbucketCloudCommit
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, commit := range page {
+				commits = append(commits, commit.toCommit())
+			}
+			return nil
+		})
+	} else {
+		endpoint := fmt.Sprintf("%s/commits?since=%s&until=%s", repoAPI, url.QueryEscape(base), url.QueryEscape(head))
+		err = c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []BitbucketServerCommit
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, commit := range page {
+				commits = append(commits, commit.toCommit())
+			}
+			return nil
+		})
+	}
+	if err != nil && !IsIncompleteListing(err) {
+		return nil, err
+	}
+
+	for i := len(commits) - 1; i >= 0; i-- {
+		comparison.Commits = append(comparison.Commits, commits[i])
+	}
+	comparison.TotalCommits = len(comparison.Commits)
+
+	if c.cloud {
+		// Cloud specs name the new revision first: the diff of head since its merge base with base
+		endpoint := fmt.Sprintf("%s/diffstat/%s..%s", repoAPI, url.PathEscape(head), url.PathEscape(base))
+		err = c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []BitbucketCloudDiffStat
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, stat := range page {
+				switch {
+				case stat.New == nil:
+					if stat.Old != nil {
+						comparison.AddFile(stat.Old.Path, "removed")
+					}
+				case stat.Old == nil:
+					comparison.AddFile(stat.New.Path, "added")
+				case stat.Status == "renamed":
+					comparison.AddFile(stat.Old.Path, "removed")
+					comparison.AddFile(stat.New.Path, "added")
+				default:
+					comparison.AddFile(stat.New.Path, "modified")
+				}
+			}
+			return nil
+		})
+	} else {
+		// Data Center lists the changes in from that are not in to
+		endpoint := fmt.Sprintf("%s/compare/changes?from=%s&to=%s", repoAPI, url.QueryEscape(head), url.QueryEscape(base))
+		err = c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []BitbucketServerChange
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, change := range page {
+				switch change.Type {
+				case "ADD", "COPY":
+					comparison.AddFile(change.Path.ToString, "added")
+				case "DELETE":
+					comparison.AddFile(change.Path.ToString, "removed")
+				case "MOVE":
+					if change.SrcPath != nil {
+						comparison.AddFile(change.SrcPath.ToString, "removed")
+					}
+					comparison.AddFile(change.Path.ToString, "added")
+				default:
+					comparison.AddFile(change.Path.ToString, "modified")
+				}
+			}
+			return nil
+		})
+	}
+	if IsIncompleteListing(err) {
+		comparison.FilesTruncated = true
+	} else if err != nil {
+		return nil, err
+	}
+
+	comparison.AssignFilesToSingleCommit()
+	return comparison, nil
+}
+
+// GetLatestCommit retrieves the latest commit SHA for a branch
+func (c *BitbucketClient) GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetLatestCommit(ctx, repo, branch)
+		}
+		return "", err
+	}
+
+	var commitSHA string
+	if c.cloud {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return "", err
+		}
+
+		var cloudBranch BitbucketCloudBranch
+		err = c.makeRequest(ctx, "GET", repoAPI+"/refs/branches/"+escapePathSegments(branch), &cloudBranch)
+		commitSHA = cloudBranch.Target.Hash
+	} else {
+		// Data Center has no single-branch endpoint; filter the list instead
+		endpoint := repoAPI + "/branches?filterText=" + url.QueryEscape(branch)
+		err = c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []BitbucketServerBranch
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, b := range page {
+				if b.DisplayID == branch {
+					commitSHA = b.LatestCommit
+				}
+			}
+			return nil
+		})
+		if err == nil && commitSHA == "" {
+			err = &RepositoryNotFoundError{Repository: repo.URL + "@" + branch, Provider: "bitbucket"}
+		}
+	}
+	if err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetLatestCommit(ctx, repo, branch)
+		}
+		return "", err
+	}
+
+	return commitSHA, nil
+}
+
+// ListPullRequests retrieves open pull requests for a repository
+func (c *BitbucketClient) ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	pullRequests := []types.PullRequest{}
+	if c.cloud {
+		err = c.paginate(ctx, repoAPI+"/pullrequests?state=OPEN", func(values json.RawMessage) error {
+			var page []BitbucketCloudPullRequest
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, pr := range page {
+				pullRequests = append(pullRequests, pr.toPullRequest())
+			}
+			return nil
+		})
+	} else {
+		err = c.paginate(ctx, repoAPI+"/pull-requests?state=OPEN", func(values json.RawMessage) error {
+			var page []BitbucketServerPullRequest
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, pr := range page {
+				pullRequests = append(pullRequests, pr.toPullRequest())
+			}
+			return nil
+		})
+	}
+	if IsIncompleteListing(err) {
+		// The pull requests listed before the page cap are still open
+		return pullRequests, err
+	}
+	if err != nil {
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "list_pull_requests",
+			"repository": repo.Name,
+		}).Error("API request failed")
+		return nil, err
+	}
+
+	return pullRequests, nil
+}
+
+// GetPullRequest retrieves a single pull request
+func (c *BitbucketClient) GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	var pullRequest types.PullRequest
+	if c.cloud {
+		var cloudPull BitbucketCloudPullRequest
+		if err := c.makeRequest(ctx, "GET", fmt.Sprintf("%s/pullrequests/%d", repoAPI, number), &cloudPull); err != nil {
+			return nil, err
+		}
+		pullRequest = cloudPull.toPullRequest()
+	} else {
+		var serverPull BitbucketServerPullRequest
+		if err := c.makeRequest(ctx, "GET", fmt.Sprintf("%s/pull-requests/%d", repoAPI, number), &serverPull); err != nil {
+			return nil, err
+		}
+		pullRequest = serverPull.toPullRequest()
+	}
+
+	return &pullRequest, nil
+}
+
+// GetDefaultBranch retrieves the name of the repository's default branch
+func (c *BitbucketClient) GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetDefaultBranch(ctx, repo)
+		}
+		return "", err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	var defaultBranch string
+	if c.cloud {
+		var cloudRepo BitbucketCloudRepository
+		err = c.makeRequest(ctx, "GET", repoAPI, &cloudRepo)
+		if cloudRepo.MainBranch != nil {
+			defaultBranch = cloudRepo.MainBranch.Name
+		}
+	} else {
+		var serverBranch BitbucketServerBranch
+		err = c.makeRequest(ctx, "GET", repoAPI+"/default-branch", &serverBranch)
+		defaultBranch = serverBranch.DisplayID
+	}
+	if err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetDefaultBranch(ctx, repo)
+		}
+		return "", err
+	}
+
+	if defaultBranch == "" {
+		return "", fmt.Errorf("repository %s has no default branch", repo.Name)
+	}
+	return defaultBranch, nil
+}
+
+// CheckPermissions verifies if the client has access to the repository
+func (c *BitbucketClient) CheckPermissions(ctx context.Context, repo types.Repository) error {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return c.makeRequest(ctx, "GET", repoAPI, nil)
+}
+
+// GetRateLimit returns current rate limit status
+func (c *BitbucketClient) GetRateLimit(ctx context.Context) (*types.RateLimit, error) {
+	// Bitbucket doesn't have a dedicated rate limit endpoint
+	// We return the current state from our rate limiter
+	limitInfo := c.rateLimiter.GetLimit()
+
+	return &types.RateLimit{
+		Limit:     limitInfo.Limit,
+		Remaining: limitInfo.Remaining,
+		Reset:     limitInfo.ResetTime,
+	}, nil
+}
+
+// GetProvider returns the provider name
+func (c *BitbucketClient) GetProvider() string {
+	return "bitbucket"
+}
+
+// Close releases any resources
+func (c *BitbucketClient) Close() error {
+	return nil
+}
+
+// ListFiles retrieves all files in a specific path for a commit
+func (c *BitbucketClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	// Neither listing reports blob SHAs, so the entries carry paths only
+	path = strings.Trim(path, "/")
+	var files []FileEntry
+
+	if !c.cloud {
+		// Data Center lists files recursively, relative to the requested path
+		endpoint := fmt.Sprintf("%s/files/%s?at=%s", repoAPI, escapePathSegments(path), url.QueryEscape(commitSHA))
+		err := c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []string
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, file := range page {
+				if path != "" {
+					file = path + "/" + file
+				}
+				files = append(files, FileEntry{Path: file})
+			}
+			return nil
+		})
+		if err != nil {
+			if c.config.EnableFallback && IsRetryableError(err) {
+				c.logger.Info("Attempting fallback after API failure")
+				return c.fallback.ListFiles(ctx, repo, commitSHA, path)
+			}
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+		return files, nil
+	}
+
+	// Cloud lists one directory level at a time, so walk the tree
+	pending := []string{path}
+	for len(pending) > 0 {
+		dir := pending[0]
+		pending = pending[1:]
+
+		endpoint := fmt.Sprintf("%s/src/%s/%s", repoAPI, url.PathEscape(commitSHA), escapePathSegments(dir))
+		if !strings.HasSuffix(endpoint, "/") {
+			endpoint += "/"
+		}
+
+		err := c.paginate(ctx, endpoint, func(values json.RawMessage) error {
+			var page []BitbucketCloudTreeEntry
+			if err := json.Unmarshal(values, &page); err != nil {
+				return err
+			}
+			for _, entry := range page {
+				switch entry.Type {
+				case "commit_file":
+					files = append(files, FileEntry{Path: entry.Path})
+				case "commit_directory":
+					pending = append(pending, entry.Path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if c.config.EnableFallback && IsRetryableError(err) {
+				c.logger.Info("Attempting fallback after API failure")
+				return c.fallback.ListFiles(ctx, repo, commitSHA, path)
+			}
+			return nil, fmt.Errorf("failed to list files: %w", err)
+		}
+	}
+
+	return files, nil
+}
+
+// GetFileContent retrieves the content of a specific file
+func (c *BitbucketClient) GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	filePath = strings.Trim(filePath, "/")
+
+	var apiURL string
+	if c.cloud {
+		apiURL = fmt.Sprintf("%s/src/%s/%s", repoAPI, url.PathEscape(commitSHA), escapePathSegments(filePath))
+	} else {
+		apiURL = fmt.Sprintf("%s/raw/%s?at=%s", repoAPI, escapePathSegments(filePath), url.QueryEscape(commitSHA))
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	if err := c.makeRequest(ctx, "GET", apiURL, &content); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.GetFileContent(ctx, repo, commitSHA, filePath)
+		}
+		return nil, fmt.Errorf("failed to get file content: %w", err)
+	}
+
+	return content, nil
+}
+
+// CheckDirectoryExists checks if a directory exists in the repository
+func (c *BitbucketClient) CheckDirectoryExists(ctx context.Context, repo types.Repository, commitSHA, dirPath string) (bool, error) {
+	repoAPI, err := c.repoAPIURL(repo.URL)
+	if err != nil {
+		return false, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	dirPath = strings.Trim(dirPath, "/")
+
+	var apiURL string
+	if c.cloud {
+		apiURL = fmt.Sprintf("%s/src/%s/%s?format=meta", repoAPI, url.PathEscape(commitSHA), escapePathSegments(dirPath))
+	} else {
+		apiURL = fmt.Sprintf("%s/browse/%s?at=%s&type=true", repoAPI, escapePathSegments(dirPath), url.QueryEscape(commitSHA))
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return false, err
+	}
+
+	var meta struct {
+		Type string `json:"type"`
+	}
+	if err := c.makeRequest(ctx, "GET", apiURL, &meta); err != nil {
+		// A missing path is reported as 404
+		if _, ok := err.(*RepositoryNotFoundError); ok {
+			return false, nil
+		}
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.CheckDirectoryExists(ctx, repo, commitSHA, dirPath)
+		}
+		return false, fmt.Errorf("failed to check directory: %w", err)
+	}
+
+	return meta.Type == "commit_directory" || meta.Type == "DIRECTORY", nil
+}
+
+// paginate fetches every page of a Bitbucket list endpoint and passes the
+// values of each page to collect. Each page waits on the shared rate limiter.
+// Stopping at the page cap returns an IncompleteListingError once the pages
+// before it have been collected.
+func (c *BitbucketClient) paginate(ctx context.Context, endpoint string, collect func(values json.RawMessage) error) error {
+	nextURL := appendQuery(endpoint, c.pageQuery(0))
+
+	for page := 1; nextURL != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation": "paginate",
+				"endpoint":  endpoint,
+				"max_pages": c.config.maxPages(),
+			}).Warn("Reached page limit, results are incomplete")
+			return &IncompleteListingError{
+				Provider: "bitbucket",
+				Resource: endpoint,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		if c.cloud {
+			var result bitbucketCloudPage
+			if err := c.makeRequest(ctx, "GET", nextURL, &result); err != nil {
+				return err
+			}
+			if err := collect(result.Values); err != nil {
+				return &NetworkError{Provider: "bitbucket", Err: err}
+			}
+			nextURL = result.Next
+		} else {
+			var result bitbucketServerPage
+			if err := c.makeRequest(ctx, "GET", nextURL, &result); err != nil {
+				return err
+			}
+			if err := collect(result.Values); err != nil {
+				return &NetworkError{Provider: "bitbucket", Err: err}
+			}
+			nextURL = ""
+			if !result.IsLastPage {
+				nextURL = appendQuery(endpoint, c.pageQuery(result.NextPageStart))
+			}
+		}
+	}
+
+	return nil
+}
+
+// pageQuery returns the paging query parameters for the API flavor
+func (c *BitbucketClient) pageQuery(start int) string {
+	if c.cloud {
+		return fmt.Sprintf("pagelen=%d", c.config.pageSize())
+	}
+	return fmt.Sprintf("limit=%d&start=%d", c.config.pageSize(), start)
+}
+
+// makeRequest makes an HTTP request to the Bitbucket API. If result is a
+// *[]byte the raw response body is stored, otherwise it is decoded as JSON.
+func (c *BitbucketClient) makeRequest(ctx context.Context, method, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return &NetworkError{Provider: "bitbucket", Err: err}
+	}
+
+	// Set headers
+	c.setAuth(req)
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	// Retry logic
+	var lastErr error
+	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		// Fail fast while the API is known to be down
+		ticket, err := c.breaker.Allow()
+		if err != nil {
+			return err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		c.breaker.RecordResponse(ticket, resp, err)
+		if err != nil {
+			lastErr = &NetworkError{Provider: "bitbucket", Err: err}
+			if attempt < c.config.RetryAttempts {
+				continue
+			}
+			return lastErr
+		}
+
+		// Update rate limiter from headers
+		c.updateRateLimitFromHeaders(resp.Header)
+
+		// Handle different status codes
+		switch resp.StatusCode {
+		case http.StatusOK:
+			defer resp.Body.Close()
+			switch out := result.(type) {
+			case nil:
+				return nil
+			case *[]byte:
+				data, err := io.ReadAll(resp.Body)
+				if err != nil {
+					return &NetworkError{Provider: "bitbucket", Err: err}
+				}
+				*out = data
+				return nil
+			default:
+				if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+					return &NetworkError{Provider: "bitbucket", Err: err}
+				}
+				return nil
+			}
+		case http.StatusUnauthorized, http.StatusForbidden:
+			resp.Body.Close()
+			return &AuthenticationError{Provider: "bitbucket", Message: "invalid or insufficient permissions"}
+		case http.StatusNotFound:
+			resp.Body.Close()
+			return &RepositoryNotFoundError{Repository: url, Provider: "bitbucket"}
+		case http.StatusTooManyRequests:
+			resp.Body.Close()
+			resetTime := c.parseRetryAfter(resp.Header.Get("Retry-After"))
+			return &RateLimitExceededError{Provider: "bitbucket", ResetTime: resetTime}
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			resp.Body.Close()
+			lastErr = &NetworkError{Provider: "bitbucket", Err: fmt.Errorf("server error: %d", resp.StatusCode)}
+			if attempt < c.config.RetryAttempts {
+				continue
+			}
+			return lastErr
+		default:
+			resp.Body.Close()
+			return &NetworkError{Provider: "bitbucket", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+		}
+	}
+
+	return lastErr
+}
+
+// setAuth sets the authorization header. Tokens of the form "username:app_password"
+// use HTTP basic auth (Bitbucket Cloud app passwords); anything else is sent as a
+// bearer token (Cloud access tokens, Data Center HTTP access tokens).
+func (c *BitbucketClient) setAuth(req *http.Request) {
+	if username, password, ok := strings.Cut(c.config.Token, ":"); ok {
+		req.SetBasicAuth(username, password)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+c.config.Token)
+}
+
+// repoAPIURL returns the API URL of the repository resource
+func (c *BitbucketClient) repoAPIURL(repoURL string) (string, error) {
+	owner, slug, err := c.parseRepoURL(repoURL)
+	if err != nil {
+		return "", err
+	}
+
+	if c.cloud {
+		return fmt.Sprintf("%s/repositories/%s/%s", c.baseURL, owner, slug), nil
+	}
+
+	// Personal repositories are addressed as ~username
+	if strings.HasPrefix(owner, "~") {
+		return fmt.Sprintf("%s/users/%s/repos/%s", c.baseURL, strings.TrimPrefix(owner, "~"), slug), nil
+	}
+	return fmt.Sprintf("%s/projects/%s/repos/%s", c.baseURL, owner, slug), nil
+}
+
+// parseRepoURL extracts the workspace (Cloud) or project key (Data Center)
+// and the repository slug from a Bitbucket URL
+func (c *BitbucketClient) parseRepoURL(repoURL string) (owner, slug string, err error) {
+	parsedURL, err := utils.ParseGitURL(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	owner, slug, _, err = parseBitbucketPath(parsedURL.Path)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid Bitbucket repository URL format: %s", repoURL)
+	}
+
+	return owner, slug, nil
+}
+
+// parseBitbucketPath splits a Bitbucket Cloud path, /{workspace}/{slug}, or a
+// Data Center path in any of the layouts utils.ParseBitbucketServerPath
+// understands. It returns the owner, repository slug and any context path prefix.
+func parseBitbucketPath(repoPath string) (owner, slug, contextPath string, err error) {
+	if owner, slug, contextPath, ok := utils.ParseBitbucketServerPath(repoPath); ok {
+		return owner, slug, contextPath, nil
+	}
+
+	parts := strings.Split(strings.Trim(repoPath, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", "", fmt.Errorf("invalid Bitbucket repository path: %s", repoPath)
+	}
+
+	return parts[0], strings.TrimSuffix(parts[1], ".git"), "", nil
+}
+
+// isBitbucketCloudHost reports whether host is Bitbucket Cloud
+func isBitbucketCloudHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "bitbucket.org" || host == "www.bitbucket.org" || host == "api.bitbucket.org"
+}
+
+// resolveBitbucketAPIURL completes a configured API base URL and reports whether
+// it points to Bitbucket Cloud. Cloud is recognized by its host or the /2.0 API
+// version; any other host is Data Center, whose REST API lives under
+// /rest/api/1.0 and may be configured as just the server or context path.
+func resolveBitbucketAPIURL(baseURL string) (string, bool) {
+	parsedURL, err := url.Parse(baseURL)
+	if err != nil {
+		return baseURL, false
+	}
+
+	if isBitbucketCloudHost(parsedURL.Hostname()) {
+		if !strings.HasSuffix(parsedURL.Path, "/2.0") {
+			return bitbucketCloudAPIURL, true
+		}
+		return baseURL, true
+	}
+	if strings.HasSuffix(parsedURL.Path, "/2.0") {
+		// A Cloud API stand-in, such as a proxy or test server
+		return baseURL, true
+	}
+
+	if !strings.Contains(parsedURL.Path, "/rest/api") {
+		baseURL += "/rest/api/1.0"
+	}
+	return baseURL, false
+}
+
+// extractBitbucketAPIURL derives the API URL from a repository URL and reports
+// whether it points to Bitbucket Cloud
+func extractBitbucketAPIURL(repoURL string) (string, bool) {
+	parsedURL, err := utils.ParseGitURL(repoURL)
+	if err != nil || parsedURL.Host == "" || isBitbucketCloudHost(parsedURL.Hostname()) {
+		return bitbucketCloudAPIURL, true
+	}
+
+	// Data Center SSH URLs (ssh://git@host:7999/key/slug.git) carry no context path
+	contextPath := ""
+	if parsedURL.Scheme != "ssh" {
+		_, _, contextPath, _ = parseBitbucketPath(parsedURL.Path)
+	}
+	return fmt.Sprintf("%s%s/rest/api/1.0", utils.WebBaseURL(parsedURL), contextPath), false
+}
+
+// escapePathSegments escapes each segment of a slash separated path
+func escapePathSegments(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// updateRateLimitFromHeaders updates the rate limiter based on response headers
+func (c *BitbucketClient) updateRateLimitFromHeaders(headers http.Header) {
+	limitStr := headers.Get("X-RateLimit-Limit")
+	remainingStr := headers.Get("X-RateLimit-Remaining")
+
+	if limitStr != "" && remainingStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			if remaining, err := strconv.Atoi(remainingStr); err == nil {
+				// Bitbucket uses a rolling one hour window without a reset header
+				c.rateLimiter.UpdateLimit(limit, remaining, time.Now().Add(time.Hour))
+			}
+		}
+	}
+}
+
+// parseRetryAfter parses the Retry-After header (seconds) into a reset time
+func (c *BitbucketClient) parseRetryAfter(retryAfter string) time.Time {
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second)
+	}
+	return time.Now().Add(time.Hour) // Default to 1 hour if parsing fails
+}
@@ -0,0 +1,322 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBitbucketPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		path        string
+		owner       string
+		slug        string
+		contextPath string
+		wantErr     bool
+	}{
+		{"cloud", "/workspace/repo", "workspace", "repo", "", false},
+		{"cloud with .git", "/workspace/repo.git", "workspace", "repo", "", false},
+		{"data center web", "/projects/PROJ/repos/service/browse", "PROJ", "service", "", false},
+		{"data center personal", "/users/jdoe/repos/dotfiles", "~jdoe", "dotfiles", "", false},
+		{"data center clone", "/scm/proj/service.git", "proj", "service", "", false},
+		{"data center context path", "/bitbucket/scm/proj/service.git", "proj", "service", "/bitbucket", false},
+		{"missing slug", "/workspace", "", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, slug, contextPath, err := parseBitbucketPath(tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.owner, owner)
+			assert.Equal(t, tt.slug, slug)
+			assert.Equal(t, tt.contextPath, contextPath)
+		})
+	}
+}
+
+func TestExtractBitbucketAPIURL(t *testing.T) {
+	apiURL, cloud := extractBitbucketAPIURL("https://bitbucket.org/workspace/repo")
+	assert.True(t, cloud)
+	assert.Equal(t, "https://api.bitbucket.org/2.0", apiURL)
+
+	apiURL, cloud = extractBitbucketAPIURL("https://git.company.com/bitbucket/projects/PROJ/repos/service")
+	assert.False(t, cloud)
+	assert.Equal(t, "https://git.company.com/bitbucket/rest/api/1.0", apiURL)
+
+	apiURL, cloud = extractBitbucketAPIURL("git@bitbucket.org:workspace/repo.git")
+	assert.True(t, cloud)
+	assert.Equal(t, "https://api.bitbucket.org/2.0", apiURL)
+
+	apiURL, cloud = extractBitbucketAPIURL("ssh://git@git.company.com:7999/proj/service.git")
+	assert.False(t, cloud)
+	assert.Equal(t, "https://git.company.com/rest/api/1.0", apiURL)
+}
+
+func TestResolveBitbucketAPIURL(t *testing.T) {
+	tests := []struct {
+		baseURL string
+		apiURL  string
+		cloud   bool
+	}{
+		{"https://api.bitbucket.org/2.0", "https://api.bitbucket.org/2.0", true},
+		{"https://bitbucket.org", "https://api.bitbucket.org/2.0", true},
+		{"https://bitbucket.company.com/rest/api/1.0", "https://bitbucket.company.com/rest/api/1.0", false},
+		{"https://bitbucket.company.com", "https://bitbucket.company.com/rest/api/1.0", false},
+		{"https://git.company.com/bitbucket", "https://git.company.com/bitbucket/rest/api/1.0", false},
+	}
+
+	for _, tt := range tests {
+		apiURL, cloud := resolveBitbucketAPIURL(tt.baseURL)
+		assert.Equal(t, tt.apiURL, apiURL, tt.baseURL)
+		assert.Equal(t, tt.cloud, cloud, tt.baseURL)
+	}
+}
+
+func newBitbucketTestClient(t *testing.T, baseURL, token string) *BitbucketClient {
+	config := GetDefaultConfig()
+	config.Token = token
+	config.BaseURL = baseURL
+	config.EnableFallback = false
+	config.RetryAttempts = 0
+
+	client, err := NewBitbucketClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	return client
+}
+
+func TestBitbucketClient_Cloud(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "bot" || pass != "app-password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		const repoAPI = "/2.0/repositories/workspace/repo"
+		switch {
+		case r.URL.Path == repoAPI+"/refs/branches" && r.URL.Query().Get("page") == "":
+			fmt.Fprintf(w, `{"values":[{"name":"main","target":{"hash":"aaa"}}],"next":"%s%s/refs/branches?pagelen=100&page=2"}`, server.URL, repoAPI)
+		case r.URL.Path == repoAPI+"/refs/branches":
+			fmt.Fprint(w, `{"values":[{"name":"feature/x","target":{"hash":"bbb"}}]}`)
+		case r.URL.Path == repoAPI+"/refs/branches/feature/x":
+			fmt.Fprint(w, `{"name":"feature/x","target":{"hash":"bbb"}}`)
+		case r.URL.Path == repoAPI:
+			fmt.Fprint(w, `{"full_name":"workspace/repo","mainbranch":{"name":"main"}}`)
+		case r.URL.Path == repoAPI+"/src/aaa/.tekton" && r.URL.Query().Get("format") == "meta":
+			fmt.Fprint(w, `{"path":".tekton","type":"commit_directory"}`)
+		case r.URL.Path == repoAPI+"/src/aaa/.tekton/":
+			fmt.Fprint(w, `{"values":[{"path":".tekton/pipeline.yaml","type":"commit_file"},{"path":".tekton/tasks","type":"commit_directory"}]}`)
+		case r.URL.Path == repoAPI+"/src/aaa/.tekton/tasks/":
+			fmt.Fprint(w, `{"values":[{"path":".tekton/tasks/build.yaml","type":"commit_file"}]}`)
+		case r.URL.Path == repoAPI+"/src/aaa/.tekton/pipeline.yaml":
+			fmt.Fprint(w, "kind: Pipeline\n")
+		case r.URL.Path == repoAPI+"/commits":
+			assert.Equal(t, "bbb", r.URL.Query().Get("include"))
+			assert.Equal(t, "aaa", r.URL.Query().Get("exclude"))
+			fmt.Fprint(w, `{"values":[
+				{"hash":"bbb","message":"Add build task","date":"2024-05-02T10:00:00+00:00","author":{"raw":"Dev One <dev@example.com>"},
+					"links":{"html":{"href":"https://bitbucket.org/workspace/repo/commits/bbb"}}},
+				{"hash":"abc","message":"Rename pipeline","date":"2024-05-01T10:00:00+00:00","author":{"raw":"ci-bot"}}]}`)
+		case r.URL.Path == repoAPI+"/diffstat/bbb..aaa":
+			fmt.Fprint(w, `{"values":[
+				{"status":"added","new":{"path":".tekton/tasks/build.yaml"}},
+				{"status":"removed","old":{"path":"ci/old.yaml"}},
+				{"status":"renamed","old":{"path":"pipeline.yaml"},"new":{"path":".tekton/pipeline.yaml"}},
+				{"status":"modified","old":{"path":"README.md"},"new":{"path":"README.md"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newBitbucketTestClient(t, server.URL+"/2.0", "bot:app-password")
+	assert.True(t, client.cloud)
+	assert.Equal(t, "bitbucket", client.GetProvider())
+
+	repo := types.Repository{Name: "repo", URL: "https://bitbucket.org/workspace/repo", Provider: "bitbucket"}
+	ctx := context.Background()
+
+	branches, err := client.GetBranches(ctx, repo)
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	assert.Equal(t, types.Branch{Name: "main", CommitSHA: "aaa"}, branches[0])
+	assert.Equal(t, types.Branch{Name: "feature/x", CommitSHA: "bbb"}, branches[1])
+
+	sha, err := client.GetLatestCommit(ctx, repo, "feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, "bbb", sha)
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+
+	assert.NoError(t, client.CheckPermissions(ctx, repo))
+
+	exists, err := client.CheckDirectoryExists(ctx, repo, "aaa", ".tekton")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, "aaa", "missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	files, err := client.ListFiles(ctx, repo, "aaa", ".tekton")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{".tekton/pipeline.yaml", ".tekton/tasks/build.yaml"}, filePaths(files))
+
+	content, err := client.GetFileContent(ctx, repo, "aaa", ".tekton/pipeline.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Pipeline\n", string(content))
+
+	comparison, err := client.CompareCommits(ctx, repo, "aaa", "bbb")
+	require.NoError(t, err)
+	require.Len(t, comparison.Commits, 2)
+	assert.Equal(t, 2, comparison.TotalCommits)
+	assert.Equal(t, "abc", comparison.Commits[0].SHA, "oldest first")
+	assert.Equal(t, "ci-bot", comparison.Commits[0].AuthorName)
+	assert.True(t, comparison.Commits[0].Timestamp.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "Dev One", comparison.Commits[1].AuthorName)
+	assert.Equal(t, "dev@example.com", comparison.Commits[1].AuthorEmail)
+	assert.Equal(t, "https://bitbucket.org/workspace/repo/commits/bbb", comparison.Commits[1].URL)
+	assert.Equal(t, []string{".tekton/tasks/build.yaml", ".tekton/pipeline.yaml"}, comparison.Added)
+	assert.Equal(t, []string{"ci/old.yaml", "pipeline.yaml"}, comparison.Removed)
+	assert.Equal(t, []string{"README.md"}, comparison.Modified)
+
+	// Wrong credentials surface as authentication errors
+	badClient := newBitbucketTestClient(t, server.URL+"/2.0", "bot:wrong")
+	_, err = badClient.GetBranches(ctx, repo)
+	assert.IsType(t, &AuthenticationError{}, err)
+}
+
+func TestBitbucketClient_DataCenter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer dc-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		const repoAPI = "/rest/api/1.0/projects/PROJ/repos/service"
+		switch {
+		case r.URL.Path == repoAPI+"/branches" && r.URL.Query().Get("filterText") != "":
+			fmt.Fprint(w, `{"values":[{"displayId":"release/1.0","latestCommit":"ccc"},{"displayId":"release/1.0-rc","latestCommit":"ddd"}],"isLastPage":true}`)
+		case r.URL.Path == repoAPI+"/branches" && r.URL.Query().Get("start") == "0":
+			fmt.Fprint(w, `{"values":[{"displayId":"main","latestCommit":"aaa","isDefault":true}],"isLastPage":false,"nextPageStart":1}`)
+		case r.URL.Path == repoAPI+"/branches" && r.URL.Query().Get("start") == "1":
+			fmt.Fprint(w, `{"values":[{"displayId":"release/1.0","latestCommit":"ccc"}],"isLastPage":true}`)
+		case r.URL.Path == repoAPI:
+			fmt.Fprint(w, `{"slug":"service"}`)
+		case r.URL.Path == repoAPI+"/default-branch":
+			fmt.Fprint(w, `{"id":"refs/heads/main","displayId":"main"}`)
+		case r.URL.Path == repoAPI+"/browse/.tekton":
+			assert.Equal(t, "aaa", r.URL.Query().Get("at"))
+			fmt.Fprint(w, `{"type":"DIRECTORY"}`)
+		case r.URL.Path == repoAPI+"/files/.tekton":
+			fmt.Fprint(w, `{"values":["pipeline.yaml","tasks/build.yaml"],"isLastPage":true}`)
+		case r.URL.Path == repoAPI+"/raw/.tekton/pipeline.yaml":
+			fmt.Fprint(w, "kind: Pipeline\n")
+		case r.URL.Path == repoAPI+"/commits":
+			assert.Equal(t, "aaa", r.URL.Query().Get("since"))
+			assert.Equal(t, "ccc", r.URL.Query().Get("until"))
+			fmt.Fprint(w, `{"values":[
+				{"id":"ccc","message":"Move tasks","author":{"name":"Dev One","emailAddress":"dev@example.com"},"authorTimestamp":1714644000000}],
+				"isLastPage":true}`)
+		case r.URL.Path == repoAPI+"/compare/changes":
+			assert.Equal(t, "ccc", r.URL.Query().Get("from"))
+			assert.Equal(t, "aaa", r.URL.Query().Get("to"))
+			fmt.Fprint(w, `{"values":[
+				{"type":"ADD","path":{"toString":".tekton/tasks/test.yaml"}},
+				{"type":"MOVE","path":{"toString":".tekton/tasks/build.yaml"},"srcPath":{"toString":"tasks/build.yaml"}},
+				{"type":"DELETE","path":{"toString":"Jenkinsfile"}},
+				{"type":"MODIFY","path":{"toString":".tekton/pipeline.yaml"}}],
+				"isLastPage":true}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newBitbucketTestClient(t, server.URL+"/rest/api/1.0", "dc-token")
+	assert.False(t, client.cloud)
+
+	repo := types.Repository{
+		Name:     "service",
+		URL:      "https://bitbucket.company.com/scm/PROJ/service.git",
+		Provider: "bitbucket",
+	}
+	ctx := context.Background()
+
+	branches, err := client.GetBranches(ctx, repo)
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	assert.Equal(t, "main", branches[0].Name)
+	assert.Equal(t, "ccc", branches[1].CommitSHA)
+
+	sha, err := client.GetLatestCommit(ctx, repo, "release/1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "ccc", sha)
+
+	_, err = client.GetLatestCommit(ctx, repo, "release")
+	assert.IsType(t, &RepositoryNotFoundError{}, err)
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+
+	assert.NoError(t, client.CheckPermissions(ctx, repo))
+
+	exists, err := client.CheckDirectoryExists(ctx, repo, "aaa", ".tekton")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	files, err := client.ListFiles(ctx, repo, "aaa", ".tekton")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".tekton/pipeline.yaml", ".tekton/tasks/build.yaml"}, filePaths(files))
+
+	content, err := client.GetFileContent(ctx, repo, "aaa", ".tekton/pipeline.yaml")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(content), "kind: Pipeline"))
+
+	comparison, err := client.CompareCommits(ctx, repo, "aaa", "ccc")
+	require.NoError(t, err)
+	require.Len(t, comparison.Commits, 1)
+	commit := comparison.Commits[0]
+	assert.Equal(t, "ccc", commit.SHA)
+	assert.Equal(t, "dev@example.com", commit.AuthorEmail)
+	assert.True(t, commit.Timestamp.Equal(time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{".tekton/tasks/test.yaml", ".tekton/tasks/build.yaml"}, commit.Added, "a single commit gets the files")
+	assert.Equal(t, []string{"tasks/build.yaml", "Jenkinsfile"}, comparison.Removed)
+	assert.Equal(t, []string{".tekton/pipeline.yaml"}, comparison.Modified)
+}
+
+func TestClientFactory_CreateClient_Bitbucket(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	config := GetDefaultConfig()
+	config.Token = "token"
+
+	client, err := factory.CreateClient(types.Repository{
+		Name:     "service",
+		URL:      "https://bitbucket.company.com/projects/PROJ/repos/service",
+		Provider: "bitbucket",
+	}, config)
+	require.NoError(t, err)
+
+	bitbucketClient, ok := client.(*BitbucketClient)
+	require.True(t, ok)
+	assert.False(t, bitbucketClient.cloud)
+	assert.Equal(t, "https://bitbucket.company.com/rest/api/1.0", bitbucketClient.baseURL)
+	assert.Equal(t, "bitbucket", bitbucketClient.rateLimiter.GetLimit().Provider)
+}
@@ -0,0 +1,253 @@
+package gitclient
+
+import (
+	"container/list"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+)
+
+// DefaultBlobCacheMaxBytes bounds the in-memory blob cache when no size is configured
+const DefaultBlobCacheMaxBytes = 64 * 1024 * 1024
+
+// BlobCacheStats represents blob cache counters
+type BlobCacheStats struct {
+	Hits    int64 `json:"hits"`    // Contents served from memory or disk
+	Misses  int64 `json:"misses"`  // Contents that had to be downloaded
+	Entries int   `json:"entries"` // Blobs held in memory
+	Bytes   int64 `json:"bytes"`   // Size of the blobs held in memory
+}
+
+// BlobCache keeps file contents keyed by git blob SHA. A blob SHA identifies
+// the content itself, so entries never go stale and are shared by every
+// repository, branch and commit containing the same file. Recently used
+// blobs are kept in memory up to a size limit; when a directory is set every
+// blob is also written to disk so the cache survives restarts.
+type BlobCache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	bytes    int64
+	order    *list.List // Most recently used first
+	entries  map[string]*list.Element
+	dir      string
+	hits     int64
+	misses   int64
+	logger   *logger.Entry
+}
+
+// blobCacheEntry is a blob held in memory
+type blobCacheEntry struct {
+	sha     string
+	content []byte
+}
+
+// NewBlobCache creates a blob cache holding up to maxBytes in memory
+// (DefaultBlobCacheMaxBytes when 0). dir may be empty to keep blobs in memory only.
+func NewBlobCache(dir string, maxBytes int64, parentLogger *logger.Entry) (*BlobCache, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultBlobCacheMaxBytes
+	}
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create blob cache directory: %w", err)
+		}
+	}
+
+	return &BlobCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		dir:      dir,
+		logger: parentLogger.WithFields(logger.Fields{
+			"component": "gitclient",
+			"module":    "blob_cache",
+		}),
+	}, nil
+}
+
+// Get returns the content of a blob, loading it from disk if needed. It is
+// safe to call on a nil cache, which always misses.
+func (c *BlobCache) Get(sha string) ([]byte, bool) {
+	if c == nil || !isBlobSHA(sha) {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	if element, exists := c.entries[sha]; exists {
+		c.order.MoveToFront(element)
+		c.mu.Unlock()
+		atomic.AddInt64(&c.hits, 1)
+		return element.Value.(*blobCacheEntry).content, true
+	}
+	c.mu.Unlock()
+
+	if content, ok := c.load(sha); ok {
+		c.remember(sha, content)
+		atomic.AddInt64(&c.hits, 1)
+		return content, true
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+	return nil, false
+}
+
+// Put caches the content of a blob in memory and on disk if a directory is
+// configured. Contents without a valid SHA are ignored, as are contents that
+// do not match their SHA: the cache is shared by every repository, so one
+// server pairing a SHA with other content must not change what others read.
+func (c *BlobCache) Put(sha string, content []byte) {
+	if c == nil || !isBlobSHA(sha) {
+		return
+	}
+	if len(sha) == 40 && gitBlobSHA1(content) != sha {
+		c.logger.WithFields(logger.Fields{
+			"operation": "put_blob",
+			"sha":       sha,
+		}).Warn("Downloaded content does not match its blob SHA, not caching it")
+		return
+	}
+
+	c.remember(sha, content)
+
+	if c.dir != "" {
+		if err := c.save(sha, content); err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation": "save_blob",
+				"sha":       sha,
+			}).Warn("Failed to persist cached blob")
+		}
+	}
+}
+
+// Stats returns the cache's counters. It is safe to call on a nil cache.
+func (c *BlobCache) Stats() BlobCacheStats {
+	if c == nil {
+		return BlobCacheStats{}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return BlobCacheStats{
+		Hits:    atomic.LoadInt64(&c.hits),
+		Misses:  atomic.LoadInt64(&c.misses),
+		Entries: len(c.entries),
+		Bytes:   c.bytes,
+	}
+}
+
+// remember stores a blob in memory, evicting the least recently used blobs
+// until it fits. Blobs larger than the whole cache are not kept in memory.
+func (c *BlobCache) remember(sha string, content []byte) {
+	size := int64(len(content))
+	if size > c.maxBytes {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[sha]; exists {
+		c.order.MoveToFront(element)
+		return
+	}
+
+	for c.bytes+size > c.maxBytes && c.order.Len() > 0 {
+		oldest := c.order.Back()
+		entry := oldest.Value.(*blobCacheEntry)
+		c.order.Remove(oldest)
+		delete(c.entries, entry.sha)
+		c.bytes -= int64(len(entry.content))
+	}
+
+	c.entries[sha] = c.order.PushFront(&blobCacheEntry{sha: sha, content: content})
+	c.bytes += size
+}
+
+// path returns where a blob is stored on disk, fanned out by the first two
+// characters of its SHA like git's object directory
+func (c *BlobCache) path(sha string) string {
+	return filepath.Join(c.dir, sha[:2], sha[2:])
+}
+
+// load reads a blob from disk. Files whose content no longer matches their
+// SHA are removed.
+func (c *BlobCache) load(sha string) ([]byte, bool) {
+	if c.dir == "" {
+		return nil, false
+	}
+
+	content, err := os.ReadFile(c.path(sha))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation": "load_blob",
+				"sha":       sha,
+			}).Debug("Failed to read cached blob")
+		}
+		return nil, false
+	}
+
+	if len(sha) == 40 && gitBlobSHA1(content) != sha {
+		c.logger.WithFields(logger.Fields{
+			"operation": "load_blob",
+			"sha":       sha,
+		}).Warn("Cached blob does not match its SHA, discarding it")
+		os.Remove(c.path(sha))
+		return nil, false
+	}
+	return content, true
+}
+
+// save writes a blob to disk through a temporary file so readers never see
+// a partial blob
+func (c *BlobCache) save(sha string, content []byte) error {
+	target := c.path(sha)
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(target), ".blob-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), target); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// isBlobSHA reports whether sha is a hex SHA-1 or SHA-256 object name
+func isBlobSHA(sha string) bool {
+	if len(sha) != 40 && len(sha) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(sha)
+	return err == nil
+}
+
+// gitBlobSHA1 computes the SHA-1 object name git gives a blob with this content
+func gitBlobSHA1(content []byte) string {
+	h := sha1.New()
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,115 @@
+package gitclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobCache_GetPut(t *testing.T) {
+	cache, err := NewBlobCache("", 0, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	content := []byte("kind: Pipeline\n")
+	sha := gitBlobSHA1(content)
+
+	_, ok := cache.Get(sha)
+	assert.False(t, ok)
+
+	cache.Put(sha, content)
+	cached, ok := cache.Get(sha)
+	require.True(t, ok)
+	assert.Equal(t, content, cached)
+
+	// Entries without a usable SHA are never cached
+	cache.Put("", content)
+	cache.Put("../../etc/passwd", content)
+	_, ok = cache.Get("")
+	assert.False(t, ok)
+
+	// Nor is content that does not match its SHA
+	other := []byte("kind: Task\n")
+	otherSHA := gitBlobSHA1(other)
+	cache.Put(otherSHA, []byte("kind: Pipeline\nspec: {}\n"))
+	_, ok = cache.Get(otherSHA)
+	assert.False(t, ok)
+
+	stats := cache.Stats()
+	assert.Equal(t, int64(1), stats.Hits)
+	assert.Equal(t, int64(2), stats.Misses)
+	assert.Equal(t, 1, stats.Entries)
+	assert.Equal(t, int64(len(content)), stats.Bytes)
+}
+
+func TestBlobCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache, err := NewBlobCache("", 10, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	first, second, third := []byte("aaaa"), []byte("bbbb"), []byte("cccc")
+	cache.Put(gitBlobSHA1(first), first)
+	cache.Put(gitBlobSHA1(second), second)
+
+	// Reading the first blob makes the second the least recently used
+	_, ok := cache.Get(gitBlobSHA1(first))
+	require.True(t, ok)
+	cache.Put(gitBlobSHA1(third), third)
+
+	_, ok = cache.Get(gitBlobSHA1(second))
+	assert.False(t, ok, "the least recently used blob is evicted")
+	_, ok = cache.Get(gitBlobSHA1(first))
+	assert.True(t, ok)
+	_, ok = cache.Get(gitBlobSHA1(third))
+	assert.True(t, ok)
+
+	// Blobs larger than the whole cache are not kept in memory
+	large := []byte("larger than ten bytes")
+	cache.Put(gitBlobSHA1(large), large)
+	_, ok = cache.Get(gitBlobSHA1(large))
+	assert.False(t, ok)
+	assert.Equal(t, int64(8), cache.Stats().Bytes)
+}
+
+func TestBlobCache_Persist(t *testing.T) {
+	dir := t.TempDir()
+	content := []byte("kind: Task\n")
+	sha := gitBlobSHA1(content)
+
+	cache, err := NewBlobCache(dir, 0, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	cache.Put(sha, content)
+	assert.FileExists(t, filepath.Join(dir, sha[:2], sha[2:]))
+
+	// A new cache over the same directory serves the blob from disk
+	restarted, err := NewBlobCache(dir, 0, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	cached, ok := restarted.Get(sha)
+	require.True(t, ok)
+	assert.Equal(t, content, cached)
+
+	// A corrupted blob is discarded instead of served
+	other := []byte("kind: Pipeline\n")
+	otherSHA := gitBlobSHA1(other)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, otherSHA[:2]), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, otherSHA[:2], otherSHA[2:]), []byte("truncated"), 0o644))
+
+	_, ok = restarted.Get(otherSHA)
+	assert.False(t, ok)
+	assert.NoFileExists(t, filepath.Join(dir, otherSHA[:2], otherSHA[2:]))
+}
+
+func TestBlobCache_Nil(t *testing.T) {
+	var cache *BlobCache
+	cache.Put(gitBlobSHA1([]byte("x")), []byte("x"))
+	_, ok := cache.Get(gitBlobSHA1([]byte("x")))
+	assert.False(t, ok)
+	assert.Equal(t, BlobCacheStats{}, cache.Stats())
+}
+
+func TestGitBlobSHA1(t *testing.T) {
+	// git hash-object of an empty file and of "hello\n"
+	assert.Equal(t, "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", gitBlobSHA1(nil))
+	assert.Equal(t, "ce013625030ba8dba906f756967f9e9ca394464a", gitBlobSHA1([]byte("hello\n")))
+}
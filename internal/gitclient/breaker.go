@@ -0,0 +1,188 @@
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// Default circuit breaker settings
+const (
+	DefaultBreakerFailureThreshold = 5
+	DefaultBreakerOpenTimeout      = time.Minute
+	DefaultBreakerHalfOpenRequests = 1
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+// Circuit breaker states
+const (
+	CircuitClosed   CircuitState = "closed"    // Requests flow normally
+	CircuitOpen     CircuitState = "open"      // Requests fail fast until the open timeout passes
+	CircuitHalfOpen CircuitState = "half-open" // A few probe requests decide whether to close again
+)
+
+// CircuitOpenError is returned without contacting the provider while its circuit is open
+type CircuitOpenError struct {
+	BaseURL string
+	RetryAt time.Time
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("circuit open for %s, next attempt after %s", e.BaseURL, e.RetryAt.Format(time.RFC3339))
+}
+
+// IsCircuitOpen reports whether an error was caused by an open circuit
+func IsCircuitOpen(err error) bool {
+	var circuitErr *CircuitOpenError
+	return errors.As(err, &circuitErr)
+}
+
+// BreakerTicket is handed out by Allow and passed back to RecordResponse. It is
+// zero for a request sent while the circuit was closed, otherwise it names the
+// half-open period the request was sent to probe.
+type BreakerTicket uint64
+
+// CircuitBreakerStatus is the state of one circuit breaker as reported by the factory
+type CircuitBreakerStatus struct {
+	BaseURL             string       `json:"base_url"`
+	State               CircuitState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            time.Time    `json:"opened_at,omitempty"`
+	LastError           string       `json:"last_error,omitempty"`
+}
+
+// CircuitBreaker stops requests to a provider API after consecutive failures.
+// Once open, it fails calls fast for the open timeout, then lets a limited
+// number of probe requests through: a successful probe closes the circuit, a
+// failed one opens it again. A nil breaker lets every request through.
+type CircuitBreaker struct {
+	baseURL string
+	config  types.CircuitBreakerConfig
+
+	mu        sync.Mutex
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int           // Probe requests in flight while half-open
+	halfOpen  BreakerTicket // Counts half-open periods, so late probes from an earlier one are not mistaken for current ones
+	lastError string
+}
+
+// NewCircuitBreaker creates a closed circuit breaker for an API base URL.
+// Unset thresholds take the package defaults.
+func NewCircuitBreaker(baseURL string, config types.CircuitBreakerConfig) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = DefaultBreakerFailureThreshold
+	}
+	if config.OpenTimeout <= 0 {
+		config.OpenTimeout = DefaultBreakerOpenTimeout
+	}
+	if config.HalfOpenRequests <= 0 {
+		config.HalfOpenRequests = DefaultBreakerHalfOpenRequests
+	}
+
+	return &CircuitBreaker{
+		baseURL: baseURL,
+		config:  config,
+		state:   CircuitClosed,
+	}
+}
+
+// Allow returns a CircuitOpenError when a request must not be sent. Every
+// allowed request must be followed by RecordResponse with the returned ticket.
+func (b *CircuitBreaker) Allow() (BreakerTicket, error) {
+	if b == nil {
+		return 0, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		retryAt := b.openedAt.Add(b.config.OpenTimeout)
+		if time.Now().Before(retryAt) {
+			return 0, &CircuitOpenError{BaseURL: b.baseURL, RetryAt: retryAt}
+		}
+		b.state = CircuitHalfOpen
+		b.probes = 0
+		b.halfOpen++
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probes >= b.config.HalfOpenRequests {
+			return 0, &CircuitOpenError{BaseURL: b.baseURL, RetryAt: time.Now().Add(b.config.OpenTimeout)}
+		}
+		b.probes++
+		return b.halfOpen, nil
+	}
+
+	return 0, nil
+}
+
+// RecordResponse records the outcome of an allowed request. Transport errors
+// and 5xx responses count as failures; any other response shows the provider
+// is up. Only probes of the current half-open period close or reopen the
+// circuit: requests sent before it opened say nothing about recovery.
+// Canceled requests say nothing about the provider and are not counted.
+func (b *CircuitBreaker) RecordResponse(ticket BreakerTicket, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	probe := ticket != 0 && ticket == b.halfOpen && b.state == CircuitHalfOpen
+	if probe {
+		b.probes--
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return
+	case err != nil:
+		b.recordFailure(err.Error(), probe)
+	case resp.StatusCode >= http.StatusInternalServerError:
+		b.recordFailure(fmt.Sprintf("server error: %d", resp.StatusCode), probe)
+	case probe || b.state == CircuitClosed:
+		b.state = CircuitClosed
+		b.failures = 0
+		b.lastError = ""
+	}
+}
+
+// recordFailure counts a failed request and opens the circuit when the
+// threshold is reached or a half-open probe fails. The caller must hold b.mu.
+func (b *CircuitBreaker) recordFailure(message string, probe bool) {
+	b.failures++
+	b.lastError = message
+
+	if probe || (b.state == CircuitClosed && b.failures >= b.config.FailureThreshold) {
+		b.state = CircuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// Status returns the breaker's current state
+func (b *CircuitBreaker) Status() CircuitBreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := CircuitBreakerStatus{
+		BaseURL:             b.baseURL,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+		LastError:           b.lastError,
+	}
+	if b.state != CircuitClosed {
+		status.OpenedAt = b.openedAt
+	}
+	return status
+}
@@ -0,0 +1,221 @@
+package gitclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func serverError() *http.Response {
+	return &http.Response{StatusCode: http.StatusServiceUnavailable}
+}
+
+// allow requires the breaker to let a request through
+func allow(t *testing.T, breaker *CircuitBreaker) BreakerTicket {
+	ticket, err := breaker.Allow()
+	require.NoError(t, err)
+	return ticket
+}
+
+// refused reports whether the breaker turns a request away
+func refused(breaker *CircuitBreaker) bool {
+	_, err := breaker.Allow()
+	return IsCircuitOpen(err)
+}
+
+func TestCircuitBreaker_OpensAfterThreshold(t *testing.T) {
+	breaker := NewCircuitBreaker("https://api.example.com", types.CircuitBreakerConfig{FailureThreshold: 3, OpenTimeout: time.Hour})
+
+	for i := 0; i < 2; i++ {
+		breaker.RecordResponse(allow(t, breaker), serverError(), nil)
+	}
+	assert.Equal(t, CircuitClosed, breaker.Status().State)
+
+	// Client errors show the API is up and reset the count
+	breaker.RecordResponse(allow(t, breaker), &http.Response{StatusCode: http.StatusNotFound}, nil)
+	assert.Equal(t, 0, breaker.Status().ConsecutiveFailures)
+
+	for i := 0; i < 3; i++ {
+		breaker.RecordResponse(allow(t, breaker), nil, errors.New("connection refused"))
+	}
+
+	status := breaker.Status()
+	assert.Equal(t, CircuitOpen, status.State)
+	assert.Equal(t, 3, status.ConsecutiveFailures)
+	assert.Equal(t, "connection refused", status.LastError)
+	assert.False(t, status.OpenedAt.IsZero())
+
+	_, err := breaker.Allow()
+	require.Error(t, err)
+	assert.True(t, IsCircuitOpen(err))
+	assert.True(t, IsCircuitOpen(fmt.Errorf("wrapped: %w", err)))
+
+	var circuitErr *CircuitOpenError
+	require.True(t, errors.As(err, &circuitErr))
+	assert.Equal(t, "https://api.example.com", circuitErr.BaseURL)
+	assert.True(t, circuitErr.RetryAt.After(time.Now()))
+}
+
+func TestCircuitBreaker_HalfOpen(t *testing.T) {
+	breaker := NewCircuitBreaker("https://api.example.com", types.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+
+	breaker.RecordResponse(allow(t, breaker), serverError(), nil)
+	require.True(t, refused(breaker))
+
+	// After the open timeout a single probe is let through
+	time.Sleep(20 * time.Millisecond)
+	probe := allow(t, breaker)
+	assert.Equal(t, CircuitHalfOpen, breaker.Status().State)
+	assert.True(t, refused(breaker), "only one probe at a time")
+
+	// A failed probe opens the circuit again
+	breaker.RecordResponse(probe, serverError(), nil)
+	assert.Equal(t, CircuitOpen, breaker.Status().State)
+
+	// A successful probe closes it
+	time.Sleep(20 * time.Millisecond)
+	breaker.RecordResponse(allow(t, breaker), &http.Response{StatusCode: http.StatusOK}, nil)
+	status := breaker.Status()
+	assert.Equal(t, CircuitClosed, status.State)
+	assert.Equal(t, 0, status.ConsecutiveFailures)
+	assert.True(t, status.OpenedAt.IsZero())
+}
+
+func TestCircuitBreaker_IgnoresCanceledRequests(t *testing.T) {
+	breaker := NewCircuitBreaker("https://api.example.com", types.CircuitBreakerConfig{FailureThreshold: 1})
+
+	breaker.RecordResponse(allow(t, breaker), nil, fmt.Errorf("request failed: %w", context.Canceled))
+	assert.Equal(t, CircuitClosed, breaker.Status().State)
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var breaker *CircuitBreaker
+	ticket, err := breaker.Allow()
+	assert.NoError(t, err)
+	breaker.RecordResponse(ticket, serverError(), nil)
+}
+
+func TestCircuitBreaker_LateResponses(t *testing.T) {
+	breaker := NewCircuitBreaker("https://api.example.com", types.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond})
+
+	// Three requests are sent while the circuit is closed; the first one fails
+	// and opens it
+	slow, slower := allow(t, breaker), allow(t, breaker)
+	breaker.RecordResponse(allow(t, breaker), serverError(), nil)
+	require.Equal(t, CircuitOpen, breaker.Status().State)
+
+	// Another one succeeding afterwards says nothing about recovery
+	breaker.RecordResponse(slow, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitOpen, breaker.Status().State)
+
+	// Nor does it free the probe slot or close the circuit while half-open
+	time.Sleep(20 * time.Millisecond)
+	probe := allow(t, breaker)
+	breaker.RecordResponse(slower, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitHalfOpen, breaker.Status().State)
+	assert.True(t, refused(breaker), "only one probe at a time")
+
+	// A probe from an earlier half-open period is not a current probe either
+	breaker.RecordResponse(probe, serverError(), nil)
+	require.Equal(t, CircuitOpen, breaker.Status().State)
+	time.Sleep(20 * time.Millisecond)
+	current := allow(t, breaker)
+	breaker.RecordResponse(probe, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitHalfOpen, breaker.Status().State)
+
+	breaker.RecordResponse(current, &http.Response{StatusCode: http.StatusOK}, nil)
+	assert.Equal(t, CircuitClosed, breaker.Status().State)
+}
+
+func TestGitHubClient_CircuitBreakerStopsRequests(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.RetryAttempts = 0
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	client.breaker = NewCircuitBreaker(server.URL, types.CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+	for i := 0; i < 2; i++ {
+		_, err := client.GetBranches(context.Background(), repo)
+		require.Error(t, err)
+		assert.False(t, IsCircuitOpen(err))
+	}
+
+	_, err = client.GetBranches(context.Background(), repo)
+	require.Error(t, err)
+	assert.True(t, IsCircuitOpen(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&hits))
+}
+
+func TestGitLabClient_OpenCircuitSkipsFallback(t *testing.T) {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+
+	// A nil fallback client makes any fallback attempt fail the test
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	client.breaker = NewCircuitBreaker(server.URL, types.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour})
+	client.breaker.RecordResponse(0, serverError(), nil)
+
+	_, err = client.GetBranches(context.Background(), types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo"})
+	require.Error(t, err)
+	assert.True(t, IsCircuitOpen(err))
+	assert.Zero(t, atomic.LoadInt32(&hits))
+}
+
+func TestClientFactory_CircuitBreakers(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	config := GetDefaultConfig()
+	config.Token = "token"
+
+	for _, repo := range []types.Repository{
+		{Name: "a", URL: "https://github.com/owner/a", Provider: "github"},
+		{Name: "b", URL: "https://github.com/owner/b", Provider: "github"},
+		{Name: "c", URL: "https://gitlab.com/group/c", Provider: "gitlab"},
+	} {
+		_, err := factory.CreateClient(repo, config)
+		require.NoError(t, err)
+	}
+
+	breakers := factory.CircuitBreakers()
+	require.Len(t, breakers, 2, "clients of the same API share a breaker")
+	assert.Equal(t, "https://api.github.com", breakers[0].BaseURL)
+	assert.Equal(t, "https://gitlab.com/api/v4", breakers[1].BaseURL)
+	for _, breaker := range breakers {
+		assert.Equal(t, CircuitClosed, breaker.State)
+	}
+
+	disabled := NewClientFactory(newPaginationTestLogger(t))
+	disabled.SetCircuitBreaker(types.CircuitBreakerConfig{Disabled: true})
+	_, err := disabled.CreateClient(types.Repository{Name: "a", URL: "https://github.com/owner/a", Provider: "github"}, config)
+	require.NoError(t, err)
+	assert.Empty(t, disabled.CircuitBreakers())
+}
@@ -2,12 +2,19 @@ package gitclient
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"net/url"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // GitClient defines the interface for Git providers
@@ -15,9 +22,24 @@ type GitClient interface {
 	// GetBranches retrieves all branches for a repository
 	GetBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error)
 
+	// GetTags retrieves all tags for a repository
+	GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error)
+
+	// ListPullRequests retrieves open pull requests (merge requests on GitLab)
+	ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error)
+
+	// GetPullRequest retrieves a single pull request in any state
+	GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error)
+
+	// CompareCommits retrieves the commits between base and head, oldest first
+	CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error)
+
 	// GetLatestCommit retrieves the latest commit SHA for a branch
 	GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error)
 
+	// GetDefaultBranch retrieves the name of the repository's default branch
+	GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error)
+
 	// CheckPermissions verifies if the client has access to the repository
 	CheckPermissions(ctx context.Context, repo types.Repository) error
 
@@ -31,7 +53,7 @@ type GitClient interface {
 	Close() error
 
 	// ListFiles retrieves all files in a specific path for a commit
-	ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]string, error)
+	ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error)
 
 	// GetFileContent retrieves the content of a specific file
 	GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error)
@@ -40,16 +62,28 @@ type GitClient interface {
 	CheckDirectoryExists(ctx context.Context, repo types.Repository, commitSHA, dirPath string) (bool, error)
 }
 
+// FileEntry is a file in a repository tree. SHA is the file's blob SHA, which
+// identifies its content; it is empty when the provider does not report it.
+type FileEntry struct {
+	Path string `json:"path"`
+	SHA  string `json:"sha,omitempty"`
+}
+
 // ClientConfig represents common configuration for Git clients
 type ClientConfig struct {
-	Token          string        `json:"-"` // Hidden for security
-	BaseURL        string        `json:"base_url,omitempty"`
-	RepositoryURL  string        `json:"repository_url,omitempty"` // For auto-detecting API URLs
-	Timeout        time.Duration `json:"timeout"`
-	RetryAttempts  int           `json:"retry_attempts"`
-	RetryBackoff   time.Duration `json:"retry_backoff"`
-	UserAgent      string        `json:"user_agent"`
-	EnableFallback bool          `json:"enable_fallback"`
+	Token          string                 `json:"-"` // Hidden for security
+	BaseURL        string                 `json:"base_url,omitempty"`
+	RepositoryURL  string                 `json:"repository_url,omitempty"` // For auto-detecting API URLs
+	Timeout        time.Duration          `json:"timeout"`
+	RetryAttempts  int                    `json:"retry_attempts"`
+	RetryBackoff   time.Duration          `json:"retry_backoff"`
+	UserAgent      string                 `json:"user_agent"`
+	EnableFallback bool                   `json:"enable_fallback"`
+	PerPage        int                    `json:"per_page,omitempty"`  // Page size for list endpoints (0 = DefaultPageSize)
+	MaxPages       int                    `json:"max_pages,omitempty"` // Safety cap on pages fetched (0 = DefaultMaxPages)
+	GitHubApp      *types.GitHubAppConfig `json:"-"`                   // GitHub App credentials used instead of Token
+	HTTPCache      *HTTPCache             `json:"-"`                   // Conditional request cache (nil disables ETag caching)
+	HTTP           types.HTTPClientConfig `json:"http,omitempty"`      // Proxy, CA bundle, client certificate and TLS version
 }
 
 // GitClientFactory defines the interface for creating Git clients
@@ -63,43 +97,343 @@ type ClientFactory struct {
 	rateLimiters map[string]RateLimiter
 	fallback     *FallbackClient
 	logger       *logger.Entry
+
+	// GitHub App token sources shared across clients so installation tokens stay cached
+	githubApp      *types.GitHubAppConfig
+	githubAppAuths map[string]*GitHubAppAuth
+
+	// Conditional request cache shared by all clients
+	httpCache *HTTPCache
+
+	// Proxy and TLS settings for repositories that do not override them
+	httpConfig types.HTTPClientConfig
+
+	// Configured request rates for new rate limiters
+	rateLimits types.RateLimitConfig
+
+	// Circuit breakers shared by clients of the same API, keyed by base URL
+	breakerConfig types.CircuitBreakerConfig
+	breakers      map[string]*CircuitBreaker
+
+	// Pagination defaults applied to clients whose config leaves them unset
+	perPage  int
+	maxPages int
 }
 
 // NewClientFactory creates a new client factory
 func NewClientFactory(parentLogger *logger.Entry) *ClientFactory {
 	return &ClientFactory{
-		rateLimiters: make(map[string]RateLimiter),
-		fallback:     NewFallbackClient(parentLogger),
-		logger:       parentLogger,
+		rateLimiters:   make(map[string]RateLimiter),
+		fallback:       NewFallbackClient(parentLogger),
+		logger:         parentLogger,
+		githubAppAuths: make(map[string]*GitHubAppAuth),
+		breakers:       make(map[string]*CircuitBreaker),
 	}
 }
 
 // CreateClient creates a client for the specified repository
 func (f *ClientFactory) CreateClient(repo types.Repository, config ClientConfig) (GitClient, error) {
-	// Set repository URL for auto-detection
-	config.RepositoryURL = repo.URL
+	config = f.resolveConfig(repo, config)
+
+	f.mu.RLock()
+	rateLimits := f.rateLimits
+	f.mu.RUnlock()
 
 	switch repo.Provider {
 	case "github":
-		rateLimiter := f.getRateLimiter("github", NewGitHubRateLimiter())
-		return NewGitHubClient(config, rateLimiter, f.fallback, f.logger)
+		rateLimiter := f.getRateLimiter(rateLimiterKey("github", config), NewGitHubRateLimiterFromConfig(rateLimits.GitHub))
+		var appAuth *GitHubAppAuth
+		if config.GitHubApp != nil {
+			var err error
+			appAuth, err = f.getGitHubAppAuth(*config.GitHubApp, config)
+			if err != nil {
+				return nil, err
+			}
+		}
+		client, err := newGitHubClient(config, rateLimiter, f.fallback, appAuth, f.logger)
+		if err != nil {
+			return nil, err
+		}
+		client.breaker = f.getBreaker(client.baseURL)
+		return client, nil
 	case "gitlab":
-		rateLimiter := f.getRateLimiter("gitlab", NewGitLabRateLimiter())
-		return NewGitLabClient(config, rateLimiter, f.fallback, f.logger)
+		rateLimiter := f.getRateLimiter(rateLimiterKey("gitlab", config), NewGitLabRateLimiterFromConfig(rateLimits.GitLab))
+		client, err := NewGitLabClient(config, rateLimiter, f.fallback, f.logger)
+		if err != nil {
+			return nil, err
+		}
+		client.breaker = f.getBreaker(client.baseURL)
+		return client, nil
+	case "bitbucket":
+		rateLimiter := f.getRateLimiter(rateLimiterKey("bitbucket", config), NewBitbucketRateLimiter())
+		client, err := NewBitbucketClient(config, rateLimiter, f.fallback, f.logger)
+		if err != nil {
+			return nil, err
+		}
+		client.breaker = f.getBreaker(client.baseURL)
+		return client, nil
+	case "gitea", "forgejo":
+		// Forgejo is a Gitea fork and serves the same API
+		rateLimiter := f.getRateLimiter(rateLimiterKey("gitea", config), NewGiteaRateLimiter())
+		client, err := NewGiteaClient(config, rateLimiter, f.fallback, f.logger)
+		if err != nil {
+			return nil, err
+		}
+		client.breaker = f.getBreaker(client.baseURL)
+		return client, nil
 	default:
 		return nil, &UnsupportedProviderError{Provider: repo.Provider}
 	}
 }
 
-// getRateLimiter returns or creates a rate limiter for a provider
-func (f *ClientFactory) getRateLimiter(provider string, defaultLimiter RateLimiter) RateLimiter {
+// resolveConfig fills in the settings a client config inherits from the
+// repository and the factory defaults
+func (f *ClientFactory) resolveConfig(repo types.Repository, config ClientConfig) ClientConfig {
+	// Set repository URL for auto-detection
+	config.RepositoryURL = repo.URL
+
+	f.mu.RLock()
+	if config.PerPage <= 0 {
+		config.PerPage = f.perPage
+	}
+	if config.MaxPages <= 0 {
+		config.MaxPages = f.maxPages
+	}
+	// A repository-level app wins, then a repository token, then the global app
+	if config.GitHubApp == nil {
+		config.GitHubApp = repo.GitHubApp
+	}
+	if config.GitHubApp == nil && config.Token == "" {
+		config.GitHubApp = f.githubApp
+	}
+	if config.HTTPCache == nil {
+		config.HTTPCache = f.httpCache
+	}
+	if config.HTTP.IsZero() {
+		config.HTTP = f.httpConfig.Merge(repo.HTTP)
+	}
+	f.mu.RUnlock()
+
+	return config
+}
+
+// SetPagination sets the page size and page cap used by clients created by this factory
+// when their ClientConfig does not specify them. Zero values keep the package defaults.
+func (f *ClientFactory) SetPagination(perPage, maxPages int) {
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	if limiter, exists := f.rateLimiters[provider]; exists {
+	f.perPage = perPage
+	f.maxPages = maxPages
+}
+
+// SetGitHubApp sets the GitHub App credentials used for GitHub repositories
+// that configure neither a token nor their own app
+func (f *ClientFactory) SetGitHubApp(app *types.GitHubAppConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.githubApp = app
+}
+
+// SetHTTPCache sets the conditional request cache used by clients created by this factory
+func (f *ClientFactory) SetHTTPCache(cache *HTTPCache) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.httpCache = cache
+}
+
+// SetHTTPConfig sets the proxy and TLS settings used by clients, which
+// repositories can override field by field
+func (f *ClientFactory) SetHTTPConfig(config types.HTTPClientConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.httpConfig = config
+}
+
+// SetRateLimits sets the request rates for rate limiters created after the call
+func (f *ClientFactory) SetRateLimits(config types.RateLimitConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.rateLimits = config
+}
+
+// SetCircuitBreaker sets the thresholds of circuit breakers created after the call
+func (f *ClientFactory) SetCircuitBreaker(config types.CircuitBreakerConfig) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.breakerConfig = config
+}
+
+// CircuitBreakers returns the state of every circuit breaker, ordered by base URL
+func (f *ClientFactory) CircuitBreakers() []CircuitBreakerStatus {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	statuses := make([]CircuitBreakerStatus, 0, len(f.breakers))
+	for _, breaker := range f.breakers {
+		statuses = append(statuses, breaker.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].BaseURL < statuses[j].BaseURL
+	})
+	return statuses
+}
+
+// SetMirrorDir sets where the git fallback keeps bare mirrors for file and tree lookups
+func (f *ClientFactory) SetMirrorDir(dir string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fallback.SetMirrorDir(dir)
+}
+
+// HTTPCacheStats returns conditional request cache counters, or zeros when caching is disabled
+func (f *ClientFactory) HTTPCacheStats() HTTPCacheStats {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.httpCache.Stats()
+}
+
+// getGitHubAppAuth returns or creates the token source for a GitHub App on an API endpoint
+func (f *ClientFactory) getGitHubAppAuth(app types.GitHubAppConfig, config ClientConfig) (*GitHubAppAuth, error) {
+	baseURL := githubAPIURL(config)
+	key := fmt.Sprintf("%s|%d|%s", baseURL, app.AppID, app.PrivateKeyPath)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if auth, exists := f.githubAppAuths[key]; exists {
+		return auth, nil
+	}
+
+	auth, err := NewGitHubAppAuth(app, baseURL, config, f.logger)
+	if err != nil {
+		return nil, err
+	}
+	f.githubAppAuths[key] = auth
+	return auth, nil
+}
+
+// RateLimits returns the state of every rate limiter, one per provider, API host
+// and credential, ordered by key
+func (f *ClientFactory) RateLimits() []RateLimitStatus {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	keys := make([]string, 0, len(f.rateLimiters))
+	for key := range f.rateLimiters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	statuses := make([]RateLimitStatus, 0, len(keys))
+	for _, key := range keys {
+		statuses = append(statuses, rateLimitStatus(key, f.rateLimiters[key]))
+	}
+	return statuses
+}
+
+// RateLimitFor returns the state of the rate limiter a repository's clients draw
+// from, or false when no client has used it yet
+func (f *ClientFactory) RateLimitFor(repo types.Repository, config ClientConfig) (RateLimitStatus, bool) {
+	provider := repo.Provider
+	if provider == "forgejo" {
+		provider = "gitea"
+	}
+	key := rateLimiterKey(provider, f.resolveConfig(repo, config))
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	limiter, exists := f.rateLimiters[key]
+	if !exists {
+		return RateLimitStatus{}, false
+	}
+	return rateLimitStatus(key, limiter), true
+}
+
+// rateLimitStatus describes the limiter stored under a key
+func rateLimitStatus(key string, limiter RateLimiter) RateLimitStatus {
+	info := limiter.GetLimit()
+	parts := strings.SplitN(key, "|", 3)
+	status := RateLimitStatus{
+		Provider:  parts[0],
+		Limit:     info.Limit,
+		Remaining: info.Remaining,
+		ResetTime: info.ResetTime,
+	}
+	if len(parts) == 3 {
+		status.Host = parts[1]
+		status.Credential = parts[2]
+	}
+	return status
+}
+
+// rateLimiterKey identifies the request budget a client draws from. Providers
+// account limits per credential and per instance, so clients share a limiter only
+// when they talk to the same API host with the same token or GitHub App.
+func rateLimiterKey(provider string, config ClientConfig) string {
+	host := ""
+	if config.BaseURL != "" {
+		if parsed, err := url.Parse(config.BaseURL); err == nil {
+			host = parsed.Host
+		}
+	}
+	if host == "" {
+		if parsed, err := utils.ParseGitURL(config.RepositoryURL); err == nil {
+			host = parsed.Hostname()
+		}
+	}
+
+	return provider + "|" + host + "|" + credentialFingerprint(config)
+}
+
+// credentialFingerprint returns a short, non-reversible identifier for the
+// credential a client authenticates with
+func credentialFingerprint(config ClientConfig) string {
+	switch {
+	case config.GitHubApp != nil:
+		return fmt.Sprintf("app:%d", config.GitHubApp.AppID)
+	case config.Token != "":
+		sum := sha256.Sum256([]byte(config.Token))
+		return "token:" + hex.EncodeToString(sum[:])[:12]
+	default:
+		return "anonymous"
+	}
+}
+
+// getBreaker returns or creates the circuit breaker for an API base URL, or nil
+// when circuit breaking is disabled
+func (f *ClientFactory) getBreaker(baseURL string) *CircuitBreaker {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.breakerConfig.Disabled {
+		return nil
+	}
+	if breaker, exists := f.breakers[baseURL]; exists {
+		return breaker
+	}
+	breaker := NewCircuitBreaker(baseURL, f.breakerConfig)
+	f.breakers[baseURL] = breaker
+	return breaker
+}
+
+// getRateLimiter returns or creates the rate limiter stored under a key
+func (f *ClientFactory) getRateLimiter(key string, defaultLimiter RateLimiter) RateLimiter {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if limiter, exists := f.rateLimiters[key]; exists {
 		return limiter
 	}
-	f.rateLimiters[provider] = defaultLimiter
+	f.rateLimiters[key] = defaultLimiter
 	return defaultLimiter
 }
 
@@ -165,6 +499,38 @@ func IsRetryableError(err error) bool {
 	}
 }
 
+// Failure reasons returned by ClassifyError
+const (
+	FailureAuth      = "auth"       // Token revoked, expired or lacking permissions
+	FailureNotFound  = "not_found"  // Repository deleted, renamed or hidden
+	FailureNetwork   = "network"    // Provider unreachable or failing, including open circuits
+	FailureRateLimit = "rate_limit" // API budget exhausted
+	FailureUnknown   = "unknown"
+)
+
+// ClassifyError returns why a request failed, looking through wrapped errors
+func ClassifyError(err error) string {
+	var (
+		authErr      *AuthenticationError
+		notFoundErr  *RepositoryNotFoundError
+		networkErr   *NetworkError
+		rateLimitErr *RateLimitExceededError
+		circuitErr   *CircuitOpenError
+	)
+	switch {
+	case errors.As(err, &authErr):
+		return FailureAuth
+	case errors.As(err, &notFoundErr):
+		return FailureNotFound
+	case errors.As(err, &rateLimitErr):
+		return FailureRateLimit
+	case errors.As(err, &networkErr), errors.As(err, &circuitErr):
+		return FailureNetwork
+	default:
+		return FailureUnknown
+	}
+}
+
 // GetDefaultConfig returns default client configuration
 func GetDefaultConfig() ClientConfig {
 	return ClientConfig{
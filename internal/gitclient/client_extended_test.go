@@ -56,7 +56,7 @@ func TestGitClientExtendedInterface(t *testing.T) {
 
 			// Test that client implements extended interface
 			extendedClient, ok := client.(interface {
-				ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]string, error)
+				ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error)
 				GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error)
 				CheckDirectoryExists(ctx context.Context, repo types.Repository, commitSHA, dirPath string) (bool, error)
 			})
@@ -113,30 +113,25 @@ func TestFallbackClientExtendedMethods(t *testing.T) {
 
 	ctx := context.Background()
 
-	// Test ListFiles - should return error (not implemented)
+	// File operations need a mirror directory, which this client does not have
 	_, listErr := fallbackClient.ListFiles(ctx, repo, "main", ".tekton")
 	if listErr == nil {
-		t.Error("Expected error for unimplemented ListFiles in fallback client")
-	}
-	if listErr.Error() != "ListFiles not implemented in fallback client - API client required" {
+		t.Error("Expected error for ListFiles without a mirror directory")
+	} else if listErr.Error() != "file access in fallback client requires a mirror directory" {
 		t.Errorf("Unexpected error message: %v", listErr)
 	}
 
-	// Test GetFileContent - should return error (not implemented)
 	_, contentErr := fallbackClient.GetFileContent(ctx, repo, "main", ".tekton/pipeline.yaml")
 	if contentErr == nil {
-		t.Error("Expected error for unimplemented GetFileContent in fallback client")
-	}
-	if contentErr.Error() != "GetFileContent not implemented in fallback client - API client required" {
+		t.Error("Expected error for GetFileContent without a mirror directory")
+	} else if contentErr.Error() != "file access in fallback client requires a mirror directory" {
 		t.Errorf("Unexpected error message: %v", contentErr)
 	}
 
-	// Test CheckDirectoryExists - should return error (not implemented)
 	_, dirErr := fallbackClient.CheckDirectoryExists(ctx, repo, "main", ".tekton")
 	if dirErr == nil {
-		t.Error("Expected error for unimplemented CheckDirectoryExists in fallback client")
-	}
-	if dirErr.Error() != "CheckDirectoryExists not implemented in fallback client - API client required" {
+		t.Error("Expected error for CheckDirectoryExists without a mirror directory")
+	} else if dirErr.Error() != "file access in fallback client requires a mirror directory" {
 		t.Errorf("Unexpected error message: %v", dirErr)
 	}
 }
@@ -231,6 +226,12 @@ func TestGitLabProjectPathParsing(t *testing.T) {
 			expected: "group/subgroup/project",
 			wantErr:  false,
 		},
+		{
+			name:     "GitLab SSH URL",
+			repoURL:  "git@gitlab.com:group/subgroup/project.git",
+			expected: "group/subgroup/project",
+			wantErr:  false,
+		},
 		{
 			name:     "Invalid URL",
 			repoURL:  "not-a-url",
@@ -1,6 +1,10 @@
 package gitclient
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -202,6 +206,24 @@ func TestErrorTypes(t *testing.T) {
 	assert.Equal(t, assert.AnError, networkErr.Err)
 }
 
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&AuthenticationError{Provider: "github"}, FailureAuth},
+		{fmt.Errorf("failed to get current branches: %w", &RepositoryNotFoundError{Repository: "gone"}), FailureNotFound},
+		{&NetworkError{Provider: "gitlab", Err: assert.AnError}, FailureNetwork},
+		{&CircuitOpenError{BaseURL: "https://api.github.com"}, FailureNetwork},
+		{&RateLimitExceededError{Provider: "github"}, FailureRateLimit},
+		{assert.AnError, FailureUnknown},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, ClassifyError(tt.err), tt.err.Error())
+	}
+}
+
 func TestClientFactory_Concurrency(t *testing.T) {
 	testLogger, err := logger.NewLogger(logger.Config{
 		Level:  "error",
@@ -528,3 +550,175 @@ func TestClientFactory_CreateClient_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestClientFactory_RateLimitersPerCredential(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	factory.SetRateLimits(types.RateLimitConfig{
+		GitHub: types.GitHubRateLimit{RequestsPerHour: 7200, Burst: 3},
+	})
+
+	newClient := func(url, token string) *GitHubClient {
+		config := GetDefaultConfig()
+		config.Token = token
+		client, err := factory.CreateClient(types.Repository{Name: "repo", URL: url, Provider: "github"}, config)
+		require.NoError(t, err)
+		return client.(*GitHubClient)
+	}
+
+	teamA := newClient("https://github.com/team-a/service", "token-a")
+	teamAOther := newClient("https://github.com/team-a/other", "token-a")
+	teamB := newClient("https://github.com/team-b/service", "token-b")
+	enterprise := newClient("https://github.example.com/team-a/service", "token-a")
+
+	// Repositories sharing a host and token share a budget
+	assert.Same(t, teamA.rateLimiter, teamAOther.rateLimiter)
+	assert.NotSame(t, teamA.rateLimiter, teamB.rateLimiter)
+	assert.NotSame(t, teamA.rateLimiter, enterprise.rateLimiter)
+
+	// Exhausting one token leaves the others untouched
+	teamA.rateLimiter.UpdateLimit(5000, 0, time.Now().Add(time.Hour))
+	assert.Equal(t, 5000, teamB.rateLimiter.GetLimit().Remaining)
+
+	limiter, ok := teamA.rateLimiter.(*GitHubRateLimiter)
+	require.True(t, ok)
+	assert.Equal(t, 3, limiter.limiter.Burst())
+
+	statuses := factory.RateLimits()
+	require.Len(t, statuses, 3)
+	var exhausted int
+	for _, status := range statuses {
+		assert.Equal(t, "github", status.Provider)
+		assert.NotContains(t, status.Credential, "token-")
+		if status.Remaining == 0 {
+			exhausted++
+			assert.Equal(t, "github.com", status.Host)
+		}
+	}
+	assert.Equal(t, 1, exhausted)
+}
+
+func TestRateLimiterKey(t *testing.T) {
+	config := GetDefaultConfig()
+	config.Token = "secret"
+	config.RepositoryURL = "git@gitlab.example.com:group/project.git"
+	assert.Equal(t, "gitlab|gitlab.example.com|"+credentialFingerprint(config), rateLimiterKey("gitlab", config))
+
+	// An explicit API endpoint identifies the instance
+	config.BaseURL = "https://api.example.com:8443/v3"
+	assert.Equal(t, "github|api.example.com:8443|"+credentialFingerprint(config), rateLimiterKey("github", config))
+
+	config.GitHubApp = &types.GitHubAppConfig{AppID: 42}
+	assert.Equal(t, "app:42", credentialFingerprint(config))
+
+	assert.Equal(t, "anonymous", credentialFingerprint(GetDefaultConfig()))
+}
+
+func TestGitHubClient_GetRateLimitUpdatesLimiter(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/rate_limit", r.URL.Path)
+		fmt.Fprintf(w, `{"resources":{"core":{"limit":15000,"remaining":1234,"reset":%d}}}`, reset.Unix())
+	}))
+	defer server.Close()
+
+	limiter := NewGitHubRateLimiter()
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+
+	client, err := NewGitHubClient(config, limiter, nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	rateLimit, err := client.GetRateLimit(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1234, rateLimit.Remaining)
+
+	info := limiter.GetLimit()
+	assert.Equal(t, 15000, info.Limit)
+	assert.Equal(t, 1234, info.Remaining)
+	assert.True(t, reset.Equal(info.ResetTime))
+}
+
+func TestGitHubClient_ForbiddenRateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected string
+	}{
+		{"primary rate limit", map[string]string{
+			"X-RateLimit-Remaining": "0",
+			"X-RateLimit-Reset":     fmt.Sprint(reset.Unix()),
+		}, FailureRateLimit},
+		{"secondary rate limit", map[string]string{"Retry-After": "60"}, FailureRateLimit},
+		{"missing permissions", map[string]string{"X-RateLimit-Remaining": "4999"}, FailureAuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for key, value := range tt.headers {
+					w.Header().Set(key, value)
+				}
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer server.Close()
+
+			config := GetDefaultConfig()
+			config.Token = "test-token"
+			config.BaseURL = server.URL
+			config.EnableFallback = false
+
+			client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+			require.NoError(t, err)
+
+			_, err = client.GetBranches(context.Background(), types.Repository{Name: "repo", URL: "https://github.com/owner/repo"})
+			require.Error(t, err)
+			assert.Equal(t, tt.expected, ClassifyError(err))
+		})
+	}
+
+	// The primary limit reports when it resets
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	_, err = client.GetBranches(context.Background(), types.Repository{Name: "repo", URL: "https://github.com/owner/repo"})
+	var rateLimitErr *RateLimitExceededError
+	require.ErrorAs(t, err, &rateLimitErr)
+	assert.True(t, reset.Equal(rateLimitErr.ResetTime))
+}
+
+func TestClientFactory_RateLimitFor(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	repo := types.Repository{Name: "repo", URL: "https://gitea.example.com/org/repo", Provider: "forgejo"}
+	config := GetDefaultConfig()
+	config.Token = "token"
+
+	_, ok := factory.RateLimitFor(repo, config)
+	assert.False(t, ok, "no limiter before a client was created")
+
+	_, err := factory.CreateClient(repo, config)
+	require.NoError(t, err)
+
+	status, ok := factory.RateLimitFor(repo, config)
+	require.True(t, ok)
+	assert.Equal(t, "gitea", status.Provider)
+	assert.Equal(t, "gitea.example.com", status.Host)
+
+	other := config
+	other.Token = "other"
+	_, ok = factory.RateLimitFor(repo, other)
+	assert.False(t, ok, "another token has its own budget")
+}
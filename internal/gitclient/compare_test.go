@@ -0,0 +1,148 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_CompareCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/compare/aaa...ccc":
+			fmt.Fprint(w, `{
+				"total_commits": 2,
+				"commits": [
+					{"sha": "bbb", "html_url": "https://github.com/owner/repo/commit/bbb",
+					 "commit": {"message": "First", "author": {"name": "Ann", "email": "ann@example.com", "date": "2024-01-01T10:00:00Z"}}},
+					{"sha": "ccc", "html_url": "https://github.com/owner/repo/commit/ccc",
+					 "commit": {"message": "Second", "author": {"name": "Bob", "email": "bob@example.com", "date": "2024-01-01T11:00:00Z"}}}
+				],
+				"files": [
+					{"filename": "new.go", "status": "added"},
+					{"filename": "main.go", "status": "modified"},
+					{"filename": "old.go", "status": "removed"},
+					{"filename": "b.go", "status": "renamed", "previous_filename": "a.go"}
+				]
+			}`)
+		case "/repos/owner/repo/compare/ccc...ddd":
+			fmt.Fprint(w, `{"total_commits": 1, "commits": [{"sha": "ddd", "commit": {"message": "Only"}}],
+				"files": [{"filename": "main.go", "status": "modified"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+	comparison, err := client.CompareCommits(context.Background(), repo, "aaa", "ccc")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, comparison.TotalCommits)
+	require.Len(t, comparison.Commits, 2)
+	assert.Equal(t, types.Commit{
+		SHA:         "ccc",
+		Message:     "Second",
+		AuthorName:  "Bob",
+		AuthorEmail: "bob@example.com",
+		Timestamp:   time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC),
+		URL:         "https://github.com/owner/repo/commit/ccc",
+	}, comparison.Commits[1])
+	assert.Equal(t, []string{"new.go", "b.go"}, comparison.Added)
+	assert.Equal(t, []string{"old.go", "a.go"}, comparison.Removed)
+	assert.Equal(t, []string{"main.go"}, comparison.Modified)
+	assert.Nil(t, comparison.Commits[1].Modified, "files are not attributed within multi-commit ranges")
+
+	single, err := client.CompareCommits(context.Background(), repo, "ccc", "ddd")
+	require.NoError(t, err)
+	require.Len(t, single.Commits, 1)
+	assert.Equal(t, []string{"main.go"}, single.Commits[0].Modified)
+}
+
+func TestGitLabClient_CompareCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/group%2Frepo", "/projects/group/repo":
+			fmt.Fprint(w, `{"id":42,"path_with_namespace":"group/repo"}`)
+		case "/projects/42/repository/compare":
+			assert.Equal(t, "aaa", r.URL.Query().Get("from"))
+			assert.Equal(t, "bbb", r.URL.Query().Get("to"))
+			fmt.Fprint(w, `{
+				"commits": [{"id": "bbb", "message": "Fix build", "author_name": "Dev", "author_email": "dev@example.com",
+					"authored_date": "2024-02-01T08:00:00Z", "web_url": "https://gitlab.com/group/repo/-/commit/bbb"}],
+				"diffs": [
+					{"old_path": "ci.yml", "new_path": "ci.yml"},
+					{"old_path": "gone.txt", "new_path": "gone.txt", "deleted_file": true},
+					{"old_path": "x.go", "new_path": "y.go", "renamed_file": true},
+					{"old_path": "added.go", "new_path": "added.go", "new_file": true}
+				]
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo"}
+	comparison, err := client.CompareCommits(context.Background(), repo, "aaa", "bbb")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, comparison.TotalCommits)
+	require.Len(t, comparison.Commits, 1)
+	commit := comparison.Commits[0]
+	assert.Equal(t, "bbb", commit.SHA)
+	assert.Equal(t, "Dev", commit.AuthorName)
+	assert.Equal(t, time.Date(2024, 2, 1, 8, 0, 0, 0, time.UTC), commit.Timestamp)
+	assert.Equal(t, []string{"y.go", "added.go"}, commit.Added)
+	assert.Equal(t, []string{"gone.txt", "x.go"}, commit.Removed)
+	assert.Equal(t, []string{"ci.yml"}, commit.Modified)
+}
+
+func TestCompareCommits_TruncatedFiles(t *testing.T) {
+	// GitHub stops listing files at 300
+	files := make([]string, githubCompareMaxFiles)
+	for i := range files {
+		files[i] = fmt.Sprintf(`{"filename": "f%d.go", "status": "modified"}`, i)
+	}
+	var github GitHubComparison
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(
+		`{"total_commits": 1, "commits": [{"sha": "bbb"}], "files": [%s]}`, strings.Join(files, ","))), &github))
+
+	comparison := github.toCommitComparison()
+	assert.True(t, comparison.FilesTruncated)
+	assert.Nil(t, comparison.Commits[0].Modified, "a partial file list is not attributed to the commit")
+
+	// GitLab flags diffs too large to list
+	var gitlab GitLabComparison
+	require.NoError(t, json.Unmarshal([]byte(
+		`{"commits": [{"id": "bbb"}], "diffs": [{"old_path": "a.go", "new_path": "a.go"}], "overflow": true}`), &gitlab))
+	assert.True(t, gitlab.toCommitComparison().FilesTruncated)
+
+	gitlab.Overflow = false
+	assert.False(t, gitlab.toCommitComparison().FilesTruncated)
+}
@@ -0,0 +1,92 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSymrefOutput(t *testing.T) {
+	output := "ref: refs/heads/trunk\tHEAD\n" +
+		"aaa111\tHEAD\n"
+	assert.Equal(t, "trunk", parseSymrefOutput(output))
+
+	// A detached HEAD has no symref line
+	assert.Empty(t, parseSymrefOutput("aaa111\tHEAD\n"))
+	assert.Empty(t, parseSymrefOutput(""))
+}
+
+func TestGitHubClient_GetDefaultBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/owner/repo", r.URL.Path)
+		fmt.Fprint(w, `{"full_name":"owner/repo","default_branch":"develop"}`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	branch, err := client.GetDefaultBranch(context.Background(), types.Repository{Name: "repo", URL: "https://github.com/owner/repo"})
+	require.NoError(t, err)
+	assert.Equal(t, "develop", branch)
+}
+
+func TestGitLabClient_GetDefaultBranch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/group%2Frepo", "/projects/group/repo":
+			fmt.Fprint(w, `{"id":42,"path_with_namespace":"group/repo","default_branch":"master"}`)
+		case "/projects/group%2Fempty", "/projects/group/empty":
+			fmt.Fprint(w, `{"id":43,"path_with_namespace":"group/empty"}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	branch, err := client.GetDefaultBranch(context.Background(), types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo"})
+	require.NoError(t, err)
+	assert.Equal(t, "master", branch)
+
+	// Empty projects have no default branch
+	_, err = client.GetDefaultBranch(context.Background(), types.Repository{Name: "empty", URL: "https://gitlab.com/group/empty"})
+	assert.Error(t, err)
+}
+
+func TestFallbackClient_GetDefaultBranch(t *testing.T) {
+	repoURL, _ := createTestGitRepo(t)
+
+	// Move the repository's HEAD off the name git init picked
+	dir := strings.TrimPrefix(repoURL, "file://")
+	output, err := exec.Command("git", "-C", dir, "checkout", "--quiet", "-b", "trunk").CombinedOutput()
+	require.NoError(t, err, string(output))
+
+	client := NewFallbackClient(logger.GetDefaultLogger().WithField("test", "fallback"))
+
+	branch, err := client.GetDefaultBranch(context.Background(), types.Repository{Name: "local/repo", URL: repoURL})
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", branch)
+}
@@ -0,0 +1,180 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// DiscoveredRepository is a repository found by listing an organization, user or group
+type DiscoveredRepository struct {
+	FullName      string   `json:"full_name"` // owner/name, or the full project path on GitLab
+	URL           string   `json:"url"`
+	DefaultBranch string   `json:"default_branch"`
+	Archived      bool     `json:"archived"`
+	Fork          bool     `json:"fork"`
+	Topics        []string `json:"topics,omitempty"`
+}
+
+// RepositoryLister is implemented by clients that can list the repositories of
+// an organization, user or group
+type RepositoryLister interface {
+	ListRepositories(ctx context.Context, source types.DiscoverySource) ([]DiscoveredRepository, error)
+}
+
+// GitLabGroupProject represents a project in GitLab's group project list responses
+type GitLabGroupProject struct {
+	PathWithNamespace string         `json:"path_with_namespace"`
+	WebURL            string         `json:"web_url"`
+	DefaultBranch     string         `json:"default_branch"`
+	Archived          bool           `json:"archived"`
+	ForkedFromProject *GitLabProject `json:"forked_from_project"`
+	Topics            []string       `json:"topics"`
+	TagList           []string       `json:"tag_list"` // Topics on GitLab versions before 14.0
+}
+
+// CreateLister creates a client that lists the repositories of a discovery source
+func (f *ClientFactory) CreateLister(source types.DiscoverySource) (RepositoryLister, error) {
+	repo := types.Repository{
+		Name:       source.Name,
+		URL:        source.OwnerURL(),
+		Provider:   source.Provider,
+		Token:      source.Token,
+		APIBaseURL: source.APIBaseURL,
+	}
+
+	config := GetDefaultConfig()
+	config.Token = source.Token
+	config.BaseURL = source.APIBaseURL
+
+	client, err := f.CreateClient(repo, config)
+	if err != nil {
+		return nil, err
+	}
+
+	lister, ok := client.(RepositoryLister)
+	if !ok {
+		return nil, &UnsupportedProviderError{Provider: source.Provider}
+	}
+	return lister, nil
+}
+
+// ListRepositories lists the repositories of a GitHub organization or user
+func (c *GitHubClient) ListRepositories(ctx context.Context, source types.DiscoverySource) ([]DiscoveredRepository, error) {
+	var listURL string
+	switch {
+	case source.Organization != "":
+		listURL = fmt.Sprintf("%s/orgs/%s/repos?type=all", c.baseURL, url.PathEscape(source.Organization))
+	case source.User != "":
+		listURL = fmt.Sprintf("%s/users/%s/repos?type=owner", c.baseURL, url.PathEscape(source.User))
+	default:
+		return nil, fmt.Errorf("discovery source %s has no organization or user", source.Name)
+	}
+	listURL = appendQuery(listURL, fmt.Sprintf("per_page=%d", c.config.pageSize()))
+
+	var repos []DiscoveredRepository
+	for page := 1; listURL != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":        "list_repositories",
+				"source":           source.Name,
+				"max_pages":        c.config.maxPages(),
+				"repository_count": len(repos),
+			}).Warn("Reached page limit, repository list is incomplete")
+			return repos, &IncompleteListingError{
+				Provider: "github",
+				Resource: "repositories of " + source.Owner(),
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var githubRepos []GitHubRepository
+		headers, err := c.doRequest(ctx, "GET", listURL, nil, &githubRepos)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, gr := range githubRepos {
+			repos = append(repos, DiscoveredRepository{
+				FullName:      gr.FullName,
+				URL:           gr.HTMLURL,
+				DefaultBranch: gr.DefaultBranch,
+				Archived:      gr.Archived,
+				Fork:          gr.Fork,
+				Topics:        gr.Topics,
+			})
+		}
+
+		listURL = parseNextLink(headers)
+	}
+
+	return repos, nil
+}
+
+// ListRepositories lists the projects of a GitLab group, and of its subgroups
+// when the source includes them
+func (c *GitLabClient) ListRepositories(ctx context.Context, source types.DiscoverySource) ([]DiscoveredRepository, error) {
+	if source.Group == "" {
+		return nil, fmt.Errorf("discovery source %s has no group", source.Name)
+	}
+
+	baseURL := fmt.Sprintf("%s/groups/%s/projects", c.baseURL, url.PathEscape(source.Group))
+	if source.IncludeSubgroups {
+		baseURL = appendQuery(baseURL, "include_subgroups=true")
+	}
+
+	var repos []DiscoveredRepository
+	for page := 1; page > 0; {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":        "list_repositories",
+				"source":           source.Name,
+				"max_pages":        c.config.maxPages(),
+				"repository_count": len(repos),
+			}).Warn("Reached page limit, repository list is incomplete")
+			return repos, &IncompleteListingError{
+				Provider: "gitlab",
+				Resource: "projects of " + source.Group,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		listURL := appendQuery(baseURL, fmt.Sprintf("per_page=%d&page=%d", c.config.pageSize(), page))
+
+		var projects []GitLabGroupProject
+		headers, err := c.doRequest(ctx, "GET", listURL, nil, &projects)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, project := range projects {
+			topics := project.Topics
+			if len(topics) == 0 {
+				topics = project.TagList
+			}
+			repos = append(repos, DiscoveredRepository{
+				FullName:      project.PathWithNamespace,
+				URL:           project.WebURL,
+				DefaultBranch: project.DefaultBranch,
+				Archived:      project.Archived,
+				Fork:          project.ForkedFromProject != nil,
+				Topics:        topics,
+			})
+		}
+
+		page = parseNextPage(headers)
+	}
+
+	return repos, nil
+}
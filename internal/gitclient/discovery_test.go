@@ -0,0 +1,164 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestGitHubClient_ListRepositories(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/orgs/acme/repos", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("type"))
+
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?type=all&per_page=100&page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"full_name":"acme/api","html_url":"https://github.com/acme/api","default_branch":"main","topics":["service"]}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"full_name":"acme/old","html_url":"https://github.com/acme/old","default_branch":"master","archived":true,"fork":true}]`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repos, err := client.ListRepositories(context.Background(), types.DiscoverySource{Name: "acme", Provider: "github", Organization: "acme"})
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+
+	assert.Equal(t, DiscoveredRepository{
+		FullName:      "acme/api",
+		URL:           "https://github.com/acme/api",
+		DefaultBranch: "main",
+		Topics:        []string{"service"},
+	}, repos[0])
+	assert.True(t, repos[1].Archived)
+	assert.True(t, repos[1].Fork)
+}
+
+func TestGitHubClient_ListUserRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/users/octocat/repos", r.URL.Path)
+		assert.Equal(t, "owner", r.URL.Query().Get("type"))
+		fmt.Fprint(w, `[{"full_name":"octocat/hello","html_url":"https://github.com/octocat/hello"}]`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repos, err := client.ListRepositories(context.Background(), types.DiscoverySource{Name: "octocat", Provider: "github", User: "octocat"})
+	require.NoError(t, err)
+	require.Len(t, repos, 1)
+	assert.Equal(t, "octocat/hello", repos[0].FullName)
+}
+
+func TestGitLabClient_ListRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/groups/platform%2Fbackend/projects", r.URL.EscapedPath())
+		assert.Equal(t, "true", r.URL.Query().Get("include_subgroups"))
+
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"path_with_namespace":"platform/backend/api","web_url":"https://gitlab.example.com/platform/backend/api","default_branch":"main","topics":["service"]}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"path_with_namespace":"platform/backend/jobs/worker","web_url":"https://gitlab.example.com/platform/backend/jobs/worker",
+			"archived":true,"forked_from_project":{"id":7},"tag_list":["legacy"]}]`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repos, err := client.ListRepositories(context.Background(), types.DiscoverySource{
+		Name:             "backend",
+		Provider:         "gitlab",
+		Group:            "platform/backend",
+		IncludeSubgroups: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, repos, 2)
+
+	assert.Equal(t, "platform/backend/api", repos[0].FullName)
+	assert.Equal(t, "main", repos[0].DefaultBranch)
+	assert.Equal(t, []string{"service"}, repos[0].Topics)
+	assert.False(t, repos[0].Fork)
+
+	assert.Equal(t, "platform/backend/jobs/worker", repos[1].FullName)
+	assert.True(t, repos[1].Archived)
+	assert.True(t, repos[1].Fork)
+	assert.Equal(t, []string{"legacy"}, repos[1].Topics, "older GitLab versions report topics as tag_list")
+}
+
+func TestListRepositories_MaxPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every page has a next one
+		if r.URL.Path == "/orgs/acme/repos" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"full_name":"acme/api"}]`)
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		fmt.Fprint(w, `[{"path_with_namespace":"platform/api"}]`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.MaxPages = 2
+
+	githubClient, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	repos, err := githubClient.ListRepositories(context.Background(), types.DiscoverySource{Name: "acme", Provider: "github", Organization: "acme"})
+	assert.True(t, IsIncompleteListing(err))
+	assert.Len(t, repos, 2, "the repositories listed before the cap are returned")
+
+	gitlabClient, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	repos, err = gitlabClient.ListRepositories(context.Background(), types.DiscoverySource{Name: "platform", Provider: "gitlab", Group: "platform"})
+	assert.True(t, IsIncompleteListing(err))
+	assert.Len(t, repos, 2)
+}
+
+func TestClientFactory_CreateLister(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+
+	lister, err := factory.CreateLister(types.DiscoverySource{Name: "acme", Provider: "github", Organization: "acme", Token: "token"})
+	require.NoError(t, err)
+	assert.IsType(t, &GitHubClient{}, lister)
+
+	lister, err = factory.CreateLister(types.DiscoverySource{Name: "platform", Provider: "gitlab", Group: "platform", URL: "https://gitlab.example.com", Token: "token"})
+	require.NoError(t, err)
+	require.IsType(t, &GitLabClient{}, lister)
+	assert.Equal(t, "https://gitlab.example.com/api/v4", lister.(*GitLabClient).baseURL)
+
+	_, err = factory.CreateLister(types.DiscoverySource{Name: "team", Provider: "bitbucket", Organization: "team", Token: "token"})
+	require.Error(t, err)
+	assert.IsType(t, &UnsupportedProviderError{}, err)
+}
@@ -16,6 +16,7 @@ import (
 // FallbackClient implements Git operations using git commands
 type FallbackClient struct {
 	timeout time.Duration
+	mirrors *mirrorManager // Bare mirrors for file and tree lookups; nil until SetMirrorDir
 	logger  *logger.Entry
 }
 
@@ -34,6 +35,12 @@ func NewFallbackClient(parentLogger *logger.Entry) *FallbackClient {
 	}
 }
 
+// SetMirrorDir sets the directory holding the shallow bare mirrors used to answer
+// file and tree lookups. File operations fail until a directory is set.
+func (f *FallbackClient) SetMirrorDir(dir string) {
+	f.mirrors = newMirrorManager(dir, f.logger)
+}
+
 // GetBranches retrieves branches using git ls-remote
 func (f *FallbackClient) GetBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error) {
 	f.logger.WithFields(logger.Fields{
@@ -46,7 +53,7 @@ func (f *FallbackClient) GetBranches(ctx context.Context, repo types.Repository)
 	defer cancel()
 
 	// Use git ls-remote to list branches
-	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--heads", repo.URL)
+	cmd := gitCommand(ctx, repo, "ls-remote", "--heads", repo.URL)
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -79,6 +86,44 @@ func (f *FallbackClient) GetBranches(ctx context.Context, repo types.Repository)
 	return branches, nil
 }
 
+// GetTags retrieves tags using git ls-remote
+func (f *FallbackClient) GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	cmd := gitCommand(ctx, repo, "ls-remote", "--tags", repo.URL)
+
+	output, err := cmd.Output()
+	if err != nil {
+		f.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "get_tags",
+			"repository": repo.Name,
+			"url":        repo.URL,
+		}).Error("Git ls-remote command failed")
+		return nil, &NetworkError{
+			Provider: "git-fallback",
+			Err:      fmt.Errorf("git ls-remote failed: %w", err),
+		}
+	}
+
+	return parseLsRemoteTags(string(output)), nil
+}
+
+// ListPullRequests is not supported by the git fallback; pull requests are a provider API concept
+func (f *FallbackClient) ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error) {
+	return nil, fmt.Errorf("ListPullRequests not implemented in fallback client - API client required")
+}
+
+// GetPullRequest is not supported by the git fallback
+func (f *FallbackClient) GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error) {
+	return nil, fmt.Errorf("GetPullRequest not implemented in fallback client - API client required")
+}
+
+// CompareCommits is not supported by the git fallback, which only reads refs
+func (f *FallbackClient) CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error) {
+	return nil, fmt.Errorf("CompareCommits not implemented in fallback client - API client required")
+}
+
 // GetLatestCommit retrieves latest commit for a specific branch using git ls-remote
 func (f *FallbackClient) GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, f.timeout)
@@ -86,7 +131,7 @@ func (f *FallbackClient) GetLatestCommit(ctx context.Context, repo types.Reposit
 
 	// Use git ls-remote to get specific branch
 	refName := fmt.Sprintf("refs/heads/%s", branch)
-	cmd := exec.CommandContext(ctx, "git", "ls-remote", repo.URL, refName)
+	cmd := gitCommand(ctx, repo, "ls-remote", repo.URL, refName)
 
 	output, err := cmd.Output()
 	if err != nil {
@@ -113,13 +158,48 @@ func (f *FallbackClient) GetLatestCommit(ctx context.Context, repo types.Reposit
 	return parts[0], nil
 }
 
+// GetDefaultBranch reads the branch the remote HEAD points to using git ls-remote --symref
+func (f *FallbackClient) GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	cmd := gitCommand(ctx, repo, "ls-remote", "--symref", repo.URL, "HEAD")
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", &NetworkError{
+			Provider: "git-fallback",
+			Err:      fmt.Errorf("git ls-remote --symref failed: %w", err),
+		}
+	}
+
+	branch := parseSymrefOutput(string(output))
+	if branch == "" {
+		return "", fmt.Errorf("remote HEAD of %s does not point to a branch", repo.URL)
+	}
+	return branch, nil
+}
+
+// parseSymrefOutput extracts the branch from the "ref: refs/heads/<branch>\tHEAD"
+// line that git ls-remote --symref prints for HEAD
+func parseSymrefOutput(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		ref, name, found := strings.Cut(strings.TrimSpace(line), "\t")
+		if !found || name != "HEAD" || !strings.HasPrefix(ref, "ref: ") {
+			continue
+		}
+		return strings.TrimPrefix(strings.TrimPrefix(ref, "ref: "), "refs/heads/")
+	}
+	return ""
+}
+
 // CheckPermissions checks if repository is accessible using git ls-remote
 func (f *FallbackClient) CheckPermissions(ctx context.Context, repo types.Repository) error {
 	ctx, cancel := context.WithTimeout(ctx, f.timeout)
 	defer cancel()
 
 	// Try to list remote references
-	cmd := exec.CommandContext(ctx, "git", "ls-remote", "--exit-code", repo.URL)
+	cmd := gitCommand(ctx, repo, "ls-remote", "--exit-code", repo.URL)
 
 	if err := cmd.Run(); err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -157,46 +237,119 @@ func (f *FallbackClient) Close() error {
 	return nil
 }
 
-// ListFiles retrieves files from a repository path (fallback implementation)
-func (f *FallbackClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]string, error) {
+// ListFiles lists the files below path at a commit using the repository's bare mirror
+func (f *FallbackClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error) {
 	f.logger.WithFields(logger.Fields{
-		"operation": "list_files",
+		"operation":  "list_files",
 		"repository": repo.Name,
-		"commit": commitSHA,
-		"path": path,
+		"commit":     commitSHA,
+		"path":       path,
 	}).Info("Starting fallback file listing")
 
-	// Note: This is a simplified fallback implementation
-	// In production, you might want to implement this using git archive or similar
-	return nil, fmt.Errorf("ListFiles not implemented in fallback client - API client required")
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	mirror, sha, err := f.prepareMirror(ctx, repo, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := runGit(ctx, repo, mirror, "ls-tree", "-r", "-z", sha)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each record is "<mode> <type> <sha>\t<path>"
+	path = strings.Trim(path, "/")
+	var files []FileEntry
+	for _, record := range strings.Split(string(output), "\x00") {
+		info, name, found := strings.Cut(record, "\t")
+		if !found || (path != "" && !strings.HasPrefix(name, path+"/")) {
+			continue
+		}
+		fields := strings.Fields(info)
+		if len(fields) != 3 || fields[1] != "blob" {
+			continue
+		}
+		files = append(files, FileEntry{Path: name, SHA: fields[2]})
+	}
+
+	return files, nil
 }
 
-// GetFileContent retrieves file content (fallback implementation)
+// GetFileContent reads a file at a commit from the repository's bare mirror
 func (f *FallbackClient) GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error) {
 	f.logger.WithFields(logger.Fields{
-		"operation": "get_file_content",
+		"operation":  "get_file_content",
 		"repository": repo.Name,
-		"commit": commitSHA,
-		"file_path": filePath,
+		"commit":     commitSHA,
+		"file_path":  filePath,
 	}).Info("Starting fallback file content retrieval")
 
-	// Note: This is a simplified fallback implementation
-	// In production, you might want to implement this using git show or similar
-	return nil, fmt.Errorf("GetFileContent not implemented in fallback client - API client required")
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	mirror, sha, err := f.prepareMirror(ctx, repo, commitSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	object := sha + ":" + strings.TrimPrefix(filePath, "/")
+	if objectType, err := runGit(ctx, repo, mirror, "cat-file", "-t", object); err != nil || strings.TrimSpace(string(objectType)) != "blob" {
+		return nil, &RepositoryNotFoundError{
+			Repository: fmt.Sprintf("%s (file: %s)", repo.URL, filePath),
+			Provider:   "git-fallback",
+		}
+	}
+
+	return runGit(ctx, repo, mirror, "cat-file", "blob", object)
 }
 
-// CheckDirectoryExists checks if directory exists (fallback implementation)
+// CheckDirectoryExists checks for a directory at a commit in the repository's bare mirror
 func (f *FallbackClient) CheckDirectoryExists(ctx context.Context, repo types.Repository, commitSHA, dirPath string) (bool, error) {
 	f.logger.WithFields(logger.Fields{
-		"operation": "check_directory_exists",
+		"operation":  "check_directory_exists",
 		"repository": repo.Name,
-		"commit": commitSHA,
-		"dir_path": dirPath,
+		"commit":     commitSHA,
+		"dir_path":   dirPath,
 	}).Info("Starting fallback directory existence check")
 
-	// Note: This is a simplified fallback implementation
-	// In production, you might want to implement this using git ls-tree or similar
-	return false, fmt.Errorf("CheckDirectoryExists not implemented in fallback client - API client required")
+	ctx, cancel := context.WithTimeout(ctx, f.timeout)
+	defer cancel()
+
+	mirror, sha, err := f.prepareMirror(ctx, repo, commitSHA)
+	if err != nil {
+		return false, err
+	}
+
+	object := sha + ":" + strings.Trim(dirPath, "/")
+	objectType, err := runGit(ctx, repo, mirror, "cat-file", "-t", object)
+	if err != nil {
+		// Missing paths make cat-file fail; the commit itself is known to exist
+		return false, nil
+	}
+
+	return strings.TrimSpace(string(objectType)) == "tree", nil
+}
+
+// prepareMirror fetches a commit into the repository's mirror and returns the
+// mirror path and the full commit SHA
+func (f *FallbackClient) prepareMirror(ctx context.Context, repo types.Repository, commitSHA string) (string, string, error) {
+	if f.mirrors == nil {
+		return "", "", fmt.Errorf("file access in fallback client requires a mirror directory")
+	}
+
+	mirror, sha, err := f.mirrors.ensureRevision(ctx, repo, commitSHA)
+	if err != nil {
+		f.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "prepare_mirror",
+			"repository": repo.Name,
+			"commit":     commitSHA,
+		}).Error("Failed to fetch commit into mirror")
+		return "", "", err
+	}
+
+	return mirror, sha, nil
 }
 
 // parseLsRemoteOutput parses git ls-remote output to extract branches
@@ -233,6 +386,33 @@ func (f *FallbackClient) parseLsRemoteOutput(output string) ([]types.Branch, err
 	return branches, nil
 }
 
+// parseLsRemoteTags parses git ls-remote --tags output. Annotated tags are listed
+// twice; the peeled "^{}" entry carries the commit the tag points to and wins.
+func parseLsRemoteTags(output string) []types.Tag {
+	tagRegex := regexp.MustCompile(`^([a-f0-9A-F]+)\s+refs/tags/(.+)$`)
+
+	tags := []types.Tag{}
+	index := make(map[string]int)
+	for _, line := range strings.Split(output, "\n") {
+		matches := tagRegex.FindStringSubmatch(strings.TrimSpace(line))
+		if len(matches) != 3 {
+			continue
+		}
+
+		name, peeled := strings.CutSuffix(matches[2], "^{}")
+		if i, exists := index[name]; exists {
+			if peeled {
+				tags[i].CommitSHA = matches[1]
+			}
+			continue
+		}
+		index[name] = len(tags)
+		tags = append(tags, types.Tag{Name: name, CommitSHA: matches[1]})
+	}
+
+	return tags
+}
+
 // Wait implements a simple delay for fallback client
 func (f *FallbackClient) Wait(ctx context.Context) error {
 	// Simple rate limiting for git commands
@@ -283,28 +463,6 @@ func ParseGitVersion(ctx context.Context) (string, error) {
 	return matches[1], nil
 }
 
-// ConfigureGitCredentials configures git credentials for authentication
-func ConfigureGitCredentials(ctx context.Context, repo types.Repository) error {
-	if repo.Token == "" {
-		return nil // No token to configure
-	}
-
-	// For HTTP(S) URLs, we can configure credentials via git config
-	if strings.HasPrefix(repo.URL, "https://") {
-		// Configure credential helper to use token
-		// This is a simplified approach - in production you might want more sophisticated credential management
-		return nil
-	}
-
-	return nil
-}
-
-// CleanupGitCredentials removes configured credentials
-func CleanupGitCredentials(ctx context.Context, repo types.Repository) error {
-	// In a real implementation, you would clean up any configured credentials
-	return nil
-}
-
 // ValidateGitRepository checks if a URL is a valid git repository
 func ValidateGitRepository(ctx context.Context, repoURL string) error {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
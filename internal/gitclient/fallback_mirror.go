@@ -0,0 +1,227 @@
+package gitclient
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
+)
+
+var (
+	fullSHAPattern      = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+	mirrorNameSanitizer = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+)
+
+// mirrorManager keeps shallow bare mirrors of repositories so file and tree
+// lookups can be answered with local git commands
+type mirrorManager struct {
+	dir    string
+	mu     sync.Mutex
+	locks  map[string]*sync.Mutex
+	logger *logger.Entry
+}
+
+// newMirrorManager creates a mirror manager rooted at dir
+func newMirrorManager(dir string, parentLogger *logger.Entry) *mirrorManager {
+	return &mirrorManager{
+		dir:    dir,
+		locks:  make(map[string]*sync.Mutex),
+		logger: parentLogger,
+	}
+}
+
+// mirrorPath returns the mirror directory for a repository. The URL hash keeps
+// repositories that share a name apart.
+func (m *mirrorManager) mirrorPath(repo types.Repository) string {
+	sum := sha256.Sum256([]byte(repo.URL))
+	name := mirrorNameSanitizer.ReplaceAllString(repo.Name, "_")
+	return filepath.Join(m.dir, fmt.Sprintf("%s-%s.git", name, hex.EncodeToString(sum[:])[:12]))
+}
+
+// lock serializes git operations on one mirror
+func (m *mirrorManager) lock(path string) func() {
+	m.mu.Lock()
+	l, ok := m.locks[path]
+	if !ok {
+		l = &sync.Mutex{}
+		m.locks[path] = l
+	}
+	m.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
+
+// ensureRevision makes sure a commit is present in the repository's mirror and
+// returns the mirror path and the full commit SHA. Full SHAs already in the
+// mirror are used as is; anything else is fetched shallowly from the remote.
+func (m *mirrorManager) ensureRevision(ctx context.Context, repo types.Repository, revision string) (string, string, error) {
+	path := m.mirrorPath(repo)
+	unlock := m.lock(path)
+	defer unlock()
+
+	if _, err := os.Stat(filepath.Join(path, "HEAD")); err != nil {
+		if err := os.MkdirAll(m.dir, 0o755); err != nil {
+			return "", "", fmt.Errorf("failed to create mirror directory: %w", err)
+		}
+		if _, err := runGit(ctx, repo, "", "init", "--quiet", "--bare", path); err != nil {
+			return "", "", err
+		}
+		m.logger.WithFields(logger.Fields{
+			"operation":  "ensure_mirror",
+			"repository": repo.Name,
+			"path":       path,
+		}).Info("Created bare mirror")
+	}
+
+	if fullSHAPattern.MatchString(revision) {
+		if _, err := runGit(ctx, repo, path, "cat-file", "-e", revision+"^{commit}"); err == nil {
+			return path, strings.ToLower(revision), nil
+		}
+	}
+
+	if _, err := runGit(ctx, repo, path, "fetch", "--quiet", "--depth=1", "--no-tags", repo.URL, revision); err != nil {
+		return "", "", err
+	}
+
+	output, err := runGit(ctx, repo, path, "rev-parse", "FETCH_HEAD^{commit}")
+	if err != nil {
+		return "", "", err
+	}
+
+	return path, strings.TrimSpace(string(output)), nil
+}
+
+// runGit runs a git command, inside dir when set, authenticated for the repository
+func runGit(ctx context.Context, repo types.Repository, dir string, args ...string) ([]byte, error) {
+	subcommand := args[0]
+	if dir != "" {
+		args = append([]string{"-C", dir}, args...)
+	}
+
+	var stderr bytes.Buffer
+	cmd := gitCommand(ctx, repo, args...)
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, &NetworkError{
+			Provider: "git-fallback",
+			Err:      fmt.Errorf("git %s failed: %w: %s", subcommand, err, strings.TrimSpace(stderr.String())),
+		}
+	}
+	return output, nil
+}
+
+// gitCommand builds a git command that authenticates as the repository. Over
+// HTTP(S) the token travels as an http.extraHeader scoped to the repository's
+// origin and is passed through GIT_CONFIG_* environment variables, never argv, so
+// it does not show up in process listings or error messages. SSH URLs use the
+// repository's deploy key and known_hosts file instead.
+func gitCommand(ctx context.Context, repo types.Repository, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if utils.IsSSHURL(repo.URL) {
+		if sshCommand := gitSSHCommand(repo); sshCommand != "" {
+			cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+sshCommand)
+		}
+		return cmd
+	}
+
+	header, origin := gitAuthHeader(repo)
+	if header == "" {
+		return cmd
+	}
+
+	// Append to any configuration already passed through the environment
+	index := 0
+	if count, err := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT")); err == nil && count > 0 {
+		index = count
+	}
+	cmd.Env = append(cmd.Env,
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", index+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.%s/.extraHeader", index, origin),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: %s", index, header),
+	)
+	return cmd
+}
+
+// gitSSHCommand returns the ssh command git should use for a repository with a
+// deploy key or known_hosts file configured, or an empty string to keep the
+// default. Host keys are checked strictly and ssh never prompts.
+func gitSSHCommand(repo types.Repository) string {
+	if repo.SSHKeyFile == "" && repo.SSHKnownHosts == "" {
+		return ""
+	}
+
+	base := os.Getenv("GIT_SSH_COMMAND")
+	if base == "" {
+		base = "ssh"
+	}
+
+	parts := []string{base, "-o", "BatchMode=yes"}
+	if repo.SSHKeyFile != "" {
+		parts = append(parts, "-i", shellQuote(repo.SSHKeyFile), "-o", "IdentitiesOnly=yes")
+	}
+	if repo.SSHKnownHosts != "" {
+		parts = append(parts, "-o", shellQuote("UserKnownHostsFile="+repo.SSHKnownHosts), "-o", "StrictHostKeyChecking=yes")
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote quotes a value for the shell git runs GIT_SSH_COMMAND with
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// gitAuthHeader returns the Authorization header value git should send for a
+// repository and the origin it applies to, or empty strings when the repository
+// has no token or is not served over HTTP(S)
+func gitAuthHeader(repo types.Repository) (string, string) {
+	if repo.Token == "" {
+		return "", ""
+	}
+
+	parsed, err := url.Parse(repo.URL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return "", ""
+	}
+	origin := parsed.Scheme + "://" + parsed.Host
+
+	// Each provider expects the token under a different basic auth username
+	var username string
+	switch repo.Provider {
+	case "github":
+		username = "x-access-token"
+	case "gitlab":
+		username = "oauth2"
+	case "bitbucket":
+		if parsed.Host != "bitbucket.org" {
+			// Bitbucket Data Center HTTP access tokens are bearer tokens
+			return "Bearer " + repo.Token, origin
+		}
+		username = "x-token-auth"
+	case "gitea", "forgejo":
+		// Gitea treats the username as the token when the password is x-oauth-basic
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(repo.Token+":x-oauth-basic")), origin
+	default:
+		return "Bearer " + repo.Token, origin
+	}
+
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+repo.Token)), origin
+}
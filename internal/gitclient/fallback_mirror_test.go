@@ -0,0 +1,198 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestGitRepo creates a repository with two commits and returns its file://
+// URL and the commit SHAs, oldest first
+func createTestGitRepo(t *testing.T) (string, []string) {
+	t.Helper()
+	if err := TestGitAvailability(context.Background()); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir, "-c", "user.name=Test", "-c", "user.email=test@example.com"}, args...)...)
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	write := func(name, content string) {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	git("init", "--quiet")
+	write(".tekton/pipeline.yaml", "kind: Pipeline\n")
+	write("README.md", "first\n")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "first")
+	first := git("rev-parse", "HEAD")
+
+	write(".tekton/tasks/build.yaml", "kind: Task\n")
+	write(".tekton-old/pipeline.yaml", "kind: Pipeline\n")
+	write("README.md", "second\n")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "second")
+	second := git("rev-parse", "HEAD")
+
+	return "file://" + dir, []string{first, second}
+}
+
+func TestFallbackClient_MirrorFileOperations(t *testing.T) {
+	repoURL, commits := createTestGitRepo(t)
+
+	client := NewFallbackClient(logger.GetDefaultLogger().WithField("test", "fallback_mirror"))
+	client.SetMirrorDir(t.TempDir())
+
+	repo := types.Repository{Name: "local/repo", URL: repoURL}
+	ctx := context.Background()
+
+	files, err := client.ListFiles(ctx, repo, commits[1], ".tekton")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".tekton/pipeline.yaml", ".tekton/tasks/build.yaml"}, filePaths(files), "sibling directories sharing the prefix are not listed")
+	assert.Equal(t, gitBlobSHA1([]byte("kind: Pipeline\n")), files[0].SHA)
+
+	// Older commits are fetched into the same mirror
+	files, err = client.ListFiles(ctx, repo, commits[0], "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{".tekton/pipeline.yaml", "README.md"}, filePaths(files))
+
+	content, err := client.GetFileContent(ctx, repo, commits[0], "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "first\n", string(content))
+
+	// Branch names are resolved through the remote
+	content, err = client.GetFileContent(ctx, repo, "HEAD", "README.md")
+	require.NoError(t, err)
+	assert.Equal(t, "second\n", string(content))
+
+	_, err = client.GetFileContent(ctx, repo, commits[1], "missing.txt")
+	var notFound *RepositoryNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+
+	exists, err := client.CheckDirectoryExists(ctx, repo, commits[1], ".tekton/tasks")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, commits[0], ".tekton/tasks")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, commits[1], "README.md")
+	require.NoError(t, err)
+	assert.False(t, exists, "files are not directories")
+}
+
+func TestFallbackClient_MirrorRequiresDirectory(t *testing.T) {
+	client := NewFallbackClient(logger.GetDefaultLogger().WithField("test", "fallback_mirror"))
+
+	_, err := client.ListFiles(context.Background(), types.Repository{Name: "repo", URL: "file:///nonexistent"}, "main", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "mirror directory")
+}
+
+func TestFallbackClient_TokenAuthentication(t *testing.T) {
+	if err := TestGitAvailability(context.Background()); err != nil {
+		t.Skip("git not available")
+	}
+
+	authorization := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization <- r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := NewFallbackClient(logger.GetDefaultLogger().WithField("test", "fallback_auth"))
+	repo := types.Repository{
+		Name:     "private",
+		URL:      server.URL + "/owner/private.git",
+		Provider: "gitlab",
+		Token:    "secret-token",
+	}
+
+	_, err := client.GetBranches(context.Background(), repo)
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-token")
+
+	expected := "Basic " + base64.StdEncoding.EncodeToString([]byte("oauth2:secret-token"))
+	select {
+	case header := <-authorization:
+		assert.Equal(t, expected, header)
+	default:
+		t.Fatal("git made no request")
+	}
+
+	cmd := gitCommand(context.Background(), repo, "ls-remote", repo.URL)
+	assert.NotContains(t, strings.Join(cmd.Args, " "), "secret-token", "token must not be passed in argv")
+}
+
+func TestGitCommand_SSHKey(t *testing.T) {
+	t.Setenv("GIT_SSH_COMMAND", "")
+
+	repo := types.Repository{
+		URL:           "git@github.com:owner/repo.git",
+		Token:         "secret-token",
+		SSHKeyFile:    "/keys/deploy key",
+		SSHKnownHosts: "/keys/known_hosts",
+	}
+
+	cmd := gitCommand(context.Background(), repo, "ls-remote", repo.URL)
+	assert.Contains(t, cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes -i '/keys/deploy key' -o IdentitiesOnly=yes -o 'UserKnownHostsFile=/keys/known_hosts' -o StrictHostKeyChecking=yes")
+	for _, env := range cmd.Env {
+		assert.NotContains(t, env, "secret-token", "tokens are not sent over SSH")
+	}
+
+	// Without a deploy key the default ssh configuration is used
+	repo.SSHKeyFile, repo.SSHKnownHosts = "", ""
+	cmd = gitCommand(context.Background(), repo, "ls-remote", repo.URL)
+	for _, env := range cmd.Env {
+		assert.NotContains(t, env, "GIT_SSH_COMMAND=ssh")
+	}
+}
+
+func TestGitAuthHeader(t *testing.T) {
+	basic := func(credentials string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	tests := []struct {
+		name   string
+		repo   types.Repository
+		header string
+		origin string
+	}{
+		{"github", types.Repository{Provider: "github", URL: "https://github.com/o/r", Token: "t"}, basic("x-access-token:t"), "https://github.com"},
+		{"gitlab", types.Repository{Provider: "gitlab", URL: "https://gitlab.example.com/g/r.git", Token: "t"}, basic("oauth2:t"), "https://gitlab.example.com"},
+		{"bitbucket cloud", types.Repository{Provider: "bitbucket", URL: "https://bitbucket.org/w/r", Token: "t"}, basic("x-token-auth:t"), "https://bitbucket.org"},
+		{"bitbucket data center", types.Repository{Provider: "bitbucket", URL: "https://git.example.com/scm/p/r.git", Token: "t"}, "Bearer t", "https://git.example.com"},
+		{"gitea", types.Repository{Provider: "gitea", URL: "https://gitea.example.com/o/r", Token: "t"}, basic("t:x-oauth-basic"), "https://gitea.example.com"},
+		{"no token", types.Repository{Provider: "github", URL: "https://github.com/o/r"}, "", ""},
+		{"ssh url", types.Repository{Provider: "github", URL: "git@github.com:o/r.git", Token: "t"}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header, origin := gitAuthHeader(tt.repo)
+			assert.Equal(t, tt.header, header)
+			assert.Equal(t, tt.origin, origin)
+		})
+	}
+}
@@ -0,0 +1,698 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
+)
+
+// GiteaClient implements GitClient for the Gitea API (also served by Forgejo)
+type GiteaClient struct {
+	config      ClientConfig
+	httpClient  *http.Client
+	rateLimiter RateLimiter
+	fallback    *FallbackClient
+	baseURL     string
+	breaker     *CircuitBreaker // Shared by clients of the same API, nil when disabled
+	logger      *logger.Entry
+}
+
+// GiteaBranch represents a branch response from Gitea API
+type GiteaBranch struct {
+	Name      string      `json:"name"`
+	Commit    GiteaCommit `json:"commit"`
+	Protected bool        `json:"protected"`
+}
+
+// GiteaCommit represents a commit in Gitea API branch response
+type GiteaCommit struct {
+	ID string `json:"id"`
+}
+
+// GiteaTag represents a tag response from Gitea API
+type GiteaTag struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+}
+
+// GiteaPullRequest represents a pull request response from Gitea API
+type GiteaPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	State   string `json:"state"` // open or closed
+	Merged  bool   `json:"merged"`
+	Draft   bool   `json:"draft"`
+	HTMLURL string `json:"html_url"`
+	Head    struct {
+		SHA string `json:"sha"`
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// toPullRequest converts a Gitea pull request to our type
+func (pr GiteaPullRequest) toPullRequest() types.PullRequest {
+	state := types.PullRequestStateOpen
+	if pr.Merged {
+		state = types.PullRequestStateMerged
+	} else if pr.State == "closed" {
+		state = types.PullRequestStateClosed
+	}
+
+	labels := make([]string, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return types.PullRequest{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        state,
+		HeadSHA:      pr.Head.SHA,
+		SourceBranch: pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		Author:       pr.User.Login,
+		Labels:       labels,
+		Draft:        pr.Draft,
+		URL:          pr.HTMLURL,
+	}
+}
+
+// GiteaTreeItem represents a single item in Gitea's git tree API response
+type GiteaTreeItem struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	SHA  string `json:"sha"`
+	Size int    `json:"size"`
+}
+
+// GiteaTree represents Gitea's git tree API response
+type GiteaTree struct {
+	SHA        string          `json:"sha"`
+	Tree       []GiteaTreeItem `json:"tree"`
+	Truncated  bool            `json:"truncated"`
+	Page       int             `json:"page"`
+	TotalCount int             `json:"total_count"`
+}
+
+// GiteaContent represents Gitea's contents API response for a single file
+type GiteaContent struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	SHA      string `json:"sha"`
+	Type     string `json:"type"`
+	Size     int    `json:"size"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// GiteaRepository represents a repository in Gitea API response
+type GiteaRepository struct {
+	FullName      string `json:"full_name"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// NewGiteaClient creates a new Gitea client
+func NewGiteaClient(config ClientConfig, rateLimiter RateLimiter, fallback *FallbackClient, parentLogger *logger.Entry) (*GiteaClient, error) {
+	if config.Token == "" {
+		return nil, fmt.Errorf("Gitea token is required")
+	}
+
+	baseURL := strings.TrimSuffix(config.BaseURL, "/")
+	if baseURL == "" {
+		if config.RepositoryURL == "" {
+			return nil, fmt.Errorf("Gitea base URL or repository URL is required")
+		}
+		baseURL = extractGiteaAPIURL(config.RepositoryURL)
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	clientLogger := parentLogger.WithFields(logger.Fields{
+		"component": "gitclient",
+		"provider":  "gitea",
+		"base_url":  baseURL,
+	})
+
+	clientLogger.Info("Initializing Gitea client")
+
+	return &GiteaClient{
+		config:      config,
+		httpClient:  httpClient,
+		rateLimiter: rateLimiter,
+		fallback:    fallback,
+		baseURL:     baseURL,
+		logger:      clientLogger,
+	}, nil
+}
+
+// GetBranches retrieves all branches for a repository
+func (c *GiteaClient) GetBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetBranches(ctx, repo)
+		}
+		return nil, err
+	}
+
+	// Gitea clamps limit to its MAX_RESPONSE_ITEMS setting, so follow Link headers
+	// rather than assuming the requested page size was honoured
+	url := fmt.Sprintf("%s/repos/%s/%s/branches?limit=%d", c.baseURL, owner, repoName, c.config.pageSize())
+
+	var branches []types.Branch
+	for page := 1; url != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":    "get_branches",
+				"repository":   repo.Name,
+				"max_pages":    c.config.maxPages(),
+				"branch_count": len(branches),
+			}).Warn("Reached page limit, branch list is incomplete")
+			return branches, &IncompleteListingError{
+				Provider: "gitea",
+				Resource: "branches of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var giteaBranches []GiteaBranch
+		headers, err := c.doRequest(ctx, "GET", url, &giteaBranches)
+		if err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "get_branches",
+				"repository": repo.Name,
+				"url":        url,
+			}).Error("API request failed")
+			if c.config.EnableFallback && IsRetryableError(err) {
+				return c.fallback.GetBranches(ctx, repo)
+			}
+			return nil, err
+		}
+
+		for _, gb := range giteaBranches {
+			branches = append(branches, types.Branch{
+				Name:      gb.Name,
+				CommitSHA: gb.Commit.ID,
+				Protected: gb.Protected,
+			})
+		}
+
+		url = parseNextLink(headers)
+	}
+
+	c.logger.WithFields(logger.Fields{
+		"operation":    "get_branches",
+		"repository":   repo.Name,
+		"branch_count": len(branches),
+	}).Info("Successfully retrieved branches")
+
+	return branches, nil
+}
+
+// GetTags retrieves all tags for a repository
+func (c *GiteaClient) GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetTags(ctx, repo)
+		}
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/tags?limit=%d", c.baseURL, owner, repoName, c.config.pageSize())
+
+	tags := []types.Tag{}
+	for page := 1; url != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "get_tags",
+				"repository": repo.Name,
+				"max_pages":  c.config.maxPages(),
+				"tag_count":  len(tags),
+			}).Warn("Reached page limit, tag list is incomplete")
+			return tags, &IncompleteListingError{
+				Provider: "gitea",
+				Resource: "tags of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var giteaTags []GiteaTag
+		headers, err := c.doRequest(ctx, "GET", url, &giteaTags)
+		if err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "get_tags",
+				"repository": repo.Name,
+				"url":        url,
+			}).Error("API request failed")
+			if c.config.EnableFallback && IsRetryableError(err) {
+				return c.fallback.GetTags(ctx, repo)
+			}
+			return nil, err
+		}
+
+		for _, gt := range giteaTags {
+			tags = append(tags, types.Tag{Name: gt.Name, CommitSHA: gt.Commit.SHA})
+		}
+
+		url = parseNextLink(headers)
+	}
+
+	return tags, nil
+}
+
+// CompareCommits is not supported for Gitea repositories
+func (c *GiteaClient) CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error) {
+	return nil, fmt.Errorf("CompareCommits not implemented for Gitea")
+}
+
+// GetLatestCommit retrieves the latest commit SHA for a branch
+func (c *GiteaClient) GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetLatestCommit(ctx, repo, branch)
+		}
+		return "", err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/branches/%s", c.baseURL, owner, repoName, escapePathSegments(branch))
+
+	var giteaBranch GiteaBranch
+	if err := c.makeRequest(ctx, "GET", url, &giteaBranch); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetLatestCommit(ctx, repo, branch)
+		}
+		return "", err
+	}
+
+	return giteaBranch.Commit.ID, nil
+}
+
+// ListPullRequests retrieves open pull requests for a repository
+func (c *GiteaClient) ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls?state=open&limit=%d", c.baseURL, owner, repoName, c.config.pageSize())
+
+	pullRequests := []types.PullRequest{}
+	for page := 1; url != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":          "list_pull_requests",
+				"repository":         repo.Name,
+				"max_pages":          c.config.maxPages(),
+				"pull_request_count": len(pullRequests),
+			}).Warn("Reached page limit, pull request list is incomplete")
+			return pullRequests, &IncompleteListingError{
+				Provider: "gitea",
+				Resource: "pull requests of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var giteaPulls []GiteaPullRequest
+		headers, err := c.doRequest(ctx, "GET", url, &giteaPulls)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pr := range giteaPulls {
+			pullRequests = append(pullRequests, pr.toPullRequest())
+		}
+
+		url = parseNextLink(headers)
+	}
+
+	return pullRequests, nil
+}
+
+// GetPullRequest retrieves a single pull request
+func (c *GiteaClient) GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, owner, repoName, number)
+
+	var giteaPull GiteaPullRequest
+	if err := c.makeRequest(ctx, "GET", url, &giteaPull); err != nil {
+		return nil, err
+	}
+
+	pullRequest := giteaPull.toPullRequest()
+	return &pullRequest, nil
+}
+
+// GetDefaultBranch retrieves the name of the repository's default branch
+func (c *GiteaClient) GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetDefaultBranch(ctx, repo)
+		}
+		return "", err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, repoName)
+
+	var giteaRepo GiteaRepository
+	if err := c.makeRequest(ctx, "GET", url, &giteaRepo); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetDefaultBranch(ctx, repo)
+		}
+		return "", err
+	}
+
+	if giteaRepo.DefaultBranch == "" {
+		return "", fmt.Errorf("repository %s has no default branch", repo.Name)
+	}
+	return giteaRepo.DefaultBranch, nil
+}
+
+// CheckPermissions verifies if the client has access to the repository
+func (c *GiteaClient) CheckPermissions(ctx context.Context, repo types.Repository) error {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, repoName)
+	return c.makeRequest(ctx, "GET", url, nil)
+}
+
+// GetRateLimit returns current rate limit status
+func (c *GiteaClient) GetRateLimit(ctx context.Context) (*types.RateLimit, error) {
+	// Gitea doesn't expose rate limits through its API
+	// We return the current state from our rate limiter
+	limitInfo := c.rateLimiter.GetLimit()
+
+	return &types.RateLimit{
+		Limit:     limitInfo.Limit,
+		Remaining: limitInfo.Remaining,
+		Reset:     limitInfo.ResetTime,
+	}, nil
+}
+
+// GetProvider returns the provider name
+func (c *GiteaClient) GetProvider() string {
+	return "gitea"
+}
+
+// Close releases any resources
+func (c *GiteaClient) Close() error {
+	return nil
+}
+
+// ListFiles retrieves all files in a specific path for a commit
+func (c *GiteaClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	path = strings.Trim(path, "/")
+	var files []FileEntry
+	seen := 0
+
+	// The recursive tree endpoint is paginated by page/per_page and reports total_count
+	for page := 1; ; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "list_files",
+				"repository": repo.Name,
+				"max_pages":  c.config.maxPages(),
+			}).Warn("Reached page limit, file list is incomplete")
+			return nil, &IncompleteListingError{
+				Provider: "gitea",
+				Resource: "files of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		apiURL := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s?recursive=true&page=%d&per_page=%d",
+			c.baseURL, owner, repoName, url.PathEscape(commitSHA), page, c.config.pageSize())
+
+		var tree GiteaTree
+		if err := c.makeRequest(ctx, "GET", apiURL, &tree); err != nil {
+			if c.config.EnableFallback && IsRetryableError(err) {
+				c.logger.Info("Attempting fallback after API failure")
+				return c.fallback.ListFiles(ctx, repo, commitSHA, path)
+			}
+			return nil, fmt.Errorf("failed to get tree: %w", err)
+		}
+
+		for _, item := range tree.Tree {
+			if item.Type == "blob" && (path == "" || strings.HasPrefix(item.Path, path+"/")) {
+				files = append(files, FileEntry{Path: item.Path, SHA: item.SHA})
+			}
+		}
+
+		seen += len(tree.Tree)
+		if len(tree.Tree) == 0 || !tree.Truncated && seen >= tree.TotalCount {
+			break
+		}
+	}
+
+	return files, nil
+}
+
+// GetFileContent retrieves the content of a specific file
+func (c *GiteaClient) GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s",
+		c.baseURL, owner, repoName, escapePathSegments(strings.Trim(filePath, "/")), url.QueryEscape(commitSHA))
+
+	var content GiteaContent
+	if err := c.makeRequest(ctx, "GET", apiURL, &content); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.GetFileContent(ctx, repo, commitSHA, filePath)
+		}
+		return nil, fmt.Errorf("failed to get file content: %w", err)
+	}
+
+	if content.Encoding == "base64" {
+		decoded, err := base64DecodeContent(content.Content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode content: %w", err)
+		}
+		return decoded, nil
+	}
+
+	return []byte(content.Content), nil
+}
+
+// CheckDirectoryExists checks if a directory exists in the repository
+func (c *GiteaClient) CheckDirectoryExists(ctx context.Context, repo types.Repository, commitSHA, dirPath string) (bool, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return false, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return false, err
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s",
+		c.baseURL, owner, repoName, escapePathSegments(strings.Trim(dirPath, "/")), url.QueryEscape(commitSHA))
+
+	// Directories are returned as a JSON array, files as an object
+	var raw json.RawMessage
+	if err := c.makeRequest(ctx, "GET", apiURL, &raw); err != nil {
+		if _, ok := err.(*RepositoryNotFoundError); ok {
+			return false, nil
+		}
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.CheckDirectoryExists(ctx, repo, commitSHA, dirPath)
+		}
+		return false, fmt.Errorf("failed to check directory: %w", err)
+	}
+
+	return strings.HasPrefix(strings.TrimSpace(string(raw)), "["), nil
+}
+
+// makeRequest makes an HTTP request to the Gitea API
+func (c *GiteaClient) makeRequest(ctx context.Context, method, url string, result interface{}) error {
+	_, err := c.doRequest(ctx, method, url, result)
+	return err
+}
+
+// doRequest makes an HTTP request to the Gitea API and returns the response headers
+func (c *GiteaClient) doRequest(ctx context.Context, method, url string, result interface{}) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, &NetworkError{Provider: "gitea", Err: err}
+	}
+
+	// Gitea accepts "token <t>" for personal access tokens
+	req.Header.Set("Authorization", "token "+c.config.Token)
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	req.Header.Set("Accept", "application/json")
+
+	// Retry logic
+	var lastErr error
+	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(c.config.RetryBackoff * time.Duration(attempt)):
+			}
+		}
+
+		// Fail fast while the API is known to be down
+		ticket, err := c.breaker.Allow()
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.httpClient.Do(req)
+		c.breaker.RecordResponse(ticket, resp, err)
+		if err != nil {
+			lastErr = &NetworkError{Provider: "gitea", Err: err}
+			if attempt < c.config.RetryAttempts {
+				continue
+			}
+			return nil, lastErr
+		}
+
+		// Handle different status codes
+		switch resp.StatusCode {
+		case http.StatusOK:
+			if result != nil {
+				defer resp.Body.Close()
+				if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+					return nil, &NetworkError{Provider: "gitea", Err: err}
+				}
+			}
+			return resp.Header, nil
+		case http.StatusUnauthorized, http.StatusForbidden:
+			resp.Body.Close()
+			return nil, &AuthenticationError{Provider: "gitea", Message: "invalid or insufficient permissions"}
+		case http.StatusNotFound:
+			resp.Body.Close()
+			return nil, &RepositoryNotFoundError{Repository: url, Provider: "gitea"}
+		case http.StatusTooManyRequests:
+			resp.Body.Close()
+			return nil, &RateLimitExceededError{Provider: "gitea", ResetTime: time.Now().Add(time.Minute)}
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			resp.Body.Close()
+			lastErr = &NetworkError{Provider: "gitea", Err: fmt.Errorf("server error: %d", resp.StatusCode)}
+			if attempt < c.config.RetryAttempts {
+				continue
+			}
+			return nil, lastErr
+		default:
+			resp.Body.Close()
+			return nil, &NetworkError{Provider: "gitea", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+		}
+	}
+
+	return nil, lastErr
+}
+
+// parseRepoURL extracts owner and repository name from a Gitea URL. Gitea may be
+// served under a sub-path, so owner and repository are the last two segments.
+func (c *GiteaClient) parseRepoURL(repoURL string) (owner, repo string, err error) {
+	parsedURL, err := utils.ParseGitURL(repoURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+	if len(pathParts) < 2 || pathParts[0] == "" {
+		return "", "", fmt.Errorf("invalid Gitea repository URL format: %s", repoURL)
+	}
+
+	owner = pathParts[len(pathParts)-2]
+	repo = strings.TrimSuffix(pathParts[len(pathParts)-1], ".git")
+
+	return owner, repo, nil
+}
+
+// extractGiteaAPIURL extracts the Gitea API URL from a repository URL,
+// keeping any sub-path the instance is served under. SSH URLs carry no sub-path
+// and map to the HTTPS endpoint on the same host.
+func extractGiteaAPIURL(repoURL string) string {
+	parsedURL, err := utils.ParseGitURL(repoURL)
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+
+	subPath := ""
+	pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+	if len(pathParts) > 2 && parsedURL.Scheme != "ssh" {
+		subPath = "/" + strings.Join(pathParts[:len(pathParts)-2], "/")
+	}
+
+	return fmt.Sprintf("%s%s/api/v1", utils.WebBaseURL(parsedURL), subPath)
+}
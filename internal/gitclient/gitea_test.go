@@ -0,0 +1,157 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeGiteaServer serves a minimal subset of the Gitea API for owner/repo
+func newFakeGiteaServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token gitea-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		const repoAPI = "/git/api/v1/repos/owner/repo"
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case repoAPI:
+			fmt.Fprint(w, `{"full_name":"owner/repo","default_branch":"main"}`)
+		case repoAPI + "/branches":
+			if query.Get("page") == "" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s%s/branches?limit=1&page=2>; rel="next"`, server.URL, repoAPI))
+				fmt.Fprint(w, `[{"name":"main","commit":{"id":"aaa"},"protected":true}]`)
+				return
+			}
+			fmt.Fprint(w, `[{"name":"feature/x","commit":{"id":"bbb"}}]`)
+		case repoAPI + "/branches/feature/x":
+			fmt.Fprint(w, `{"name":"feature/x","commit":{"id":"bbb"}}`)
+		case repoAPI + "/git/trees/aaa":
+			assert.Equal(t, "true", query.Get("recursive"))
+			if query.Get("page") == "1" {
+				fmt.Fprint(w, `{"sha":"aaa","truncated":true,"page":1,"total_count":4,"tree":[
+					{"path":".tekton","type":"tree"},
+					{"path":".tekton/pipeline.yaml","type":"blob","sha":"pipeline-blob"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"sha":"aaa","truncated":false,"page":2,"total_count":4,"tree":[
+				{"path":".tekton-old/task.yaml","type":"blob"},
+				{"path":"README.md","type":"blob"}]}`)
+		case repoAPI + "/contents/.tekton":
+			fmt.Fprint(w, `[{"name":"pipeline.yaml","path":".tekton/pipeline.yaml","type":"file"}]`)
+		case repoAPI + "/contents/.tekton/pipeline.yaml":
+			assert.Equal(t, "aaa", query.Get("ref"))
+			fmt.Fprintf(w, `{"name":"pipeline.yaml","type":"file","encoding":"base64","content":"%s"}`,
+				base64.StdEncoding.EncodeToString([]byte("kind: Pipeline\n")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	return server
+}
+
+func TestGiteaClient(t *testing.T) {
+	server := newFakeGiteaServer(t)
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "gitea-token"
+	config.RepositoryURL = server.URL + "/git/owner/repo.git"
+	config.EnableFallback = false
+	config.RetryAttempts = 0
+	config.PerPage = 2
+
+	client, err := NewGiteaClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	assert.Equal(t, server.URL+"/git/api/v1", client.baseURL)
+	assert.Equal(t, "gitea", client.GetProvider())
+
+	repo := types.Repository{Name: "repo", URL: config.RepositoryURL, Provider: "gitea"}
+	ctx := context.Background()
+
+	branches, err := client.GetBranches(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Branch{
+		{Name: "main", CommitSHA: "aaa", Protected: true},
+		{Name: "feature/x", CommitSHA: "bbb"},
+	}, branches)
+
+	sha, err := client.GetLatestCommit(ctx, repo, "feature/x")
+	require.NoError(t, err)
+	assert.Equal(t, "bbb", sha)
+
+	defaultBranch, err := client.GetDefaultBranch(ctx, repo)
+	require.NoError(t, err)
+	assert.Equal(t, "main", defaultBranch)
+
+	assert.NoError(t, client.CheckPermissions(ctx, repo))
+
+	exists, err := client.CheckDirectoryExists(ctx, repo, "aaa", ".tekton")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, "aaa", ".tekton/pipeline.yaml")
+	require.NoError(t, err)
+	assert.False(t, exists, "a file is not a directory")
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, "aaa", "missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	files, err := client.ListFiles(ctx, repo, "aaa", ".tekton")
+	require.NoError(t, err)
+	assert.Equal(t, []FileEntry{{Path: ".tekton/pipeline.yaml", SHA: "pipeline-blob"}}, files)
+
+	content, err := client.GetFileContent(ctx, repo, "aaa", ".tekton/pipeline.yaml")
+	require.NoError(t, err)
+	assert.Equal(t, "kind: Pipeline\n", string(content))
+}
+
+func TestGiteaClient_AuthenticationError(t *testing.T) {
+	server := newFakeGiteaServer(t)
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "wrong"
+	config.BaseURL = server.URL + "/git/api/v1"
+	config.EnableFallback = false
+
+	client, err := NewGiteaClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	_, err = client.GetBranches(context.Background(), types.Repository{Name: "repo", URL: server.URL + "/git/owner/repo"})
+	assert.IsType(t, &AuthenticationError{}, err)
+}
+
+func TestExtractGiteaAPIURL(t *testing.T) {
+	assert.Equal(t, "https://gitea.company.com/api/v1", extractGiteaAPIURL("https://gitea.company.com/owner/repo"))
+	assert.Equal(t, "https://company.com/gitea/api/v1", extractGiteaAPIURL("https://company.com/gitea/owner/repo.git"))
+	assert.Equal(t, "https://gitea.company.com/api/v1", extractGiteaAPIURL("ssh://git@gitea.company.com:2222/owner/repo.git"))
+	assert.Equal(t, "", extractGiteaAPIURL("not a url"))
+}
+
+func TestClientFactory_CreateClient_Gitea(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	config := GetDefaultConfig()
+	config.Token = "token"
+
+	for _, provider := range []string{"gitea", "forgejo"} {
+		client, err := factory.CreateClient(types.Repository{
+			Name:     "repo",
+			URL:      "https://codeberg.org/owner/repo",
+			Provider: provider,
+		}, config)
+		require.NoError(t, err)
+		assert.Equal(t, "gitea", client.GetProvider())
+	}
+}
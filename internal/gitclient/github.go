@@ -1,18 +1,20 @@
 package gitclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
-	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // GitHubClient implements GitClient for GitHub API
@@ -22,6 +24,8 @@ type GitHubClient struct {
 	rateLimiter RateLimiter
 	fallback    *FallbackClient
 	baseURL     string
+	breaker     *CircuitBreaker // Shared by clients of the same API, nil when disabled
+	appAuth     *GitHubAppAuth  // Set when authenticating as a GitHub App
 	logger      *logger.Entry
 }
 
@@ -32,19 +36,140 @@ type GitHubBranch struct {
 	Protected bool         `json:"protected"`
 }
 
+// GitHubTag represents a tag response from GitHub API
+type GitHubTag struct {
+	Name   string       `json:"name"`
+	Commit GitHubCommit `json:"commit"`
+}
+
+// GitHubPullRequest represents a pull request response from GitHub API
+type GitHubPullRequest struct {
+	Number   int     `json:"number"`
+	Title    string  `json:"title"`
+	State    string  `json:"state"` // open or closed
+	Draft    bool    `json:"draft"`
+	HTMLURL  string  `json:"html_url"`
+	MergedAt *string `json:"merged_at"`
+	Head     struct {
+		SHA string `json:"sha"`
+		Ref string `json:"ref"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	User struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+// toPullRequest converts a GitHub pull request to our type
+func (pr GitHubPullRequest) toPullRequest() types.PullRequest {
+	state := types.PullRequestStateOpen
+	if pr.MergedAt != nil {
+		state = types.PullRequestStateMerged
+	} else if pr.State == "closed" {
+		state = types.PullRequestStateClosed
+	}
+
+	labels := make([]string, 0, len(pr.Labels))
+	for _, label := range pr.Labels {
+		labels = append(labels, label.Name)
+	}
+
+	return types.PullRequest{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        state,
+		HeadSHA:      pr.Head.SHA,
+		SourceBranch: pr.Head.Ref,
+		TargetBranch: pr.Base.Ref,
+		Author:       pr.User.Login,
+		Labels:       labels,
+		Draft:        pr.Draft,
+		URL:          pr.HTMLURL,
+	}
+}
+
 // GitHubCommit represents a commit in GitHub API response
 type GitHubCommit struct {
 	SHA string `json:"sha"`
 }
 
+// githubCompareMaxFiles is the most changed files GitHub lists in a compare response
+const githubCompareMaxFiles = 300
+
+// GitHubComparison represents a compare response from GitHub API
+type GitHubComparison struct {
+	TotalCommits int `json:"total_commits"`
+	Commits      []struct {
+		SHA     string `json:"sha"`
+		HTMLURL string `json:"html_url"`
+		Commit  struct {
+			Message string `json:"message"`
+			Author  struct {
+				Name  string    `json:"name"`
+				Email string    `json:"email"`
+				Date  time.Time `json:"date"`
+			} `json:"author"`
+		} `json:"commit"`
+	} `json:"commits"`
+	Files []struct {
+		Filename         string `json:"filename"`
+		Status           string `json:"status"` // added, removed, modified, renamed, copied, changed
+		PreviousFilename string `json:"previous_filename"`
+	} `json:"files"`
+}
+
+// toCommitComparison converts a GitHub comparison to our type
+func (c GitHubComparison) toCommitComparison() *types.CommitComparison {
+	comparison := &types.CommitComparison{
+		Commits:        make([]types.Commit, 0, len(c.Commits)),
+		TotalCommits:   c.TotalCommits,
+		FilesTruncated: len(c.Files) >= githubCompareMaxFiles,
+	}
+
+	for _, commit := range c.Commits {
+		comparison.Commits = append(comparison.Commits, types.Commit{
+			SHA:         commit.SHA,
+			Message:     commit.Commit.Message,
+			AuthorName:  commit.Commit.Author.Name,
+			AuthorEmail: commit.Commit.Author.Email,
+			Timestamp:   commit.Commit.Author.Date,
+			URL:         commit.HTMLURL,
+		})
+	}
+
+	for _, file := range c.Files {
+		switch file.Status {
+		case "renamed":
+			comparison.AddFile(file.PreviousFilename, "removed")
+			comparison.AddFile(file.Filename, "added")
+		case "copied":
+			comparison.AddFile(file.Filename, "added")
+		default:
+			comparison.AddFile(file.Filename, file.Status)
+		}
+	}
+
+	comparison.AssignFilesToSingleCommit()
+	return comparison
+}
+
 // GitHubRepository represents a repository in GitHub API
 type GitHubRepository struct {
-	ID       int    `json:"id"`
-	Name     string `json:"name"`
-	FullName string `json:"full_name"`
-	Private  bool   `json:"private"`
-	HTMLURL  string `json:"html_url"`
-	CloneURL string `json:"clone_url"`
+	ID            int      `json:"id"`
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	Private       bool     `json:"private"`
+	HTMLURL       string   `json:"html_url"`
+	CloneURL      string   `json:"clone_url"`
+	DefaultBranch string   `json:"default_branch"`
+	Archived      bool     `json:"archived"`
+	Fork          bool     `json:"fork"`
+	Topics        []string `json:"topics"`
 }
 
 // GitHubRateLimit represents GitHub's rate limit response
@@ -60,17 +185,20 @@ type GitHubRateLimit struct {
 
 // NewGitHubClient creates a new GitHub client
 func NewGitHubClient(config ClientConfig, rateLimiter RateLimiter, fallback *FallbackClient, parentLogger *logger.Entry) (*GitHubClient, error) {
-	if config.Token == "" {
-		return nil, fmt.Errorf("GitHub token is required")
-	}
+	return newGitHubClient(config, rateLimiter, fallback, nil, parentLogger)
+}
 
-	baseURL := config.BaseURL
-	if baseURL == "" {
-		baseURL = "https://api.github.com"
+// newGitHubClient creates a GitHub client, optionally reusing a shared GitHub App token source
+func newGitHubClient(config ClientConfig, rateLimiter RateLimiter, fallback *FallbackClient, appAuth *GitHubAppAuth, parentLogger *logger.Entry) (*GitHubClient, error) {
+	if config.Token == "" && config.GitHubApp == nil {
+		return nil, fmt.Errorf("GitHub token or GitHub App credentials are required")
 	}
 
-	httpClient := &http.Client{
-		Timeout: config.Timeout,
+	baseURL := githubAPIURL(config)
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
 	}
 
 	clientLogger := parentLogger.WithFields(logger.Fields{
@@ -79,6 +207,14 @@ func NewGitHubClient(config ClientConfig, rateLimiter RateLimiter, fallback *Fal
 		"base_url":  baseURL,
 	})
 
+	if config.GitHubApp != nil && appAuth == nil {
+		var err error
+		appAuth, err = NewGitHubAppAuth(*config.GitHubApp, baseURL, config, parentLogger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	clientLogger.Info("Initializing GitHub client")
 
 	return &GitHubClient{
@@ -87,10 +223,19 @@ func NewGitHubClient(config ClientConfig, rateLimiter RateLimiter, fallback *Fal
 		rateLimiter: rateLimiter,
 		fallback:    fallback,
 		baseURL:     baseURL,
+		appAuth:     appAuth,
 		logger:      clientLogger,
 	}, nil
 }
 
+// githubAPIURL returns the API base URL for a GitHub client configuration
+func githubAPIURL(config ClientConfig) string {
+	if config.BaseURL != "" {
+		return config.BaseURL
+	}
+	return "https://api.github.com"
+}
+
 // GetBranches retrieves all branches for a repository
 func (c *GitHubClient) GetBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error) {
 	c.logger.WithFields(logger.Fields{
@@ -108,7 +253,7 @@ func (c *GitHubClient) GetBranches(ctx context.Context, repo types.Repository) (
 		}).Error("Failed to parse repository URL")
 		if c.config.EnableFallback {
 			c.logger.Info("Attempting fallback for branch retrieval")
-			return c.fallback.GetBranches(ctx, repo)
+			return c.fallback.GetBranches(ctx, c.fallbackRepository(ctx, repo))
 		}
 		return nil, err
 	}
@@ -126,35 +271,65 @@ func (c *GitHubClient) GetBranches(ctx context.Context, repo types.Repository) (
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/repos/%s/%s/branches", c.baseURL, owner, repoName)
-	c.logger.WithFields(logger.Fields{
-		"operation":  "get_branches",
-		"repository": repo.Name,
-		"url":        url,
-	}).Debug("Making API request")
+	url := appendQuery(fmt.Sprintf("%s/repos/%s/%s/branches", c.baseURL, owner, repoName),
+		fmt.Sprintf("per_page=%d", c.config.pageSize()))
+
+	var branches []types.Branch
+	for page := 1; url != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":    "get_branches",
+				"repository":   repo.Name,
+				"max_pages":    c.config.maxPages(),
+				"branch_count": len(branches),
+			}).Warn("Reached page limit, branch list is incomplete")
+			return branches, &IncompleteListingError{
+				Provider: "github",
+				Resource: "branches of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
 
-	var githubBranches []GitHubBranch
-	if err := c.makeRequest(ctx, "GET", url, nil, &githubBranches); err != nil {
-		c.logger.WithError(err).WithFields(logger.Fields{
+		// Every page counts against the shared rate limit
+		if page > 1 {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		c.logger.WithFields(logger.Fields{
 			"operation":  "get_branches",
 			"repository": repo.Name,
 			"url":        url,
-		}).Error("API request failed")
-		if c.config.EnableFallback && IsRetryableError(err) {
-			c.logger.Info("Attempting fallback after API failure")
-			return c.fallback.GetBranches(ctx, repo)
+			"page":       page,
+		}).Debug("Making API request")
+
+		var githubBranches []GitHubBranch
+		headers, err := c.doRequest(ctx, "GET", url, nil, &githubBranches)
+		if err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "get_branches",
+				"repository": repo.Name,
+				"url":        url,
+				"page":       page,
+			}).Error("API request failed")
+			if c.config.EnableFallback && IsRetryableError(err) {
+				c.logger.Info("Attempting fallback after API failure")
+				return c.fallback.GetBranches(ctx, c.fallbackRepository(ctx, repo))
+			}
+			return nil, err
 		}
-		return nil, err
-	}
 
-	// Convert to our types
-	branches := make([]types.Branch, len(githubBranches))
-	for i, gb := range githubBranches {
-		branches[i] = types.Branch{
-			Name:      gb.Name,
-			CommitSHA: gb.Commit.SHA,
-			Protected: gb.Protected,
+		// Convert to our types
+		for _, gb := range githubBranches {
+			branches = append(branches, types.Branch{
+				Name:      gb.Name,
+				CommitSHA: gb.Commit.SHA,
+				Protected: gb.Protected,
+			})
 		}
+
+		url = parseNextLink(headers)
 	}
 
 	c.logger.WithFields(logger.Fields{
@@ -166,6 +341,171 @@ func (c *GitHubClient) GetBranches(ctx context.Context, repo types.Repository) (
 	return branches, nil
 }
 
+// GetTags retrieves all tags for a repository
+func (c *GitHubClient) GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error) {
+	c.logger.WithFields(logger.Fields{
+		"operation":  "get_tags",
+		"repository": repo.Name,
+	}).Info("Starting tag retrieval")
+
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			c.logger.Info("Attempting fallback for tag retrieval")
+			return c.fallback.GetTags(ctx, c.fallbackRepository(ctx, repo))
+		}
+		return nil, err
+	}
+
+	url := appendQuery(fmt.Sprintf("%s/repos/%s/%s/tags", c.baseURL, owner, repoName),
+		fmt.Sprintf("per_page=%d", c.config.pageSize()))
+
+	tags := []types.Tag{}
+	for page := 1; url != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "get_tags",
+				"repository": repo.Name,
+				"max_pages":  c.config.maxPages(),
+				"tag_count":  len(tags),
+			}).Warn("Reached page limit, tag list is incomplete")
+			return tags, &IncompleteListingError{
+				Provider: "github",
+				Resource: "tags of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var githubTags []GitHubTag
+		headers, err := c.doRequest(ctx, "GET", url, nil, &githubTags)
+		if err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "get_tags",
+				"repository": repo.Name,
+				"url":        url,
+				"page":       page,
+			}).Error("API request failed")
+			if c.config.EnableFallback && IsRetryableError(err) {
+				c.logger.Info("Attempting fallback after API failure")
+				return c.fallback.GetTags(ctx, c.fallbackRepository(ctx, repo))
+			}
+			return nil, err
+		}
+
+		for _, gt := range githubTags {
+			tags = append(tags, types.Tag{Name: gt.Name, CommitSHA: gt.Commit.SHA})
+		}
+
+		url = parseNextLink(headers)
+	}
+
+	c.logger.WithFields(logger.Fields{
+		"operation":  "get_tags",
+		"repository": repo.Name,
+		"tag_count":  len(tags),
+	}).Info("Successfully retrieved tags")
+
+	return tags, nil
+}
+
+// ListPullRequests retrieves open pull requests for a repository
+func (c *GitHubClient) ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	url := appendQuery(fmt.Sprintf("%s/repos/%s/%s/pulls", c.baseURL, owner, repoName),
+		fmt.Sprintf("state=open&per_page=%d", c.config.pageSize()))
+
+	pullRequests := []types.PullRequest{}
+	for page := 1; url != ""; page++ {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":          "list_pull_requests",
+				"repository":         repo.Name,
+				"max_pages":          c.config.maxPages(),
+				"pull_request_count": len(pullRequests),
+			}).Warn("Reached page limit, pull request list is incomplete")
+			return pullRequests, &IncompleteListingError{
+				Provider: "github",
+				Resource: "pull requests of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var githubPulls []GitHubPullRequest
+		headers, err := c.doRequest(ctx, "GET", url, nil, &githubPulls)
+		if err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "list_pull_requests",
+				"repository": repo.Name,
+				"url":        url,
+			}).Error("API request failed")
+			return nil, err
+		}
+
+		for _, pr := range githubPulls {
+			pullRequests = append(pullRequests, pr.toPullRequest())
+		}
+
+		url = parseNextLink(headers)
+	}
+
+	return pullRequests, nil
+}
+
+// GetPullRequest retrieves a single pull request
+func (c *GitHubClient) GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/pulls/%d", c.baseURL, owner, repoName, number)
+
+	var githubPull GitHubPullRequest
+	if err := c.makeRequest(ctx, "GET", url, nil, &githubPull); err != nil {
+		return nil, err
+	}
+
+	pullRequest := githubPull.toPullRequest()
+	return &pullRequest, nil
+}
+
+// CompareCommits retrieves the commits between two revisions
+func (c *GitHubClient) CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s/compare/%s...%s", c.baseURL, owner, repoName, base, head)
+
+	var githubComparison GitHubComparison
+	if err := c.makeRequest(ctx, "GET", url, nil, &githubComparison); err != nil {
+		return nil, err
+	}
+
+	return githubComparison.toCommitComparison(), nil
+}
+
 // GetLatestCommit retrieves the latest commit SHA for a branch
 func (c *GitHubClient) GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error) {
 	c.logger.WithFields(logger.Fields{
@@ -183,7 +523,7 @@ func (c *GitHubClient) GetLatestCommit(ctx context.Context, repo types.Repositor
 		}).Error("Failed to parse repository URL")
 		if c.config.EnableFallback {
 			c.logger.Info("Attempting fallback for latest commit")
-			return c.fallback.GetLatestCommit(ctx, repo, branch)
+			return c.fallback.GetLatestCommit(ctx, c.fallbackRepository(ctx, repo), branch)
 		}
 		return "", err
 	}
@@ -205,7 +545,7 @@ func (c *GitHubClient) GetLatestCommit(ctx context.Context, repo types.Repositor
 		}).Error("API request failed")
 		if c.config.EnableFallback && IsRetryableError(err) {
 			c.logger.Info("Attempting fallback after API failure")
-			return c.fallback.GetLatestCommit(ctx, repo, branch)
+			return c.fallback.GetLatestCommit(ctx, c.fallbackRepository(ctx, repo), branch)
 		}
 		return "", err
 	}
@@ -220,6 +560,37 @@ func (c *GitHubClient) GetLatestCommit(ctx context.Context, repo types.Repositor
 	return githubBranch.Commit.SHA, nil
 }
 
+// GetDefaultBranch retrieves the name of the repository's default branch
+func (c *GitHubClient) GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error) {
+	owner, repoName, err := c.parseRepoURL(repo.URL)
+	if err != nil {
+		if c.config.EnableFallback {
+			return c.fallback.GetDefaultBranch(ctx, c.fallbackRepository(ctx, repo))
+		}
+		return "", err
+	}
+
+	// Wait for rate limiter
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, owner, repoName)
+
+	var githubRepo GitHubRepository
+	if err := c.makeRequest(ctx, "GET", url, nil, &githubRepo); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			return c.fallback.GetDefaultBranch(ctx, c.fallbackRepository(ctx, repo))
+		}
+		return "", err
+	}
+
+	if githubRepo.DefaultBranch == "" {
+		return "", fmt.Errorf("repository %s has no default branch", repo.Name)
+	}
+	return githubRepo.DefaultBranch, nil
+}
+
 // CheckPermissions verifies if the client has access to the repository
 func (c *GitHubClient) CheckPermissions(ctx context.Context, repo types.Repository) error {
 	c.logger.WithFields(logger.Fields{
@@ -261,6 +632,9 @@ func (c *GitHubClient) GetRateLimit(ctx context.Context) (*types.RateLimit, erro
 	}
 
 	resetTime := time.Unix(int64(rateLimitResp.Resources.Core.Reset), 0)
+	if c.rateLimiter != nil {
+		c.rateLimiter.UpdateLimit(rateLimitResp.Resources.Core.Limit, rateLimitResp.Resources.Core.Remaining, resetTime)
+	}
 
 	return &types.RateLimit{
 		Limit:     rateLimitResp.Resources.Core.Limit,
@@ -281,15 +655,40 @@ func (c *GitHubClient) Close() error {
 
 // makeRequest makes an HTTP request to the GitHub API
 func (c *GitHubClient) makeRequest(ctx context.Context, method, url string, body interface{}, result interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	_, err := c.doRequest(ctx, method, url, body, result)
+	return err
+}
+
+// doRequest makes an HTTP request to the GitHub API and returns the response headers
+func (c *GitHubClient) doRequest(ctx context.Context, method, url string, body interface{}, result interface{}) (http.Header, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return &NetworkError{Provider: "github", Err: err}
+		return nil, &NetworkError{Provider: "github", Err: err}
 	}
 
 	// Set headers
-	req.Header.Set("Authorization", "token "+c.config.Token)
+	authorization, err := c.authorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", authorization)
 	req.Header.Set("User-Agent", c.config.UserAgent)
 	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Conditional request validators from a previous response
+	cached := c.config.HTTPCache.applyValidators(ctx, req)
 
 	// Retry logic
 	var lastErr error
@@ -297,18 +696,31 @@ func (c *GitHubClient) makeRequest(ctx context.Context, method, url string, body
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return nil, ctx.Err()
 			case <-time.After(c.config.RetryBackoff * time.Duration(attempt)):
 			}
+			// Rewind the request body consumed by the previous attempt
+			if req.GetBody != nil {
+				if req.Body, err = req.GetBody(); err != nil {
+					return nil, &NetworkError{Provider: "github", Err: err}
+				}
+			}
+		}
+
+		// Fail fast while the API is known to be down
+		ticket, err := c.breaker.Allow()
+		if err != nil {
+			return nil, err
 		}
 
 		resp, err := c.httpClient.Do(req)
+		c.breaker.RecordResponse(ticket, resp, err)
 		if err != nil {
 			lastErr = &NetworkError{Provider: "github", Err: err}
 			if attempt < c.config.RetryAttempts {
 				continue
 			}
-			return lastErr
+			return nil, lastErr
 		}
 
 		// Update rate limiter from headers
@@ -317,37 +729,107 @@ func (c *GitHubClient) makeRequest(ctx context.Context, method, url string, body
 		// Handle different status codes
 		switch resp.StatusCode {
 		case http.StatusOK:
-			if result != nil {
-				defer resp.Body.Close()
-				if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-					return &NetworkError{Provider: "github", Err: err}
-				}
+			defer resp.Body.Close()
+			if err := c.config.HTTPCache.decodeAndStore(ctx, req, resp, result); err != nil {
+				return nil, &NetworkError{Provider: "github", Err: err}
 			}
-			return nil
+			return resp.Header, nil
+		case http.StatusNotModified:
+			resp.Body.Close()
+			if cached == nil {
+				return nil, &NetworkError{Provider: "github", Err: fmt.Errorf("not modified response without a cached entry")}
+			}
+			headers, err := c.config.HTTPCache.notModified(cached, result)
+			if err != nil {
+				return nil, &NetworkError{Provider: "github", Err: err}
+			}
+			return headers, nil
 		case http.StatusUnauthorized, http.StatusForbidden:
 			resp.Body.Close()
-			return &AuthenticationError{Provider: "github", Message: "invalid or insufficient permissions"}
+			if resp.StatusCode == http.StatusForbidden {
+				if resetTime, limited := c.rateLimitedUntil(resp.Header); limited {
+					return nil, &RateLimitExceededError{Provider: "github", ResetTime: resetTime}
+				}
+			}
+			if resp.StatusCode == http.StatusUnauthorized && c.appAuth != nil {
+				// Revoked or expired installation token, fetch a fresh one next time
+				c.appAuth.Invalidate(c.installationID(), c.repositoryOwner())
+			}
+			return nil, &AuthenticationError{Provider: "github", Message: "invalid or insufficient permissions"}
 		case http.StatusNotFound:
 			resp.Body.Close()
-			return &RepositoryNotFoundError{Repository: url, Provider: "github"}
+			return nil, &RepositoryNotFoundError{Repository: url, Provider: "github"}
 		case http.StatusTooManyRequests:
 			resp.Body.Close()
 			resetTime := c.parseResetTime(resp.Header.Get("X-RateLimit-Reset"))
-			return &RateLimitExceededError{Provider: "github", ResetTime: resetTime}
+			return nil, &RateLimitExceededError{Provider: "github", ResetTime: resetTime}
 		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
 			resp.Body.Close()
 			lastErr = &NetworkError{Provider: "github", Err: fmt.Errorf("server error: %d", resp.StatusCode)}
 			if attempt < c.config.RetryAttempts {
 				continue
 			}
-			return lastErr
+			return nil, lastErr
 		default:
 			resp.Body.Close()
-			return &NetworkError{Provider: "github", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+			return nil, &NetworkError{Provider: "github", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
 		}
 	}
 
-	return lastErr
+	return nil, lastErr
+}
+
+// authorization returns the Authorization header value, using a GitHub App
+// installation token when the client is configured for app authentication
+func (c *GitHubClient) authorization(ctx context.Context) (string, error) {
+	if c.appAuth == nil {
+		return "token " + c.config.Token, nil
+	}
+
+	token, err := c.appAuth.Token(ctx, c.installationID(), c.repositoryOwner())
+	if err != nil {
+		return "", err
+	}
+	return "token " + token, nil
+}
+
+// fallbackRepository returns the repository for the git fallback. With GitHub
+// App authentication the repository has no token of its own, so git is given
+// the installation token instead.
+func (c *GitHubClient) fallbackRepository(ctx context.Context, repo types.Repository) types.Repository {
+	if c.appAuth == nil {
+		return repo
+	}
+
+	token, err := c.appAuth.Token(ctx, c.installationID(), c.repositoryOwner())
+	if err != nil {
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "fallback",
+			"repository": repo.Name,
+		}).Warn("Failed to get GitHub App token for git fallback")
+		return repo
+	}
+	repo.Token = token
+	return repo
+}
+
+// installationID returns the configured GitHub App installation ID, or 0 to discover it
+func (c *GitHubClient) installationID() int64 {
+	if c.config.GitHubApp == nil {
+		return 0
+	}
+	return c.config.GitHubApp.InstallationID
+}
+
+// repositoryOwner returns the owner of the repository this client was created
+// for. Discovery clients are created for an organization or user URL, which has
+// no repository part.
+func (c *GitHubClient) repositoryOwner() string {
+	parsedURL, err := utils.ParseGitURL(c.config.RepositoryURL)
+	if err != nil {
+		return ""
+	}
+	return strings.Split(strings.Trim(parsedURL.Path, "/"), "/")[0]
 }
 
 // parseRepoURL extracts owner and repository name from GitHub URL
@@ -355,12 +837,13 @@ func (c *GitHubClient) parseRepoURL(repoURL string) (owner, repo string, err err
 	// Clean and normalize the URL
 	repoURL = strings.TrimSpace(repoURL)
 
-	parsedURL, err := url.Parse(repoURL)
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		return "", "", fmt.Errorf("invalid repository URL: %w", err)
 	}
 
-	// Handle GitHub URLs: https://github.com/owner/repo or https://github.com/owner/repo.git
+	// Handle GitHub URLs: https://github.com/owner/repo, https://github.com/owner/repo.git
+	// or git@github.com:owner/repo.git
 	pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
 	if len(pathParts) < 2 {
 		return "", "", fmt.Errorf("invalid GitHub repository URL format: %s", repoURL)
@@ -379,6 +862,11 @@ func (c *GitHubClient) parseRepoURL(repoURL string) (owner, repo string, err err
 
 // updateRateLimitFromHeaders updates the rate limiter based on response headers
 func (c *GitHubClient) updateRateLimitFromHeaders(headers http.Header) {
+	// GraphQL and search have their own budgets; only the core REST limit drives the limiter
+	if resource := headers.Get("X-RateLimit-Resource"); resource != "" && resource != "core" {
+		return
+	}
+
 	limitStr := headers.Get("X-RateLimit-Limit")
 	remainingStr := headers.Get("X-RateLimit-Remaining")
 	resetStr := headers.Get("X-RateLimit-Reset")
@@ -403,6 +891,20 @@ func (c *GitHubClient) parseResetTime(resetStr string) time.Time {
 	return time.Now().Add(time.Hour) // Default to 1 hour if parsing fails
 }
 
+// rateLimitedUntil reports whether a 403 response is GitHub refusing a request
+// over a rate limit rather than over credentials, and when the limit resets.
+// The primary limit answers with X-RateLimit-Remaining: 0, secondary limits
+// with Retry-After.
+func (c *GitHubClient) rateLimitedUntil(headers http.Header) (time.Time, bool) {
+	if headers.Get("X-RateLimit-Remaining") == "0" {
+		return c.parseResetTime(headers.Get("X-RateLimit-Reset")), true
+	}
+	if seconds, err := strconv.Atoi(headers.Get("Retry-After")); err == nil {
+		return time.Now().Add(time.Duration(seconds) * time.Second), true
+	}
+	return time.Time{}, false
+}
+
 // GitHubTreeItem represents a single item in GitHub's git tree API response
 type GitHubTreeItem struct {
 	Path string `json:"path"`
@@ -435,33 +937,175 @@ type GitHubContent struct {
 	Encoding    string `json:"encoding"`
 }
 
-// ListFiles retrieves all files in a specific path for a commit
-func (c *GitHubClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]string, error) {
+// ListFiles retrieves all files below a directory at a commit. Only the
+// directory's own tree is listed, and when GitHub truncates the recursive
+// listing of a large tree it is walked one level at a time instead.
+func (c *GitHubClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error) {
 	owner, repoName, err := c.parseRepoURL(repo.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid repository URL: %w", err)
 	}
 
-	// Use Git Tree API to get files recursively
-	url := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s?recursive=1", 
-		c.baseURL, owner, repoName, commitSHA)
-
-	var tree GitHubTree
-	if err := c.makeRequest(ctx, "GET", url, nil, &tree); err != nil {
+	path = strings.Trim(path, "/")
+	files, err := c.listTreeFiles(ctx, repo, owner, repoName, commitSHA, path)
+	if err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.ListFiles(ctx, c.fallbackRepository(ctx, repo), commitSHA, path)
+		}
 		return nil, fmt.Errorf("failed to get tree: %w", err)
 	}
 
-	var files []string
-	for _, item := range tree.Tree {
-		// Filter files in the specified path
-		if item.Type == "blob" && (path == "" || strings.HasPrefix(item.Path, path)) {
-			files = append(files, item.Path)
+	return files, nil
+}
+
+// listTreeFiles lists the blobs below path, which is empty for the whole repository
+func (c *GitHubClient) listTreeFiles(ctx context.Context, repo types.Repository, owner, repoName, commitSHA, path string) ([]FileEntry, error) {
+	treeSHA := commitSHA
+	if path != "" {
+		sha, found, err := c.resolveSubtree(ctx, owner, repoName, commitSHA, path)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, nil
+		}
+		treeSHA = sha
+	}
+
+	tree, err := c.getTree(ctx, owner, repoName, treeSHA, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if !tree.Truncated {
+		var files []FileEntry
+		for _, item := range tree.Tree {
+			if item.Type == "blob" {
+				files = append(files, FileEntry{Path: joinTreePath(path, item.Path), SHA: item.SHA})
+			}
+		}
+		return files, nil
+	}
+
+	c.logger.WithFields(logger.Fields{
+		"operation":  "list_files",
+		"repository": repo.Name,
+		"path":       path,
+		"item_count": len(tree.Tree),
+	}).Warn("Recursive tree listing was truncated, walking the tree instead")
+
+	return c.walkTree(ctx, repo, owner, repoName, treeSHA, path)
+}
+
+// resolveSubtree finds the tree SHA of a directory by descending from the
+// commit's root tree one path segment at a time
+func (c *GitHubClient) resolveSubtree(ctx context.Context, owner, repoName, commitSHA, path string) (string, bool, error) {
+	sha := commitSHA
+	for _, segment := range strings.Split(path, "/") {
+		tree, err := c.getTree(ctx, owner, repoName, sha, false)
+		if err != nil {
+			return "", false, err
+		}
+
+		found := false
+		for _, item := range tree.Tree {
+			if item.Type == "tree" && item.Path == segment {
+				sha = item.SHA
+				found = true
+				break
+			}
+		}
+		if !found {
+			return "", false, nil
+		}
+	}
+	return sha, true, nil
+}
+
+// walkTree lists the blobs below a tree with one non-recursive request per
+// directory, for trees too large for a recursive listing. It fails rather than
+// return a partial list when it runs into the page cap or a directory GitHub
+// cannot list in full.
+func (c *GitHubClient) walkTree(ctx context.Context, repo types.Repository, owner, repoName, treeSHA, path string) ([]FileEntry, error) {
+	type pendingTree struct {
+		sha  string
+		path string
+	}
+
+	var files []FileEntry
+	queue := []pendingTree{{sha: treeSHA, path: path}}
+	for requests := 0; len(queue) > 0; requests++ {
+		if requests >= c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "list_files",
+				"repository": repo.Name,
+				"path":       path,
+				"max_pages":  c.config.maxPages(),
+			}).Warn("Reached page limit, file list is incomplete")
+			return nil, &IncompleteListingError{
+				Provider: "github",
+				Resource: "files of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		next := queue[0]
+		queue = queue[1:]
+
+		tree, err := c.getTree(ctx, owner, repoName, next.sha, false)
+		if err != nil {
+			return nil, err
+		}
+		if tree.Truncated {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "list_files",
+				"repository": repo.Name,
+				"path":       next.path,
+			}).Warn("Directory listing was truncated, file list is incomplete")
+			return nil, fmt.Errorf("github truncated the listing of %q in %s", next.path, repo.Name)
+		}
+
+		for _, item := range tree.Tree {
+			itemPath := joinTreePath(next.path, item.Path)
+			switch item.Type {
+			case "blob":
+				files = append(files, FileEntry{Path: itemPath, SHA: item.SHA})
+			case "tree":
+				queue = append(queue, pendingTree{sha: item.SHA, path: itemPath})
+			}
 		}
 	}
 
 	return files, nil
 }
 
+// getTree fetches a git tree by SHA. A commit SHA resolves to its root tree.
+func (c *GitHubClient) getTree(ctx context.Context, owner, repoName, sha string, recursive bool) (*GitHubTree, error) {
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s/repos/%s/%s/git/trees/%s", c.baseURL, owner, repoName, sha)
+	if recursive {
+		apiURL += "?recursive=1"
+	}
+
+	var tree GitHubTree
+	if err := c.makeRequest(ctx, "GET", apiURL, nil, &tree); err != nil {
+		return nil, err
+	}
+	return &tree, nil
+}
+
+// joinTreePath prefixes a path from a tree listing with the tree's own path
+func joinTreePath(dir, name string) string {
+	if dir == "" {
+		return name
+	}
+	return dir + "/" + name
+}
+
 // GetFileContent retrieves the content of a specific file
 func (c *GitHubClient) GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error) {
 	owner, repoName, err := c.parseRepoURL(repo.URL)
@@ -475,6 +1119,10 @@ func (c *GitHubClient) GetFileContent(ctx context.Context, repo types.Repository
 
 	var content GitHubContent
 	if err := c.makeRequest(ctx, "GET", url, nil, &content); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.GetFileContent(ctx, c.fallbackRepository(ctx, repo), commitSHA, filePath)
+		}
 		return nil, fmt.Errorf("failed to get file content: %w", err)
 	}
 
@@ -501,18 +1149,21 @@ func (c *GitHubClient) CheckDirectoryExists(ctx context.Context, repo types.Repo
 	url := fmt.Sprintf("%s/repos/%s/%s/contents/%s?ref=%s", 
 		c.baseURL, owner, repoName, dirPath, commitSHA)
 
-	var contents []GitHubContent
-	if err := c.makeRequest(ctx, "GET", url, nil, &contents); err != nil {
+	// Directories are returned as a JSON array, files as an object
+	var raw json.RawMessage
+	if err := c.makeRequest(ctx, "GET", url, nil, &raw); err != nil {
 		// If 404, directory doesn't exist
-		if networkErr, ok := err.(*NetworkError); ok {
-			if strings.Contains(networkErr.Err.Error(), "404") {
-				return false, nil
-			}
+		if _, ok := err.(*RepositoryNotFoundError); ok {
+			return false, nil
+		}
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.CheckDirectoryExists(ctx, c.fallbackRepository(ctx, repo), commitSHA, dirPath)
 		}
 		return false, fmt.Errorf("failed to check directory: %w", err)
 	}
 
-	return len(contents) > 0, nil
+	return strings.HasPrefix(strings.TrimSpace(string(raw)), "["), nil
 }
 
 // base64DecodeContent decodes base64 encoded content from GitHub API
@@ -0,0 +1,321 @@
+package gitclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+const (
+	// githubAppJWTLifetime stays below GitHub's 10 minute maximum
+	githubAppJWTLifetime = 9 * time.Minute
+
+	// githubAppClockSkew backdates the JWT issue time to tolerate clock drift
+	githubAppClockSkew = 60 * time.Second
+
+	// githubAppTokenRefreshMargin refreshes installation tokens this long before they expire
+	githubAppTokenRefreshMargin = 5 * time.Minute
+)
+
+// GitHubAppAuth issues installation access tokens for a GitHub App.
+// Tokens are cached per installation and refreshed shortly before they expire.
+// Requests to GitHub are made without holding the lock, and concurrent
+// callers needing the same lookup or refresh share one request.
+type GitHubAppAuth struct {
+	appID      int64
+	privateKey *rsa.PrivateKey
+	baseURL    string
+	userAgent  string
+	httpClient *http.Client
+	logger     *logger.Entry
+	requests   singleflight.Group // Keyed by installation ID or owner
+
+	mu            sync.Mutex
+	tokens        map[int64]*installationToken
+	installations map[string]int64 // owner -> installation ID
+}
+
+// installationToken is a cached GitHub App installation access token
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// GitHubInstallation represents an installation lookup response from GitHub API
+type GitHubInstallation struct {
+	ID int64 `json:"id"`
+}
+
+// GitHubInstallationToken represents an installation access token response from GitHub API
+type GitHubInstallationToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewGitHubAppAuth creates a GitHub App token source for the given API base URL
+func NewGitHubAppAuth(app types.GitHubAppConfig, baseURL string, config ClientConfig, parentLogger *logger.Entry) (*GitHubAppAuth, error) {
+	if app.AppID <= 0 {
+		return nil, fmt.Errorf("GitHub App ID is required")
+	}
+	if app.PrivateKeyPath == "" {
+		return nil, fmt.Errorf("GitHub App private key path is required")
+	}
+
+	privateKey, err := loadGitHubAppPrivateKey(app.PrivateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GitHubAppAuth{
+		appID:      app.AppID,
+		privateKey: privateKey,
+		baseURL:    baseURL,
+		userAgent:  config.UserAgent,
+		httpClient: httpClient,
+		logger: parentLogger.WithFields(logger.Fields{
+			"component": "gitclient",
+			"provider":  "github",
+			"app_id":    app.AppID,
+		}),
+		tokens:        make(map[int64]*installationToken),
+		installations: make(map[string]int64),
+	}, nil
+}
+
+// Token returns a valid installation token. When installationID is 0 the
+// installation is discovered from the repository owner and remembered.
+func (a *GitHubAppAuth) Token(ctx context.Context, installationID int64, owner string) (string, error) {
+	if installationID == 0 {
+		id, err := a.installationForOwner(ctx, owner)
+		if err != nil {
+			return "", err
+		}
+		installationID = id
+	}
+
+	if token, ok := a.cachedToken(installationID); ok {
+		return token, nil
+	}
+
+	token, err, _ := a.requests.Do("installation/"+strconv.FormatInt(installationID, 10), func() (interface{}, error) {
+		// A refresh that finished while waiting for the flight is as good
+		if token, ok := a.cachedToken(installationID); ok {
+			return token, nil
+		}
+		return a.refreshToken(ctx, installationID)
+	})
+	if err != nil {
+		return "", err
+	}
+	return token.(string), nil
+}
+
+// cachedToken returns the cached token of an installation unless it is about to expire
+func (a *GitHubAppAuth) cachedToken(installationID int64) (string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cached, exists := a.tokens[installationID]
+	if !exists || time.Until(cached.expiresAt) <= githubAppTokenRefreshMargin {
+		return "", false
+	}
+	return cached.token, true
+}
+
+// refreshToken requests a new installation token and caches it
+func (a *GitHubAppAuth) refreshToken(ctx context.Context, installationID int64) (string, error) {
+	a.logger.WithFields(logger.Fields{
+		"operation":       "refresh_installation_token",
+		"installation_id": installationID,
+	}).Debug("Requesting installation access token")
+
+	var result GitHubInstallationToken
+	url := fmt.Sprintf("%s/app/installations/%d/access_tokens", a.baseURL, installationID)
+	if err := a.appRequest(ctx, "POST", url, &result); err != nil {
+		return "", err
+	}
+	if result.Token == "" {
+		return "", &AuthenticationError{Provider: "github", Message: "GitHub App installation token response was empty"}
+	}
+
+	a.mu.Lock()
+	a.tokens[installationID] = &installationToken{token: result.Token, expiresAt: result.ExpiresAt}
+	a.mu.Unlock()
+
+	a.logger.WithFields(logger.Fields{
+		"operation":       "refresh_installation_token",
+		"installation_id": installationID,
+		"expires_at":      result.ExpiresAt,
+	}).Info("Obtained installation access token")
+
+	return result.Token, nil
+}
+
+// Invalidate drops cached tokens for an installation so the next call fetches a new one
+func (a *GitHubAppAuth) Invalidate(installationID int64, owner string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if installationID == 0 {
+		installationID = a.installations[owner]
+	}
+	delete(a.tokens, installationID)
+}
+
+// installationForOwner looks up the installation of the app on an organization
+// or user account, remembering it for later calls
+func (a *GitHubAppAuth) installationForOwner(ctx context.Context, owner string) (int64, error) {
+	if owner == "" {
+		return 0, &AuthenticationError{Provider: "github", Message: "GitHub App installation ID is not set and repository owner is unknown"}
+	}
+
+	a.mu.Lock()
+	id, exists := a.installations[owner]
+	a.mu.Unlock()
+	if exists {
+		return id, nil
+	}
+
+	result, err, _ := a.requests.Do("owner/"+owner, func() (interface{}, error) {
+		return a.lookupInstallation(ctx, owner)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(int64), nil
+}
+
+// lookupInstallation asks GitHub for the installation of the app on an owner's account
+func (a *GitHubAppAuth) lookupInstallation(ctx context.Context, owner string) (int64, error) {
+
+	var installation GitHubInstallation
+	err := a.appRequest(ctx, "GET", fmt.Sprintf("%s/orgs/%s/installation", a.baseURL, owner), &installation)
+	if _, notFound := err.(*RepositoryNotFoundError); notFound {
+		// Not an organization, try a user account
+		err = a.appRequest(ctx, "GET", fmt.Sprintf("%s/users/%s/installation", a.baseURL, owner), &installation)
+	}
+	if err != nil {
+		if _, notFound := err.(*RepositoryNotFoundError); notFound {
+			return 0, &AuthenticationError{Provider: "github", Message: fmt.Sprintf("GitHub App %d is not installed for %s", a.appID, owner)}
+		}
+		return 0, err
+	}
+
+	a.logger.WithFields(logger.Fields{
+		"operation":       "discover_installation",
+		"owner":           owner,
+		"installation_id": installation.ID,
+	}).Info("Discovered GitHub App installation")
+
+	a.mu.Lock()
+	a.installations[owner] = installation.ID
+	a.mu.Unlock()
+	return installation.ID, nil
+}
+
+// appRequest makes a request authenticated as the app itself using a signed JWT
+func (a *GitHubAppAuth) appRequest(ctx context.Context, method, url string, result interface{}) error {
+	jwt, err := a.signJWT(time.Now())
+	if err != nil {
+		return &AuthenticationError{Provider: "github", Message: err.Error()}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return &NetworkError{Provider: "github", Err: err}
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("User-Agent", a.userAgent)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return &NetworkError{Provider: "github", Err: err}
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return &NetworkError{Provider: "github", Err: err}
+		}
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return &AuthenticationError{Provider: "github", Message: "GitHub App credentials were rejected"}
+	case http.StatusNotFound:
+		return &RepositoryNotFoundError{Repository: url, Provider: "github"}
+	default:
+		return &NetworkError{Provider: "github", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+	}
+}
+
+// signJWT creates an RS256 JSON Web Token identifying the app
+func (a *GitHubAppAuth) signJWT(now time.Time) (string, error) {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-githubAppClockSkew).Unix(),
+		"exp": now.Add(githubAppJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(a.appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// loadGitHubAppPrivateKey reads a PKCS#1 or PKCS#8 RSA private key in PEM format
+func loadGitHubAppPrivateKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read GitHub App private key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("GitHub App private key %s is not PEM encoded", path)
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GitHub App private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("GitHub App private key must be an RSA key")
+	}
+	return key, nil
+}
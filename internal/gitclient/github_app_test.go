@@ -0,0 +1,248 @@
+package gitclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGitHubAppKey writes a fresh PKCS#1 RSA key to a temp file and returns its path
+func writeGitHubAppKey(t *testing.T) (string, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "app.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	require.NoError(t, os.WriteFile(path, pemBytes, 0600))
+	return path, key
+}
+
+// verifyGitHubAppJWT checks the RS256 signature and issuer of an app JWT
+func verifyGitHubAppJWT(t *testing.T, authorization string, key *rsa.PrivateKey) bool {
+	token := strings.TrimPrefix(authorization, "Bearer ")
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return false
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature) != nil {
+		return false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		Issuer   string `json:"iss"`
+		IssuedAt int64  `json:"iat"`
+		Expires  int64  `json:"exp"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, "42", claims.Issuer)
+	assert.LessOrEqual(t, claims.Expires-claims.IssuedAt, int64(10*time.Minute/time.Second))
+	return true
+}
+
+// newFakeGitHubAppServer serves installation lookup, token exchange and a branch listing
+func newFakeGitHubAppServer(t *testing.T, key *rsa.PrivateKey, tokenTTL time.Duration, exchanges *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/orgs/octo-user/installation":
+			http.NotFound(w, r)
+		case r.URL.Path == "/users/octo-user/installation":
+			if !verifyGitHubAppJWT(t, r.Header.Get("Authorization"), key) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"id":7}`)
+		case r.URL.Path == "/app/installations/7/access_tokens" && r.Method == "POST":
+			if !verifyGitHubAppJWT(t, r.Header.Get("Authorization"), key) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			n := atomic.AddInt32(exchanges, 1)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":"%s"}`, n, time.Now().Add(tokenTTL).UTC().Format(time.RFC3339))
+		case r.URL.Path == "/repos/octo-user/repo/branches":
+			if !strings.HasPrefix(r.Header.Get("Authorization"), "token ghs_") {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `[{"name":"main","commit":{"sha":"aaa"}}]`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
+
+func TestGitHubClient_AppAuthentication(t *testing.T) {
+	keyPath, key := writeGitHubAppKey(t)
+	var exchanges int32
+	server := newFakeGitHubAppServer(t, key, time.Hour, &exchanges)
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.BaseURL = server.URL
+	config.RepositoryURL = "https://github.com/octo-user/repo"
+	config.EnableFallback = false
+	config.RetryAttempts = 0
+	config.GitHubApp = &types.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath}
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: config.RepositoryURL, Provider: "github"}
+	for i := 0; i < 2; i++ {
+		branches, err := client.GetBranches(context.Background(), repo)
+		require.NoError(t, err)
+		assert.Equal(t, []types.Branch{{Name: "main", CommitSHA: "aaa"}}, branches)
+	}
+
+	// The installation token is cached between requests
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exchanges))
+}
+
+func TestGitHubAppAuth_RefreshesBeforeExpiry(t *testing.T) {
+	keyPath, key := writeGitHubAppKey(t)
+	var exchanges int32
+	// Tokens that expire inside the refresh margin are never reused
+	server := newFakeGitHubAppServer(t, key, githubAppTokenRefreshMargin/2, &exchanges)
+	defer server.Close()
+
+	auth, err := NewGitHubAppAuth(types.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath, InstallationID: 7},
+		server.URL, GetDefaultConfig(), newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	first, err := auth.Token(context.Background(), 7, "")
+	require.NoError(t, err)
+	second, err := auth.Token(context.Background(), 7, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "ghs_1", first)
+	assert.Equal(t, "ghs_2", second)
+}
+
+func TestGitHubAppAuth_ConcurrentRefresh(t *testing.T) {
+	keyPath, key := writeGitHubAppKey(t)
+	var exchanges int32
+	server := newFakeGitHubAppServer(t, key, time.Hour, &exchanges)
+	defer server.Close()
+
+	auth, err := NewGitHubAppAuth(types.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath},
+		server.URL, GetDefaultConfig(), newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	// Callers waiting on the same installation share one lookup and one exchange
+	var wg sync.WaitGroup
+	tokens := make([]string, 8)
+	for i := range tokens {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			token, err := auth.Token(context.Background(), 0, "octo-user")
+			assert.NoError(t, err)
+			tokens[i] = token
+		}(i)
+	}
+	wg.Wait()
+
+	for _, token := range tokens {
+		assert.Equal(t, "ghs_1", token)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&exchanges))
+}
+
+func TestGitHubClient_AppAuthenticationFallback(t *testing.T) {
+	keyPath, key := writeGitHubAppKey(t)
+	var exchanges int32
+	server := newFakeGitHubAppServer(t, key, time.Hour, &exchanges)
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.BaseURL = server.URL
+	config.RepositoryURL = "https://github.com/octo-user/repo"
+	config.GitHubApp = &types.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath}
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	// git authenticates with the installation token
+	repo := types.Repository{Name: "repo", URL: config.RepositoryURL, Provider: "github"}
+	assert.Equal(t, "ghs_1", client.fallbackRepository(context.Background(), repo).Token)
+
+	tokenConfig := GetDefaultConfig()
+	tokenConfig.Token = "pat"
+	tokenClient, err := NewGitHubClient(tokenConfig, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	repo.Token = "pat"
+	assert.Equal(t, repo, tokenClient.fallbackRepository(context.Background(), repo))
+}
+
+func TestGitHubAppAuth_Errors(t *testing.T) {
+	_, err := NewGitHubAppAuth(types.GitHubAppConfig{PrivateKeyPath: "key.pem"}, "", GetDefaultConfig(), newPaginationTestLogger(t))
+	assert.Error(t, err)
+
+	_, err = NewGitHubAppAuth(types.GitHubAppConfig{AppID: 42, PrivateKeyPath: filepath.Join(t.TempDir(), "missing.pem")},
+		"", GetDefaultConfig(), newPaginationTestLogger(t))
+	assert.Error(t, err)
+
+	keyPath, key := writeGitHubAppKey(t)
+	var exchanges int32
+	server := newFakeGitHubAppServer(t, key, time.Hour, &exchanges)
+	defer server.Close()
+
+	auth, err := NewGitHubAppAuth(types.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath},
+		server.URL, GetDefaultConfig(), newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	// Not installed on the owner
+	_, err = auth.Token(context.Background(), 0, "someone-else")
+	assert.IsType(t, &AuthenticationError{}, err)
+}
+
+func TestClientFactory_CreateClient_GitHubApp(t *testing.T) {
+	keyPath, _ := writeGitHubAppKey(t)
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	factory.SetGitHubApp(&types.GitHubAppConfig{AppID: 42, PrivateKeyPath: keyPath})
+
+	repo := types.Repository{Name: "repo", URL: "https://github.com/octo-user/repo", Provider: "github"}
+	first, err := factory.CreateClient(repo, GetDefaultConfig())
+	require.NoError(t, err)
+	second, err := factory.CreateClient(repo, GetDefaultConfig())
+	require.NoError(t, err)
+
+	// The global app is used when the repository has no token, and its token cache is shared
+	require.NotNil(t, first.(*GitHubClient).appAuth)
+	assert.Same(t, first.(*GitHubClient).appAuth, second.(*GitHubClient).appAuth)
+
+	// A repository token takes precedence over the global app
+	config := GetDefaultConfig()
+	config.Token = "pat"
+	client, err := factory.CreateClient(repo, config)
+	require.NoError(t, err)
+	assert.Nil(t, client.(*GitHubClient).appAuth)
+}
@@ -0,0 +1,207 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// GitHubGraphQLRequest represents a GitHub GraphQL API request body
+type GitHubGraphQLRequest struct {
+	Query string `json:"query"`
+}
+
+// GitHubGraphQLError represents an error entry in a GitHub GraphQL response
+type GitHubGraphQLError struct {
+	Type    string        `json:"type"`
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+// GitHubGraphQLRefs represents a page of refs for one repository in a GraphQL response
+type GitHubGraphQLRefs struct {
+	Refs struct {
+		PageInfo struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+		Nodes []struct {
+			Name   string `json:"name"`
+			Target struct {
+				OID string `json:"oid"`
+			} `json:"target"`
+			BranchProtectionRule *struct {
+				ID string `json:"id"`
+			} `json:"branchProtectionRule"`
+		} `json:"nodes"`
+	} `json:"refs"`
+}
+
+// githubGraphQLResponse is a batched branch query response keyed by repository alias
+type githubGraphQLResponse struct {
+	Data   map[string]*GitHubGraphQLRefs `json:"data"`
+	Errors []GitHubGraphQLError          `json:"errors"`
+}
+
+// graphQLRepoState tracks pagination of one repository within a batched query
+type graphQLRepoState struct {
+	repo   types.Repository
+	owner  string
+	name   string
+	cursor string
+	pages  int
+}
+
+// GetBranchesBatch retrieves the branches of several repositories with one GraphQL
+// query per page instead of one REST listing per repository. The result is keyed
+// by repository name; repositories that fail are omitted and logged so callers can
+// fall back to GetBranches for them.
+func (c *GitHubClient) GetBranchesBatch(ctx context.Context, repos []types.Repository) (map[string][]types.Branch, error) {
+	c.logger.WithFields(logger.Fields{
+		"operation":        "get_branches_batch",
+		"repository_count": len(repos),
+	}).Info("Starting batched branch retrieval")
+
+	pending := make(map[string]*graphQLRepoState)
+	for i, repo := range repos {
+		owner, name, err := c.parseRepoURL(repo.URL)
+		if err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "get_branches_batch",
+				"repository": repo.Name,
+			}).Warn("Skipping repository with unparseable URL")
+			continue
+		}
+		pending[fmt.Sprintf("r%d", i)] = &graphQLRepoState{repo: repo, owner: owner, name: name, pages: 1}
+	}
+
+	results := make(map[string][]types.Branch)
+	graphQLURL := githubGraphQLURL(c.baseURL)
+
+	for len(pending) > 0 {
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		var response githubGraphQLResponse
+		request := GitHubGraphQLRequest{Query: c.buildBranchesQuery(pending)}
+		if _, err := c.doRequest(ctx, "POST", graphQLURL, request, &response); err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":        "get_branches_batch",
+				"repository_count": len(pending),
+			}).Error("GraphQL request failed")
+			return nil, err
+		}
+
+		errorsByAlias := make(map[string]string)
+		for _, gqlErr := range response.Errors {
+			if len(gqlErr.Path) == 0 {
+				// Errors without a path apply to the whole query
+				return nil, &NetworkError{Provider: "github", Err: fmt.Errorf("graphql error: %s", gqlErr.Message)}
+			}
+			if alias, ok := gqlErr.Path[0].(string); ok {
+				errorsByAlias[alias] = gqlErr.Message
+			}
+		}
+
+		for alias, state := range pending {
+			data := response.Data[alias]
+			if data == nil || errorsByAlias[alias] != "" {
+				c.logger.WithFields(logger.Fields{
+					"operation":  "get_branches_batch",
+					"repository": state.repo.Name,
+					"error":      errorsByAlias[alias],
+				}).Warn("Repository missing from GraphQL response")
+				delete(results, state.repo.Name)
+				delete(pending, alias)
+				continue
+			}
+
+			branches := results[state.repo.Name]
+			if branches == nil {
+				branches = []types.Branch{}
+			}
+			for _, node := range data.Refs.Nodes {
+				branches = append(branches, types.Branch{
+					Name:      node.Name,
+					CommitSHA: node.Target.OID,
+					Protected: node.BranchProtectionRule != nil,
+				})
+			}
+			results[state.repo.Name] = branches
+
+			if !data.Refs.PageInfo.HasNextPage {
+				delete(pending, alias)
+				continue
+			}
+			if state.pages >= c.config.maxPages() {
+				// An incomplete listing must not pass for a complete one: leave
+				// the repository to its own request
+				c.logger.WithFields(logger.Fields{
+					"operation":    "get_branches_batch",
+					"repository":   state.repo.Name,
+					"max_pages":    c.config.maxPages(),
+					"branch_count": len(branches),
+				}).Warn("Reached page limit, dropping repository from batch")
+				delete(results, state.repo.Name)
+				delete(pending, alias)
+				continue
+			}
+			state.cursor = data.Refs.PageInfo.EndCursor
+			state.pages++
+		}
+	}
+
+	c.logger.WithFields(logger.Fields{
+		"operation":        "get_branches_batch",
+		"repository_count": len(repos),
+		"succeeded":        len(results),
+	}).Info("Completed batched branch retrieval")
+
+	return results, nil
+}
+
+// buildBranchesQuery builds one aliased repository(...) { refs } selection per pending repository
+func (c *GitHubClient) buildBranchesQuery(pending map[string]*graphQLRepoState) string {
+	aliases := make([]string, 0, len(pending))
+	for alias := range pending {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+
+	var query strings.Builder
+	query.WriteString("query {\n")
+	for _, alias := range aliases {
+		state := pending[alias]
+		after := ""
+		if state.cursor != "" {
+			after = ", after: " + graphQLString(state.cursor)
+		}
+		fmt.Fprintf(&query,
+			"  %s: repository(owner: %s, name: %s) { refs(refPrefix: \"refs/heads/\", first: %d%s) { pageInfo { hasNextPage endCursor } nodes { name target { oid } branchProtectionRule { id } } } }\n",
+			alias, graphQLString(state.owner), graphQLString(state.name), c.config.pageSize(), after)
+	}
+	query.WriteString("}")
+	return query.String()
+}
+
+// githubGraphQLURL derives the GraphQL endpoint from a REST API base URL.
+// GitHub Enterprise serves REST at /api/v3 and GraphQL at /api/graphql.
+func githubGraphQLURL(baseURL string) string {
+	baseURL = strings.TrimSuffix(baseURL, "/")
+	if strings.HasSuffix(baseURL, "/api/v3") {
+		return strings.TrimSuffix(baseURL, "/v3") + "/graphql"
+	}
+	return baseURL + "/graphql"
+}
+
+// graphQLString quotes a value as a GraphQL string literal
+func graphQLString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
@@ -0,0 +1,141 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_GetBranchesBatch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/graphql", r.URL.Path)
+		require.Equal(t, "POST", r.Method)
+		assert.Equal(t, "token test-token", r.Header.Get("Authorization"))
+		requests++
+
+		var request GitHubGraphQLRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		if requests == 1 {
+			// First page for all three repositories
+			assert.Contains(t, request.Query, `r0: repository(owner: "octo", name: "one")`)
+			assert.Contains(t, request.Query, `r1: repository(owner: "octo", name: "two")`)
+			assert.Contains(t, request.Query, `r2: repository(owner: "octo", name: "missing")`)
+			assert.Contains(t, request.Query, `first: 2`)
+			fmt.Fprint(w, `{
+				"data": {
+					"r0": {"refs": {"pageInfo": {"hasNextPage": true, "endCursor": "c1"}, "nodes": [
+						{"name": "main", "target": {"oid": "aaa"}, "branchProtectionRule": {"id": "p1"}},
+						{"name": "dev", "target": {"oid": "bbb"}, "branchProtectionRule": null}]}},
+					"r1": {"refs": {"pageInfo": {"hasNextPage": false, "endCursor": null}, "nodes": []}},
+					"r2": null
+				},
+				"errors": [{"type": "NOT_FOUND", "path": ["r2"], "message": "Could not resolve to a Repository"}]
+			}`)
+			return
+		}
+
+		// Only the repository with more pages is queried again, from its cursor
+		assert.Contains(t, request.Query, `r0: repository(owner: "octo", name: "one")`)
+		assert.Contains(t, request.Query, `after: "c1"`)
+		assert.NotContains(t, request.Query, "r1:")
+		fmt.Fprint(w, `{"data": {"r0": {"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [
+			{"name": "feature/x", "target": {"oid": "ccc"}}]}}}}`)
+	}))
+	defer server.Close()
+
+	limiter := &countingRateLimiter{}
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.PerPage = 2
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, limiter, nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	results, err := client.GetBranchesBatch(context.Background(), []types.Repository{
+		{Name: "one", URL: "https://github.com/octo/one"},
+		{Name: "two", URL: "https://github.com/octo/two"},
+		{Name: "missing", URL: "https://github.com/octo/missing"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 2, limiter.waits)
+	assert.Equal(t, []types.Branch{
+		{Name: "main", CommitSHA: "aaa", Protected: true},
+		{Name: "dev", CommitSHA: "bbb"},
+		{Name: "feature/x", CommitSHA: "ccc"},
+	}, results["one"])
+	assert.Equal(t, []types.Branch{}, results["two"])
+	_, found := results["missing"]
+	assert.False(t, found, "failed repositories are left for REST fallback")
+}
+
+func TestGitHubClient_GetBranchesBatch_QueryError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"errors": [{"message": "Parse error"}]}`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	_, err = client.GetBranchesBatch(context.Background(), []types.Repository{{Name: "one", URL: "https://github.com/octo/one"}})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "Parse error"))
+}
+
+func TestGitHubClient_GetBranchesBatch_MaxPages(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, `{"data": {
+			"r0": {"refs": {"pageInfo": {"hasNextPage": true, "endCursor": "c1"}, "nodes": [
+				{"name": "main", "target": {"oid": "aaa"}}]}},
+			"r1": {"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [
+				{"name": "main", "target": {"oid": "bbb"}}]}}
+		}}`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.MaxPages = 1
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	results, err := client.GetBranchesBatch(context.Background(), []types.Repository{
+		{Name: "one", URL: "https://github.com/octo/one"},
+		{Name: "two", URL: "https://github.com/octo/two"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, requests)
+	assert.Equal(t, []types.Branch{{Name: "main", CommitSHA: "bbb"}}, results["two"])
+	_, found := results["one"]
+	assert.False(t, found, "a listing cut short by the page cap is left to REST")
+}
+
+func TestGitHubGraphQLURL(t *testing.T) {
+	assert.Equal(t, "https://api.github.com/graphql", githubGraphQLURL("https://api.github.com"))
+	assert.Equal(t, "https://ghe.company.com/api/graphql", githubGraphQLURL("https://ghe.company.com/api/v3/"))
+}
@@ -3,7 +3,6 @@ package gitclient
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -13,6 +12,7 @@ import (
 
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // GitLabClient implements GitClient for GitLab API
@@ -22,6 +22,7 @@ type GitLabClient struct {
 	rateLimiter RateLimiter
 	fallback    *FallbackClient
 	baseURL     string
+	breaker     *CircuitBreaker // Shared by clients of the same API, nil when disabled
 	logger      *logger.Entry
 }
 
@@ -32,11 +33,120 @@ type GitLabBranch struct {
 	Protected bool         `json:"protected"`
 }
 
+// GitLabTag represents a tag response from GitLab API
+type GitLabTag struct {
+	Name   string       `json:"name"`
+	Commit GitLabCommit `json:"commit"`
+}
+
+// GitLabMergeRequest represents a merge request response from GitLab API
+type GitLabMergeRequest struct {
+	IID            int      `json:"iid"`
+	Title          string   `json:"title"`
+	State          string   `json:"state"` // opened, closed, locked or merged
+	SHA            string   `json:"sha"`
+	SourceBranch   string   `json:"source_branch"`
+	TargetBranch   string   `json:"target_branch"`
+	Labels         []string `json:"labels"`
+	Draft          bool     `json:"draft"`
+	WorkInProgress bool     `json:"work_in_progress"` // Replaced by draft in GitLab 14
+	WebURL         string   `json:"web_url"`
+	Author         struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// toPullRequest converts a GitLab merge request to our type
+func (mr GitLabMergeRequest) toPullRequest() types.PullRequest {
+	state := types.PullRequestStateOpen
+	switch mr.State {
+	case "merged":
+		state = types.PullRequestStateMerged
+	case "closed":
+		state = types.PullRequestStateClosed
+	}
+
+	labels := mr.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+
+	return types.PullRequest{
+		Number:       mr.IID,
+		Title:        mr.Title,
+		State:        state,
+		HeadSHA:      mr.SHA,
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		Author:       mr.Author.Username,
+		Labels:       labels,
+		Draft:        mr.Draft || mr.WorkInProgress,
+		URL:          mr.WebURL,
+	}
+}
+
 // GitLabCommit represents a commit in GitLab API response
 type GitLabCommit struct {
 	ID string `json:"id"`
 }
 
+// GitLabComparison represents a repository compare response from GitLab API
+type GitLabComparison struct {
+	Commits []struct {
+		ID           string    `json:"id"`
+		Message      string    `json:"message"`
+		AuthorName   string    `json:"author_name"`
+		AuthorEmail  string    `json:"author_email"`
+		AuthoredDate time.Time `json:"authored_date"`
+		WebURL       string    `json:"web_url"`
+	} `json:"commits"`
+	Diffs []struct {
+		OldPath     string `json:"old_path"`
+		NewPath     string `json:"new_path"`
+		NewFile     bool   `json:"new_file"`
+		RenamedFile bool   `json:"renamed_file"`
+		DeletedFile bool   `json:"deleted_file"`
+	} `json:"diffs"`
+	Overflow bool `json:"overflow"` // Set when the diff is too large to list every file
+}
+
+// toCommitComparison converts a GitLab comparison to our type
+func (c GitLabComparison) toCommitComparison() *types.CommitComparison {
+	comparison := &types.CommitComparison{
+		Commits:        make([]types.Commit, 0, len(c.Commits)),
+		TotalCommits:   len(c.Commits),
+		FilesTruncated: c.Overflow,
+	}
+
+	for _, commit := range c.Commits {
+		comparison.Commits = append(comparison.Commits, types.Commit{
+			SHA:         commit.ID,
+			Message:     commit.Message,
+			AuthorName:  commit.AuthorName,
+			AuthorEmail: commit.AuthorEmail,
+			Timestamp:   commit.AuthoredDate,
+			URL:         commit.WebURL,
+		})
+	}
+
+	for _, diff := range c.Diffs {
+		switch {
+		case diff.NewFile:
+			comparison.AddFile(diff.NewPath, "added")
+		case diff.DeletedFile:
+			comparison.AddFile(diff.OldPath, "removed")
+		case diff.RenamedFile:
+			comparison.AddFile(diff.OldPath, "removed")
+			comparison.AddFile(diff.NewPath, "added")
+		default:
+			comparison.AddFile(diff.NewPath, "modified")
+		}
+	}
+
+	comparison.AssignFilesToSingleCommit()
+	return comparison
+}
+
 // GitLabProject represents a project in GitLab API
 type GitLabProject struct {
 	ID                int    `json:"id"`
@@ -47,6 +157,7 @@ type GitLabProject struct {
 	WebURL            string `json:"web_url"`
 	HTTPURLToRepo     string `json:"http_url_to_repo"`
 	Visibility        string `json:"visibility"`
+	DefaultBranch     string `json:"default_branch"`
 }
 
 // NewGitLabClient creates a new GitLab client
@@ -65,8 +176,9 @@ func NewGitLabClient(config ClientConfig, rateLimiter RateLimiter, fallback *Fal
 		}
 	}
 
-	httpClient := &http.Client{
-		Timeout: config.Timeout,
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
 	}
 
 	clientLogger := parentLogger.WithFields(logger.Fields{
@@ -91,7 +203,7 @@ func NewGitLabClient(config ClientConfig, rateLimiter RateLimiter, fallback *Fal
 func (c *GitLabClient) GetBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error) {
 	projectID, err := c.getProjectID(ctx, repo.URL)
 	if err != nil {
-		if c.config.EnableFallback {
+		if c.config.EnableFallback && !IsCircuitOpen(err) {
 			return c.fallback.GetBranches(ctx, repo)
 		}
 		return nil, err
@@ -102,34 +214,206 @@ func (c *GitLabClient) GetBranches(ctx context.Context, repo types.Repository) (
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/projects/%s/repository/branches", c.baseURL, projectID)
+	baseURL := fmt.Sprintf("%s/projects/%s/repository/branches", c.baseURL, projectID)
+
+	var branches []types.Branch
+	for page := 1; page > 0; {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":    "get_branches",
+				"repository":   repo.Name,
+				"max_pages":    c.config.maxPages(),
+				"branch_count": len(branches),
+			}).Warn("Reached page limit, branch list is incomplete")
+			return branches, &IncompleteListingError{
+				Provider: "gitlab",
+				Resource: "branches of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
 
-	var gitlabBranches []GitLabBranch
-	if err := c.makeRequest(ctx, "GET", url, nil, &gitlabBranches); err != nil {
-		if c.config.EnableFallback && IsRetryableError(err) {
-			return c.fallback.GetBranches(ctx, repo)
+		// Every page counts against the shared rate limit
+		if page > 1 {
+			if err := c.rateLimiter.Wait(ctx); err != nil {
+				return nil, err
+			}
+		}
+
+		url := appendQuery(baseURL, fmt.Sprintf("per_page=%d&page=%d", c.config.pageSize(), page))
+
+		var gitlabBranches []GitLabBranch
+		headers, err := c.doRequest(ctx, "GET", url, nil, &gitlabBranches)
+		if err != nil {
+			if c.config.EnableFallback && IsRetryableError(err) {
+				return c.fallback.GetBranches(ctx, repo)
+			}
+			return nil, err
+		}
+
+		// Convert to our types
+		for _, gb := range gitlabBranches {
+			branches = append(branches, types.Branch{
+				Name:      gb.Name,
+				CommitSHA: gb.Commit.ID,
+				Protected: gb.Protected,
+			})
+		}
+
+		page = parseNextPage(headers)
+	}
+
+	return branches, nil
+}
+
+// GetTags retrieves all tags for a repository
+func (c *GitLabClient) GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error) {
+	projectID, err := c.getProjectID(ctx, repo.URL)
+	if err != nil {
+		if c.config.EnableFallback && !IsCircuitOpen(err) {
+			return c.fallback.GetTags(ctx, repo)
 		}
 		return nil, err
 	}
 
-	// Convert to our types
-	branches := make([]types.Branch, len(gitlabBranches))
-	for i, gb := range gitlabBranches {
-		branches[i] = types.Branch{
-			Name:      gb.Name,
-			CommitSHA: gb.Commit.ID,
-			Protected: gb.Protected,
+	baseURL := fmt.Sprintf("%s/projects/%s/repository/tags", c.baseURL, projectID)
+
+	tags := []types.Tag{}
+	for page := 1; page > 0; {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "get_tags",
+				"repository": repo.Name,
+				"max_pages":  c.config.maxPages(),
+				"tag_count":  len(tags),
+			}).Warn("Reached page limit, tag list is incomplete")
+			return tags, &IncompleteListingError{
+				Provider: "gitlab",
+				Resource: "tags of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
 		}
+
+		url := appendQuery(baseURL, fmt.Sprintf("per_page=%d&page=%d", c.config.pageSize(), page))
+
+		var gitlabTags []GitLabTag
+		headers, err := c.doRequest(ctx, "GET", url, nil, &gitlabTags)
+		if err != nil {
+			if c.config.EnableFallback && IsRetryableError(err) {
+				return c.fallback.GetTags(ctx, repo)
+			}
+			return nil, err
+		}
+
+		for _, gt := range gitlabTags {
+			tags = append(tags, types.Tag{Name: gt.Name, CommitSHA: gt.Commit.ID})
+		}
+
+		page = parseNextPage(headers)
 	}
 
-	return branches, nil
+	return tags, nil
+}
+
+// ListPullRequests retrieves open merge requests for a project
+func (c *GitLabClient) ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error) {
+	projectID, err := c.getProjectID(ctx, repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := fmt.Sprintf("%s/projects/%s/merge_requests", c.baseURL, projectID)
+
+	pullRequests := []types.PullRequest{}
+	for page := 1; page > 0; {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":          "list_pull_requests",
+				"repository":         repo.Name,
+				"max_pages":          c.config.maxPages(),
+				"pull_request_count": len(pullRequests),
+			}).Warn("Reached page limit, merge request list is incomplete")
+			return pullRequests, &IncompleteListingError{
+				Provider: "gitlab",
+				Resource: "merge requests of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
+
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		url := appendQuery(baseURL, fmt.Sprintf("state=opened&per_page=%d&page=%d", c.config.pageSize(), page))
+
+		var mergeRequests []GitLabMergeRequest
+		headers, err := c.doRequest(ctx, "GET", url, nil, &mergeRequests)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, mr := range mergeRequests {
+			pullRequests = append(pullRequests, mr.toPullRequest())
+		}
+
+		page = parseNextPage(headers)
+	}
+
+	return pullRequests, nil
+}
+
+// GetPullRequest retrieves a single merge request by IID
+func (c *GitLabClient) GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error) {
+	projectID, err := c.getProjectID(ctx, repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/merge_requests/%d", c.baseURL, projectID, number)
+
+	var mergeRequest GitLabMergeRequest
+	if err := c.makeRequest(ctx, "GET", url, nil, &mergeRequest); err != nil {
+		return nil, err
+	}
+
+	pullRequest := mergeRequest.toPullRequest()
+	return &pullRequest, nil
+}
+
+// CompareCommits retrieves the commits between two revisions
+func (c *GitLabClient) CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error) {
+	projectID, err := c.getProjectID(ctx, repo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+
+	apiURL := fmt.Sprintf("%s/projects/%s/repository/compare?from=%s&to=%s",
+		c.baseURL, projectID, url.QueryEscape(base), url.QueryEscape(head))
+
+	var gitlabComparison GitLabComparison
+	if err := c.makeRequest(ctx, "GET", apiURL, nil, &gitlabComparison); err != nil {
+		return nil, err
+	}
+
+	return gitlabComparison.toCommitComparison(), nil
 }
 
 // GetLatestCommit retrieves the latest commit SHA for a branch
 func (c *GitLabClient) GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error) {
 	projectID, err := c.getProjectID(ctx, repo.URL)
 	if err != nil {
-		if c.config.EnableFallback {
+		if c.config.EnableFallback && !IsCircuitOpen(err) {
 			return c.fallback.GetLatestCommit(ctx, repo, branch)
 		}
 		return "", err
@@ -153,6 +437,22 @@ func (c *GitLabClient) GetLatestCommit(ctx context.Context, repo types.Repositor
 	return gitlabBranch.Commit.ID, nil
 }
 
+// GetDefaultBranch retrieves the name of the project's default branch
+func (c *GitLabClient) GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error) {
+	gitlabProject, err := c.getProject(ctx, repo.URL)
+	if err != nil {
+		if c.config.EnableFallback && !IsCircuitOpen(err) {
+			return c.fallback.GetDefaultBranch(ctx, repo)
+		}
+		return "", err
+	}
+
+	if gitlabProject.DefaultBranch == "" {
+		return "", fmt.Errorf("repository %s has no default branch", repo.Name)
+	}
+	return gitlabProject.DefaultBranch, nil
+}
+
 // CheckPermissions verifies if the client has access to the repository
 func (c *GitLabClient) CheckPermissions(ctx context.Context, repo types.Repository) error {
 	_, err := c.getProjectID(ctx, repo.URL)
@@ -184,33 +484,49 @@ func (c *GitLabClient) Close() error {
 
 // getProjectID retrieves the project ID from a GitLab repository URL
 func (c *GitLabClient) getProjectID(ctx context.Context, repoURL string) (string, error) {
-	namespace, project, err := c.parseRepoURL(repoURL)
+	gitlabProject, err := c.getProject(ctx, repoURL)
 	if err != nil {
 		return "", err
 	}
 
+	return strconv.Itoa(gitlabProject.ID), nil
+}
+
+// getProject looks up a project by the path in its repository URL
+func (c *GitLabClient) getProject(ctx context.Context, repoURL string) (*GitLabProject, error) {
+	namespace, project, err := c.parseRepoURL(repoURL)
+	if err != nil {
+		return nil, err
+	}
+
 	projectPath := url.QueryEscape(fmt.Sprintf("%s/%s", namespace, project))
 
 	// Wait for rate limiter
 	if err := c.rateLimiter.Wait(ctx); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/projects/%s", c.baseURL, projectPath)
 
 	var gitlabProject GitLabProject
 	if err := c.makeRequest(ctx, "GET", url, nil, &gitlabProject); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return strconv.Itoa(gitlabProject.ID), nil
+	return &gitlabProject, nil
 }
 
 // makeRequest makes an HTTP request to the GitLab API
 func (c *GitLabClient) makeRequest(ctx context.Context, method, url string, body interface{}, result interface{}) error {
+	_, err := c.doRequest(ctx, method, url, body, result)
+	return err
+}
+
+// doRequest makes an HTTP request to the GitLab API and returns the response headers
+func (c *GitLabClient) doRequest(ctx context.Context, method, url string, body interface{}, result interface{}) (http.Header, error) {
 	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
-		return &NetworkError{Provider: "gitlab", Err: err}
+		return nil, &NetworkError{Provider: "gitlab", Err: err}
 	}
 
 	// Set headers
@@ -218,24 +534,34 @@ func (c *GitLabClient) makeRequest(ctx context.Context, method, url string, body
 	req.Header.Set("User-Agent", c.config.UserAgent)
 	req.Header.Set("Content-Type", "application/json")
 
+	// Conditional request validators from a previous response
+	cached := c.config.HTTPCache.applyValidators(ctx, req)
+
 	// Retry logic
 	var lastErr error
 	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
 		if attempt > 0 {
 			select {
 			case <-ctx.Done():
-				return ctx.Err()
+				return nil, ctx.Err()
 			case <-time.After(c.config.RetryBackoff * time.Duration(attempt)):
 			}
 		}
 
+		// Fail fast while the API is known to be down
+		ticket, err := c.breaker.Allow()
+		if err != nil {
+			return nil, err
+		}
+
 		resp, err := c.httpClient.Do(req)
+		c.breaker.RecordResponse(ticket, resp, err)
 		if err != nil {
 			lastErr = &NetworkError{Provider: "gitlab", Err: err}
 			if attempt < c.config.RetryAttempts {
 				continue
 			}
-			return lastErr
+			return nil, lastErr
 		}
 
 		// Update rate limiter from headers
@@ -244,48 +570,56 @@ func (c *GitLabClient) makeRequest(ctx context.Context, method, url string, body
 		// Handle different status codes
 		switch resp.StatusCode {
 		case http.StatusOK:
-			if result != nil {
-				defer resp.Body.Close()
-				if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-					return &NetworkError{Provider: "gitlab", Err: err}
-				}
+			defer resp.Body.Close()
+			if err := c.config.HTTPCache.decodeAndStore(ctx, req, resp, result); err != nil {
+				return nil, &NetworkError{Provider: "gitlab", Err: err}
+			}
+			return resp.Header, nil
+		case http.StatusNotModified:
+			resp.Body.Close()
+			if cached == nil {
+				return nil, &NetworkError{Provider: "gitlab", Err: fmt.Errorf("not modified response without a cached entry")}
+			}
+			headers, err := c.config.HTTPCache.notModified(cached, result)
+			if err != nil {
+				return nil, &NetworkError{Provider: "gitlab", Err: err}
 			}
-			return nil
+			return headers, nil
 		case http.StatusUnauthorized, http.StatusForbidden:
 			resp.Body.Close()
-			return &AuthenticationError{Provider: "gitlab", Message: "invalid or insufficient permissions"}
+			return nil, &AuthenticationError{Provider: "gitlab", Message: "invalid or insufficient permissions"}
 		case http.StatusNotFound:
 			resp.Body.Close()
-			return &RepositoryNotFoundError{Repository: url, Provider: "gitlab"}
+			return nil, &RepositoryNotFoundError{Repository: url, Provider: "gitlab"}
 		case http.StatusTooManyRequests:
 			resp.Body.Close()
 			resetTime := c.parseResetTime(resp.Header.Get("RateLimit-ResetTime"))
-			return &RateLimitExceededError{Provider: "gitlab", ResetTime: resetTime}
+			return nil, &RateLimitExceededError{Provider: "gitlab", ResetTime: resetTime}
 		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
 			resp.Body.Close()
 			lastErr = &NetworkError{Provider: "gitlab", Err: fmt.Errorf("server error: %d", resp.StatusCode)}
 			if attempt < c.config.RetryAttempts {
 				continue
 			}
-			return lastErr
+			return nil, lastErr
 		default:
 			resp.Body.Close()
-			return &NetworkError{Provider: "gitlab", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
+			return nil, &NetworkError{Provider: "gitlab", Err: fmt.Errorf("unexpected status code: %d", resp.StatusCode)}
 		}
 	}
 
-	return lastErr
+	return nil, lastErr
 }
 
 // parseRepoURL extracts namespace and project name from GitLab URL
 func (c *GitLabClient) parseRepoURL(repoURL string) (namespace, project string, err error) {
-	parsedURL, err := url.Parse(repoURL)
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		return "", "", fmt.Errorf("invalid repository URL: %w", err)
 	}
 
-	// Handle GitLab URLs: https://gitlab.com/namespace/project
-	pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+	// Handle GitLab URLs: https://gitlab.com/namespace/project or git@gitlab.com:namespace/project.git
+	pathParts := strings.Split(strings.TrimSuffix(strings.Trim(parsedURL.Path, "/"), ".git"), "/")
 	if len(pathParts) < 2 {
 		return "", "", fmt.Errorf("invalid GitLab repository URL format: %s", repoURL)
 	}
@@ -327,30 +661,31 @@ func (c *GitLabClient) parseResetTime(resetStr string) time.Time {
 	return time.Now().Add(time.Minute) // Default to 1 minute if parsing fails
 }
 
+// parseNextPage returns the X-Next-Page header value, or 0 on the last page
+func parseNextPage(headers http.Header) int {
+	next, err := strconv.Atoi(strings.TrimSpace(headers.Get("X-Next-Page")))
+	if err != nil {
+		return 0
+	}
+	return next
+}
+
 // extractGitLabAPIURL extracts GitLab API URL from repository URL
 func extractGitLabAPIURL(repoURL string) string {
 	// Parse the repository URL to extract the host
-	parsedURL, err := url.Parse(repoURL)
-	if err != nil {
+	parsedURL, err := utils.ParseGitURL(repoURL)
+	if err != nil || parsedURL.Host == "" {
 		return "https://gitlab.com/api/v4" // Default fallback
 	}
 
-	// Construct API URL from the host
-	scheme := parsedURL.Scheme
-	if scheme == "" {
-		scheme = "https"
-	}
-
 	// For our specific use case, support these two GitLab instances
-	host := parsedURL.Host
-	switch host {
-	case "gitlab-master.nvidia.com":
-		return fmt.Sprintf("%s://%s/api/v4", scheme, host)
+	switch parsedURL.Hostname() {
 	case "gitlab.com":
 		return "https://gitlab.com/api/v4"
 	default:
-		// For any other GitLab instance, construct API URL
-		return fmt.Sprintf("%s://%s/api/v4", scheme, host)
+		// For any other GitLab instance (including gitlab-master.nvidia.com), construct
+		// the API URL; SSH URLs map to the instance's HTTPS endpoint
+		return utils.WebBaseURL(parsedURL) + "/api/v4"
 	}
 }
 
@@ -378,32 +713,62 @@ type GitLabFile struct {
 }
 
 // ListFiles retrieves all files in a specific path for a commit
-func (c *GitLabClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]string, error) {
+func (c *GitLabClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]FileEntry, error) {
 	projectPath, err := c.parseProjectPath(repo.URL)
 	if err != nil {
 		return nil, fmt.Errorf("invalid repository URL: %w", err)
 	}
 
-	// Use repository tree API
+	// Use repository tree API, which lists only the given directory
 	encodedPath := url.QueryEscape(projectPath)
-	apiURL := fmt.Sprintf("%s/projects/%s/repository/tree?ref=%s&recursive=true", 
-		c.baseURL, encodedPath, commitSHA)
-	
+	baseURL := fmt.Sprintf("%s/projects/%s/repository/tree?ref=%s&recursive=true",
+		c.baseURL, encodedPath, url.QueryEscape(commitSHA))
+
+	path = strings.Trim(path, "/")
 	if path != "" {
-		apiURL += "&path=" + url.QueryEscape(path)
-	}
+		baseURL += "&path=" + url.QueryEscape(path)
+	}
+
+	var files []FileEntry
+	for page := 1; page > 0; {
+		if page > c.config.maxPages() {
+			c.logger.WithFields(logger.Fields{
+				"operation":  "list_files",
+				"repository": repo.Name,
+				"path":       path,
+				"max_pages":  c.config.maxPages(),
+			}).Warn("Reached page limit, file list is incomplete")
+			return nil, &IncompleteListingError{
+				Provider: "gitlab",
+				Resource: "files of " + repo.Name,
+				MaxPages: c.config.maxPages(),
+			}
+		}
 
-	var tree []GitLabTreeItem
-	if err := c.makeRequest(ctx, "GET", apiURL, nil, &tree); err != nil {
-		return nil, fmt.Errorf("failed to get tree: %w", err)
-	}
+		if err := c.rateLimiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		apiURL := appendQuery(baseURL, fmt.Sprintf("per_page=%d&page=%d", c.config.pageSize(), page))
+
+		var tree []GitLabTreeItem
+		headers, err := c.doRequest(ctx, "GET", apiURL, nil, &tree)
+		if err != nil {
+			if c.config.EnableFallback && IsRetryableError(err) {
+				c.logger.Info("Attempting fallback after API failure")
+				return c.fallback.ListFiles(ctx, repo, commitSHA, path)
+			}
+			return nil, fmt.Errorf("failed to get tree: %w", err)
+		}
 
-	var files []string
-	for _, item := range tree {
-		// Only include files (blobs)
-		if item.Type == "blob" {
-			files = append(files, item.Path)
+		for _, item := range tree {
+			// Only include files (blobs)
+			if item.Type == "blob" {
+				files = append(files, FileEntry{Path: item.Path, SHA: item.ID})
+			}
 		}
+
+		page = parseNextPage(headers)
 	}
 
 	return files, nil
@@ -424,6 +789,10 @@ func (c *GitLabClient) GetFileContent(ctx context.Context, repo types.Repository
 
 	var file GitLabFile
 	if err := c.makeRequest(ctx, "GET", apiURL, nil, &file); err != nil {
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.GetFileContent(ctx, repo, commitSHA, filePath)
+		}
 		return nil, fmt.Errorf("failed to get file content: %w", err)
 	}
 
@@ -454,10 +823,12 @@ func (c *GitLabClient) CheckDirectoryExists(ctx context.Context, repo types.Repo
 	var tree []GitLabTreeItem
 	if err := c.makeRequest(ctx, "GET", apiURL, nil, &tree); err != nil {
 		// If 404, directory doesn't exist
-		if networkErr, ok := err.(*NetworkError); ok {
-			if strings.Contains(networkErr.Err.Error(), "404") {
-				return false, nil
-			}
+		if _, ok := err.(*RepositoryNotFoundError); ok {
+			return false, nil
+		}
+		if c.config.EnableFallback && IsRetryableError(err) {
+			c.logger.Info("Attempting fallback after API failure")
+			return c.fallback.CheckDirectoryExists(ctx, repo, commitSHA, dirPath)
 		}
 		return false, fmt.Errorf("failed to check directory: %w", err)
 	}
@@ -471,17 +842,18 @@ func (c *GitLabClient) parseProjectPath(repoURL string) (string, error) {
 	// https://gitlab.com/group/project
 	// https://gitlab-master.nvidia.com/group/subgroup/project
 	
-	parsedURL, err := url.Parse(repoURL)
+	// git@gitlab.com:group/project.git
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		return "", fmt.Errorf("invalid URL format: %w", err)
 	}
 
-	// Validate that it's a proper HTTP/HTTPS URL
+	// Validate that it's a proper HTTP/HTTPS or SSH URL
 	if parsedURL.Scheme == "" || parsedURL.Host == "" {
 		return "", fmt.Errorf("invalid URL: missing scheme or host")
 	}
 	
-	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" && parsedURL.Scheme != "ssh" {
 		return "", fmt.Errorf("unsupported URL scheme: %s", parsedURL.Scheme)
 	}
 
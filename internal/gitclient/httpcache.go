@@ -0,0 +1,266 @@
+package gitclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// maxHTTPCacheEntries bounds the in-memory cache; persisted entries are bounded by age
+const maxHTTPCacheEntries = 10000
+
+// DefaultHTTPCacheMaxAge is how long a response is cached without being downloaded
+// again, used when HTTPCacheConfig leaves MaxAge unset
+const DefaultHTTPCacheMaxAge = 7 * 24 * time.Hour
+
+// httpCachePruneInterval is how often entries older than the max age are deleted
+const httpCachePruneInterval = time.Hour
+
+// cachedResponseHeaders are replayed from the cache on 304 responses so pagination keeps working
+var cachedResponseHeaders = []string{"Link", "X-Next-Page", "X-Total", "X-Total-Pages"}
+
+// HTTPCacheStore persists cached API responses. storage.Storage satisfies this interface.
+type HTTPCacheStore interface {
+	GetHTTPCacheEntry(ctx context.Context, key string) (*types.HTTPCacheEntry, error)
+	SaveHTTPCacheEntry(ctx context.Context, entry *types.HTTPCacheEntry) error
+	DeleteOldHTTPCacheEntries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// HTTPCacheStats represents conditional request cache counters
+type HTTPCacheStats struct {
+	Hits   int64 `json:"hits"`   // 304 responses served from the cache
+	Misses int64 `json:"misses"` // Full responses downloaded
+}
+
+// HTTPCache keeps ETag / Last-Modified validated API responses keyed by URL so
+// clients can send conditional requests. 304 responses do not count against
+// GitHub's rate limit. Entries not downloaded again within the max age are
+// deleted, so responses for URLs no longer requested do not pile up in storage.
+type HTTPCache struct {
+	mu        sync.RWMutex
+	entries   map[string]*types.HTTPCacheEntry
+	store     HTTPCacheStore
+	maxAge    time.Duration
+	lastPrune time.Time
+	hits      int64
+	misses    int64
+	logger    *logger.Entry
+}
+
+// NewHTTPCache creates a response cache. store may be nil to keep entries in memory only.
+func NewHTTPCache(store HTTPCacheStore, parentLogger *logger.Entry) *HTTPCache {
+	return &HTTPCache{
+		entries: make(map[string]*types.HTTPCacheEntry),
+		store:   store,
+		maxAge:  DefaultHTTPCacheMaxAge,
+		logger: parentLogger.WithFields(logger.Fields{
+			"component": "gitclient",
+			"module":    "http_cache",
+		}),
+	}
+}
+
+// SetMaxAge sets how long a response is cached without being downloaded again.
+// Zero or less keeps the default.
+func (c *HTTPCache) SetMaxAge(maxAge time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if maxAge <= 0 {
+		maxAge = DefaultHTTPCacheMaxAge
+	}
+	c.maxAge = maxAge
+}
+
+// Get returns the cached entry for a key, loading it from the store if needed
+func (c *HTTPCache) Get(ctx context.Context, key string) *types.HTTPCacheEntry {
+	c.mu.RLock()
+	entry, exists := c.entries[key]
+	c.mu.RUnlock()
+	if exists || c.store == nil {
+		return entry
+	}
+
+	entry, err := c.store.GetHTTPCacheEntry(ctx, key)
+	if err != nil {
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation": "get_cache_entry",
+			"key":       key,
+		}).Debug("Failed to load cached response")
+		return nil
+	}
+	if entry != nil {
+		c.remember(entry)
+	}
+	return entry
+}
+
+// Set caches an entry in memory and in the store if one is configured
+func (c *HTTPCache) Set(ctx context.Context, entry *types.HTTPCacheEntry) {
+	if entry.UpdatedAt.IsZero() {
+		entry.UpdatedAt = time.Now()
+	}
+	c.remember(entry)
+
+	if c.store != nil {
+		if err := c.store.SaveHTTPCacheEntry(ctx, entry); err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation": "save_cache_entry",
+				"key":       entry.Key,
+			}).Warn("Failed to persist cached response")
+		}
+	}
+
+	c.pruneIfDue(ctx, time.Now())
+}
+
+// pruneIfDue deletes entries older than the max age from memory and the store,
+// at most once per httpCachePruneInterval
+func (c *HTTPCache) pruneIfDue(ctx context.Context, now time.Time) {
+	c.mu.Lock()
+	if now.Sub(c.lastPrune) < httpCachePruneInterval {
+		c.mu.Unlock()
+		return
+	}
+	c.lastPrune = now
+	maxAge := c.maxAge
+	cutoff := now.Add(-maxAge)
+	for key, entry := range c.entries {
+		if entry.UpdatedAt.Before(cutoff) {
+			delete(c.entries, key)
+		}
+	}
+	c.mu.Unlock()
+
+	if c.store == nil {
+		return
+	}
+	deleted, err := c.store.DeleteOldHTTPCacheEntries(ctx, cutoff)
+	if err != nil {
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation": "prune_cache",
+		}).Warn("Failed to delete old cached responses")
+		return
+	}
+	if deleted > 0 {
+		c.logger.WithFields(logger.Fields{
+			"operation": "prune_cache",
+			"deleted":   deleted,
+			"max_age":   maxAge.String(),
+		}).Debug("Deleted old cached responses")
+	}
+}
+
+// Stats returns hit and miss counts since the cache was created
+func (c *HTTPCache) Stats() HTTPCacheStats {
+	if c == nil {
+		return HTTPCacheStats{}
+	}
+	return HTTPCacheStats{
+		Hits:   atomic.LoadInt64(&c.hits),
+		Misses: atomic.LoadInt64(&c.misses),
+	}
+}
+
+// remember stores an entry in memory, evicting an arbitrary entry when full
+func (c *HTTPCache) remember(entry *types.HTTPCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.entries[entry.Key]; !exists && len(c.entries) >= maxHTTPCacheEntries {
+		for key := range c.entries {
+			delete(c.entries, key)
+			break
+		}
+	}
+	c.entries[entry.Key] = entry
+}
+
+// applyValidators adds conditional headers from a cached response to a GET request
+// and returns the entry they came from. It is safe to call on a nil cache.
+func (c *HTTPCache) applyValidators(ctx context.Context, req *http.Request) *types.HTTPCacheEntry {
+	if c == nil || req.Method != http.MethodGet {
+		return nil
+	}
+
+	entry := c.Get(ctx, req.URL.String())
+	if entry == nil {
+		return nil
+	}
+	if entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	if entry.LastModified != "" {
+		req.Header.Set("If-Modified-Since", entry.LastModified)
+	}
+	return entry
+}
+
+// notModified decodes a cached response into result and returns its replayed headers
+func (c *HTTPCache) notModified(entry *types.HTTPCacheEntry, result interface{}) (http.Header, error) {
+	atomic.AddInt64(&c.hits, 1)
+
+	if result != nil {
+		if err := json.Unmarshal(entry.Body, result); err != nil {
+			return nil, err
+		}
+	}
+
+	headers := make(http.Header)
+	for name, value := range entry.Headers {
+		headers.Set(name, value)
+	}
+	return headers, nil
+}
+
+// decodeAndStore decodes a 200 response into result and caches it when the
+// provider sent validators. It is safe to call on a nil cache.
+func (c *HTTPCache) decodeAndStore(ctx context.Context, req *http.Request, resp *http.Response, result interface{}) error {
+	if c == nil || req.Method != http.MethodGet {
+		if result == nil {
+			return nil
+		}
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+
+	atomic.AddInt64(&c.misses, 1)
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if result != nil {
+		if err := json.Unmarshal(body, result); err != nil {
+			return err
+		}
+	}
+
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return nil
+	}
+
+	headers := make(map[string]string)
+	for _, name := range cachedResponseHeaders {
+		if value := resp.Header.Get(name); value != "" {
+			headers[name] = value
+		}
+	}
+
+	c.Set(ctx, &types.HTTPCacheEntry{
+		Key:          req.URL.String(),
+		ETag:         etag,
+		LastModified: lastModified,
+		Headers:      headers,
+		Body:         body,
+	})
+	return nil
+}
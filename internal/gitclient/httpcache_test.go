@@ -0,0 +1,179 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCacheStore is an HTTPCacheStore backed by a map
+type memoryCacheStore struct {
+	mu      sync.Mutex
+	entries map[string]*types.HTTPCacheEntry
+}
+
+func (s *memoryCacheStore) GetHTTPCacheEntry(ctx context.Context, key string) (*types.HTTPCacheEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.entries[key], nil
+}
+
+func (s *memoryCacheStore) SaveHTTPCacheEntry(ctx context.Context, entry *types.HTTPCacheEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.Key] = entry
+	return nil
+}
+
+func (s *memoryCacheStore) DeleteOldHTTPCacheEntries(ctx context.Context, before time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var deleted int64
+	for key, entry := range s.entries {
+		if entry.UpdatedAt.Before(before) {
+			delete(s.entries, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// newETagServer serves two pages of branches (plus a GitLab project lookup) with ETags
+// and answers 304 to a matching If-None-Match
+func newETagServer(t *testing.T, nextPageHeader func(serverURL, path string) (string, string)) (*httptest.Server, *int32) {
+	var fullResponses int32
+	var mu sync.Mutex
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		etag := fmt.Sprintf(`W/"page-%s"`, page)
+		if !strings.HasSuffix(r.URL.Path, "/branches") {
+			etag = `W/"project"`
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		mu.Lock()
+		fullResponses++
+		mu.Unlock()
+
+		w.Header().Set("ETag", etag)
+		if etag == `W/"project"` {
+			fmt.Fprint(w, `{"id":42,"path_with_namespace":"group/repo"}`)
+			return
+		}
+		if page == "1" {
+			name, value := nextPageHeader(server.URL, r.URL.Path)
+			w.Header().Set(name, value)
+		}
+		fmt.Fprintf(w, `[{"name":"branch-%s","commit":{"sha":"sha-%s","id":"sha-%s"}}]`, page, page, page)
+	}))
+	return server, &fullResponses
+}
+
+func TestGitHubClient_ConditionalRequests(t *testing.T) {
+	server, fullResponses := newETagServer(t, func(serverURL, path string) (string, string) {
+		return "Link", fmt.Sprintf(`<%s%s?per_page=1&page=2>; rel="next"`, serverURL, path)
+	})
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+	config.HTTPCache = NewHTTPCache(nil, newPaginationTestLogger(t))
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo", Provider: "github"}
+	first, err := client.GetBranches(context.Background(), repo)
+	require.NoError(t, err)
+	second, err := client.GetBranches(context.Background(), repo)
+	require.NoError(t, err)
+
+	// The second listing is replayed from the cache, including the pagination link
+	assert.Len(t, second, 2)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(2), *fullResponses)
+	assert.Equal(t, HTTPCacheStats{Hits: 2, Misses: 2}, config.HTTPCache.Stats())
+}
+
+func TestGitLabClient_ConditionalRequests(t *testing.T) {
+	server, fullResponses := newETagServer(t, func(serverURL, path string) (string, string) {
+		return "X-Next-Page", "2"
+	})
+	defer server.Close()
+
+	store := &memoryCacheStore{entries: make(map[string]*types.HTTPCacheEntry)}
+	config := GetDefaultConfig()
+	config.Token = "token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+	config.HTTPCache = NewHTTPCache(store, newPaginationTestLogger(t))
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo", Provider: "gitlab"}
+	_, err = client.GetBranches(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Len(t, store.entries, 3, "project lookup and both branch pages are persisted")
+
+	// A fresh cache backed by the same store still sends validators, e.g. after a restart
+	config.HTTPCache = NewHTTPCache(store, newPaginationTestLogger(t))
+	client, err = NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	branches, err := client.GetBranches(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Len(t, branches, 2)
+	assert.Equal(t, int32(3), *fullResponses)
+	assert.Equal(t, HTTPCacheStats{Hits: 3}, config.HTTPCache.Stats())
+}
+
+func TestHTTPCache_NilSafe(t *testing.T) {
+	var cache *HTTPCache
+	assert.Equal(t, HTTPCacheStats{}, cache.Stats())
+
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	assert.Equal(t, HTTPCacheStats{}, factory.HTTPCacheStats())
+}
+
+func TestHTTPCache_Prune(t *testing.T) {
+	now := time.Now()
+	store := &memoryCacheStore{entries: map[string]*types.HTTPCacheEntry{
+		"stale": {Key: "stale", ETag: `"a"`, UpdatedAt: now.Add(-48 * time.Hour)},
+	}}
+	cache := NewHTTPCache(store, newPaginationTestLogger(t))
+	cache.SetMaxAge(24 * time.Hour)
+	require.NotNil(t, cache.Get(context.Background(), "stale"))
+
+	// Saving an entry prunes those not downloaded again within the max age
+	cache.Set(context.Background(), &types.HTTPCacheEntry{Key: "fresh", ETag: `"b"`})
+	assert.Len(t, store.entries, 1)
+	assert.Contains(t, store.entries, "fresh")
+	assert.Nil(t, cache.Get(context.Background(), "stale"))
+
+	// Pruning runs at most once per interval
+	store.entries["old"] = &types.HTTPCacheEntry{Key: "old", UpdatedAt: now.Add(-48 * time.Hour)}
+	cache.Set(context.Background(), &types.HTTPCacheEntry{Key: "another"})
+	assert.Contains(t, store.entries, "old")
+
+	cache.pruneIfDue(context.Background(), now.Add(httpCachePruneInterval+time.Minute))
+	assert.NotContains(t, store.entries, "old")
+}
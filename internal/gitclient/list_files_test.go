@@ -0,0 +1,209 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGitHubFilesTestClient(t *testing.T, baseURL string) *GitHubClient {
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = baseURL
+	config.EnableFallback = false
+	config.RetryAttempts = 0
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+	return client
+}
+
+// filePaths returns the paths of listed files
+func filePaths(files []FileEntry) []string {
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	return paths
+}
+
+func TestGitHubClient_ListFiles(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.RequestURI())
+		switch r.URL.RequestURI() {
+		case "/repos/owner/repo/git/trees/commit1":
+			fmt.Fprint(w, `{"sha":"root","tree":[
+				{"path":".tekton","type":"tree","sha":"tekton-tree"},
+				{"path":".tektonfoo","type":"tree","sha":"other-tree"},
+				{"path":"README.md","type":"blob","sha":"readme"}]}`)
+		case "/repos/owner/repo/git/trees/tekton-tree?recursive=1":
+			fmt.Fprint(w, `{"sha":"tekton-tree","truncated":false,"tree":[
+				{"path":"pipeline.yaml","type":"blob","sha":"pipeline-blob"},
+				{"path":"tasks","type":"tree","sha":"tasks-tree"},
+				{"path":"tasks/build.yaml","type":"blob","sha":"build-blob"}]}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.RequestURI())
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newGitHubFilesTestClient(t, server.URL)
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+
+	files, err := client.ListFiles(context.Background(), repo, "commit1", ".tekton/")
+	require.NoError(t, err)
+	assert.Equal(t, []FileEntry{
+		{Path: ".tekton/pipeline.yaml", SHA: "pipeline-blob"},
+		{Path: ".tekton/tasks/build.yaml", SHA: "build-blob"},
+	}, files)
+	assert.Equal(t, []string{
+		"/repos/owner/repo/git/trees/commit1",
+		"/repos/owner/repo/git/trees/tekton-tree?recursive=1",
+	}, requested, "only the directory's subtree is listed recursively")
+
+	// A directory that doesn't exist has no files
+	files, err = client.ListFiles(context.Background(), repo, "commit1", ".tekton-missing")
+	require.NoError(t, err)
+	assert.Empty(t, files)
+}
+
+func TestGitHubClient_ListFilesTruncated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/repos/owner/repo/git/trees/commit1":
+			fmt.Fprint(w, `{"sha":"root","tree":[{"path":"deploy","type":"tree","sha":"deploy-tree"}]}`)
+		case "/repos/owner/repo/git/trees/deploy-tree":
+			fmt.Fprint(w, `{"sha":"deploy-tree","tree":[{"path":".tekton","type":"tree","sha":"tekton-tree"}]}`)
+		case "/repos/owner/repo/git/trees/tekton-tree?recursive=1":
+			fmt.Fprint(w, `{"sha":"tekton-tree","truncated":true,"tree":[{"path":"pipeline.yaml","type":"blob"}]}`)
+		case "/repos/owner/repo/git/trees/tekton-tree":
+			fmt.Fprint(w, `{"sha":"tekton-tree","tree":[
+				{"path":"pipeline.yaml","type":"blob","sha":"p"},
+				{"path":"tasks","type":"tree","sha":"tasks-tree"}]}`)
+		case "/repos/owner/repo/git/trees/tasks-tree":
+			fmt.Fprint(w, `{"sha":"tasks-tree","tree":[
+				{"path":"build.yaml","type":"blob","sha":"b"},
+				{"path":"test.yaml","type":"blob","sha":"t"}]}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.RequestURI())
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newGitHubFilesTestClient(t, server.URL)
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+
+	files, err := client.ListFiles(context.Background(), repo, "commit1", "deploy/.tekton")
+	require.NoError(t, err)
+	assert.Equal(t, []FileEntry{
+		{Path: "deploy/.tekton/pipeline.yaml", SHA: "p"},
+		{Path: "deploy/.tekton/tasks/build.yaml", SHA: "b"},
+		{Path: "deploy/.tekton/tasks/test.yaml", SHA: "t"},
+	}, files, "a truncated listing is replaced by walking the tree")
+}
+
+func TestGitHubClient_ListFilesIncompleteWalk(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.RequestURI() {
+		case "/repos/owner/repo/git/trees/commit1":
+			fmt.Fprint(w, `{"sha":"root","tree":[{"path":".tekton","type":"tree","sha":"tekton-tree"}]}`)
+		case "/repos/owner/repo/git/trees/tekton-tree?recursive=1":
+			fmt.Fprint(w, `{"sha":"tekton-tree","truncated":true,"tree":[]}`)
+		case "/repos/owner/repo/git/trees/tekton-tree":
+			fmt.Fprint(w, `{"sha":"tekton-tree","tree":[
+				{"path":"pipeline.yaml","type":"blob","sha":"p"},
+				{"path":"tasks","type":"tree","sha":"tasks-tree"}]}`)
+		case "/repos/owner/repo/git/trees/tasks-tree":
+			// Too many entries for GitHub to list in one response
+			fmt.Fprint(w, `{"sha":"tasks-tree","truncated":true,"tree":[{"path":"build.yaml","type":"blob","sha":"b"}]}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.RequestURI())
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newGitHubFilesTestClient(t, server.URL)
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+
+	files, err := client.ListFiles(context.Background(), repo, "commit1", ".tekton")
+	assert.Error(t, err, "a directory listed in part fails the walk")
+	assert.Nil(t, files)
+
+	// So does running out of requests before the walk is done
+	client.config.MaxPages = 1
+	files, err = client.ListFiles(context.Background(), repo, "commit1", ".tekton")
+	assert.True(t, IsIncompleteListing(err))
+	assert.Nil(t, files)
+}
+
+func TestGitHubClient_CheckDirectoryExists(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/contents/.tekton":
+			fmt.Fprint(w, `[{"name":"pipeline.yaml","path":".tekton/pipeline.yaml","type":"file"}]`)
+		case "/repos/owner/repo/contents/README.md":
+			fmt.Fprint(w, `{"name":"README.md","path":"README.md","type":"file"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newGitHubFilesTestClient(t, server.URL)
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+	ctx := context.Background()
+
+	exists, err := client.CheckDirectoryExists(ctx, repo, "commit1", ".tekton")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, "commit1", "README.md")
+	require.NoError(t, err)
+	assert.False(t, exists, "a file is not a directory")
+
+	exists, err = client.CheckDirectoryExists(ctx, repo, "commit1", "missing")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestGitLabClient_ListFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/projects/group%2Frepo/repository/tree", r.URL.EscapedPath())
+		query := r.URL.Query()
+		assert.Equal(t, ".tekton", query.Get("path"))
+		assert.Equal(t, "true", query.Get("recursive"))
+
+		if query.Get("page") == "1" {
+			w.Header().Set("X-Next-Page", "2")
+			fmt.Fprint(w, `[{"id":"pipeline-blob","path":".tekton/pipeline.yaml","type":"blob"},{"id":"tasks-tree","path":".tekton/tasks","type":"tree"}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id":"build-blob","path":".tekton/tasks/build.yaml","type":"blob"}]`)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	files, err := client.ListFiles(context.Background(), types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo"}, "commit1", ".tekton")
+	require.NoError(t, err)
+	assert.Equal(t, []FileEntry{
+		{Path: ".tekton/pipeline.yaml", SHA: "pipeline-blob"},
+		{Path: ".tekton/tasks/build.yaml", SHA: "build-blob"},
+	}, files, "GitLab reports blob SHAs as ids")
+}
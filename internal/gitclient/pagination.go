@@ -0,0 +1,82 @@
+package gitclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Pagination defaults used when ClientConfig leaves the values unset
+const (
+	DefaultPageSize = 100 // Maximum page size accepted by GitHub and GitLab
+	DefaultMaxPages = 50  // Safety cap to avoid unbounded listing loops
+)
+
+// pageSize returns the configured page size or the default
+func (c ClientConfig) pageSize() int {
+	if c.PerPage > 0 {
+		return c.PerPage
+	}
+	return DefaultPageSize
+}
+
+// maxPages returns the configured page cap or the default
+func (c ClientConfig) maxPages() int {
+	if c.MaxPages > 0 {
+		return c.MaxPages
+	}
+	return DefaultMaxPages
+}
+
+// IncompleteListingError reports a listing that stopped at the page cap before
+// reaching the last page. Clients return the items listed so far along with
+// it: they are current, but anything missing from them may still exist.
+type IncompleteListingError struct {
+	Provider string
+	Resource string // What was listed, e.g. "branches of owner/repo"
+	MaxPages int
+}
+
+func (e *IncompleteListingError) Error() string {
+	return fmt.Sprintf("listing %s on %s stopped at the %d page limit, results are incomplete",
+		e.Resource, e.Provider, e.MaxPages)
+}
+
+// IsIncompleteListing reports whether an error was caused by a listing cut short by the page cap
+func IsIncompleteListing(err error) bool {
+	var incompleteErr *IncompleteListingError
+	return errors.As(err, &incompleteErr)
+}
+
+// parseNextLink extracts the rel="next" URL from a RFC 8288 Link header
+// as returned by GitHub, e.g. `<https://api.github.com/...&page=2>; rel="next"`
+func parseNextLink(headers http.Header) string {
+	for _, link := range strings.Split(headers.Get("Link"), ",") {
+		segments := strings.Split(link, ";")
+		if len(segments) < 2 {
+			continue
+		}
+
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+
+		for _, param := range segments[1:] {
+			param = strings.TrimSpace(param)
+			if param == `rel="next"` || param == "rel=next" {
+				return strings.Trim(target, "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// appendQuery appends a raw query string to a URL that may already have one
+func appendQuery(rawURL, query string) string {
+	if strings.Contains(rawURL, "?") {
+		return rawURL + "&" + query
+	}
+	return rawURL + "?" + query
+}
@@ -0,0 +1,259 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRateLimiter records how often the client consults the limiter
+type countingRateLimiter struct {
+	mu      sync.Mutex
+	waits   int
+	updates int
+	last    RateLimitInfo
+}
+
+func (r *countingRateLimiter) Wait(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.waits++
+	return nil
+}
+
+func (r *countingRateLimiter) Allow() bool { return true }
+
+func (r *countingRateLimiter) GetLimit() RateLimitInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.last
+}
+
+func (r *countingRateLimiter) UpdateLimit(limit, remaining int, resetTime time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.updates++
+	r.last = RateLimitInfo{Limit: limit, Remaining: remaining, ResetTime: resetTime}
+}
+
+func newPaginationTestLogger(t *testing.T) *logger.Entry {
+	testLogger, err := logger.NewLogger(logger.Config{
+		Level:  "error",
+		Format: "json",
+		Output: "stderr",
+	})
+	require.NoError(t, err)
+	return testLogger.WithField("test", "pagination")
+}
+
+func TestParseNextLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   string
+	}{
+		{"empty", "", ""},
+		{
+			"next and last",
+			`<https://api.github.com/repositories/1/branches?page=2>; rel="next", <https://api.github.com/repositories/1/branches?page=5>; rel="last"`,
+			"https://api.github.com/repositories/1/branches?page=2",
+		},
+		{
+			"last page only has prev",
+			`<https://api.github.com/repositories/1/branches?page=4>; rel="prev", <https://api.github.com/repositories/1/branches?page=1>; rel="first"`,
+			"",
+		},
+		{"malformed", `https://example.com; rel="next"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			headers := http.Header{}
+			if tt.header != "" {
+				headers.Set("Link", tt.header)
+			}
+			assert.Equal(t, tt.want, parseNextLink(headers))
+		})
+	}
+}
+
+func TestParseNextPage(t *testing.T) {
+	headers := http.Header{}
+	assert.Equal(t, 0, parseNextPage(headers))
+
+	headers.Set("X-Next-Page", "3")
+	assert.Equal(t, 3, parseNextPage(headers))
+
+	headers.Set("X-Next-Page", "")
+	assert.Equal(t, 0, parseNextPage(headers))
+}
+
+func TestGitHubClient_GetBranches_Pagination(t *testing.T) {
+	const totalPages = 3
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/owner/repo/branches", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < totalPages {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/branches?per_page=2&page=%d>; rel="next"`, server.URL, page+1))
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(5000-page))
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		fmt.Fprintf(w, `[{"name":"b%d-1","commit":{"sha":"sha%d1"}},{"name":"b%d-2","commit":{"sha":"sha%d2"},"protected":true}]`, page, page, page, page)
+	}))
+	defer server.Close()
+
+	limiter := &countingRateLimiter{}
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.PerPage = 2
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, limiter, nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	branches, err := client.GetBranches(context.Background(), types.Repository{
+		Name: "repo",
+		URL:  "https://github.com/owner/repo",
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, branches, totalPages*2)
+	assert.Equal(t, "b1-1", branches[0].Name)
+	assert.Equal(t, "sha32", branches[5].CommitSHA)
+	assert.True(t, branches[5].Protected)
+
+	assert.Equal(t, totalPages, limiter.waits, "each page should wait on the shared limiter")
+	assert.Equal(t, totalPages, limiter.updates, "each page should update rate limit accounting")
+	assert.Equal(t, 5000-totalPages, limiter.GetLimit().Remaining)
+}
+
+func TestGitHubClient_GetBranches_MaxPages(t *testing.T) {
+	var requests int
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		// Always advertise another page
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/branches?page=%d>; rel="next"`, server.URL, requests+1))
+		fmt.Fprintf(w, `[{"name":"b%d","commit":{"sha":"sha%d"}}]`, requests, requests)
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.MaxPages = 2
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	branches, err := client.GetBranches(context.Background(), types.Repository{
+		Name: "repo",
+		URL:  "https://github.com/owner/repo",
+	})
+	require.Error(t, err)
+	assert.True(t, IsIncompleteListing(err))
+	assert.Contains(t, err.Error(), "branches of repo")
+
+	// The branches listed before the cap are still returned
+	assert.Len(t, branches, 2)
+	assert.Equal(t, 2, requests)
+}
+
+func TestGitLabClient_GetBranches_Pagination(t *testing.T) {
+	const totalPages = 3
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/group%2Fproject":
+			fmt.Fprint(w, `{"id":42,"path_with_namespace":"group/project"}`)
+		case "/projects/42/repository/branches":
+			assert.Equal(t, "2", r.URL.Query().Get("per_page"))
+			page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+			if page < totalPages {
+				w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+			} else {
+				w.Header().Set("X-Next-Page", "")
+			}
+			w.Header().Set("RateLimit-Limit", "600")
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(600-page))
+			w.Header().Set("RateLimit-ResetTime", time.Now().Add(time.Minute).UTC().Format(time.RFC3339))
+			fmt.Fprintf(w, `[{"name":"b%d-1","commit":{"id":"sha%d1"}},{"name":"b%d-2","commit":{"id":"sha%d2"}}]`, page, page, page, page)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	limiter := &countingRateLimiter{}
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.PerPage = 2
+	config.EnableFallback = false
+
+	client, err := NewGitLabClient(config, limiter, nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	branches, err := client.GetBranches(context.Background(), types.Repository{
+		Name: "project",
+		URL:  "https://gitlab.example.com/group/project",
+	})
+	require.NoError(t, err)
+
+	assert.Len(t, branches, totalPages*2)
+	assert.Equal(t, "b3-2", branches[5].Name)
+	assert.Equal(t, "sha32", branches[5].CommitSHA)
+
+	// One wait for the project lookup plus one per page
+	assert.Equal(t, totalPages+1, limiter.waits)
+	assert.Equal(t, totalPages, limiter.updates)
+	assert.Equal(t, 600-totalPages, limiter.GetLimit().Remaining)
+}
+
+func TestClientFactory_SetPagination(t *testing.T) {
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	factory.SetPagination(25, 4)
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+
+	client, err := factory.CreateClient(types.Repository{
+		Name:     "repo",
+		URL:      "https://github.com/owner/repo",
+		Provider: "github",
+	}, config)
+	require.NoError(t, err)
+
+	githubClient, ok := client.(*GitHubClient)
+	require.True(t, ok)
+	assert.Equal(t, 25, githubClient.config.pageSize())
+	assert.Equal(t, 4, githubClient.config.maxPages())
+
+	// Explicit client config wins over factory defaults
+	config.PerPage = 10
+	client, err = factory.CreateClient(types.Repository{
+		Name:     "repo",
+		URL:      "https://github.com/owner/repo",
+		Provider: "github",
+	}, config)
+	require.NoError(t, err)
+	assert.Equal(t, 10, client.(*GitHubClient).config.pageSize())
+}
@@ -0,0 +1,141 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubClient_PullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/pulls":
+			assert.Equal(t, "open", r.URL.Query().Get("state"))
+			fmt.Fprint(w, `[{
+				"number": 12, "title": "Add feature", "state": "open", "draft": true,
+				"html_url": "https://github.com/owner/repo/pull/12", "merged_at": null,
+				"head": {"sha": "head-sha", "ref": "feature"}, "base": {"ref": "main"},
+				"user": {"login": "octocat"}, "labels": [{"name": "ci"}, {"name": "needs, review"}]
+			}]`)
+		case "/repos/owner/repo/pulls/11":
+			fmt.Fprint(w, `{"number": 11, "state": "closed", "merged_at": "2024-01-01T00:00:00Z",
+				"head": {"sha": "old-sha", "ref": "fix"}, "base": {"ref": "main"}, "user": {"login": "octocat"}, "labels": []}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: "https://github.com/owner/repo"}
+	pulls, err := client.ListPullRequests(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, []types.PullRequest{{
+		Number:       12,
+		Title:        "Add feature",
+		State:        types.PullRequestStateOpen,
+		HeadSHA:      "head-sha",
+		SourceBranch: "feature",
+		TargetBranch: "main",
+		Author:       "octocat",
+		Labels:       []string{"ci", "needs, review"},
+		Draft:        true,
+		URL:          "https://github.com/owner/repo/pull/12",
+	}}, pulls)
+
+	merged, err := client.GetPullRequest(context.Background(), repo, 11)
+	require.NoError(t, err)
+	assert.Equal(t, types.PullRequestStateMerged, merged.State)
+
+	_, err = client.GetPullRequest(context.Background(), repo, 99)
+	var notFound *RepositoryNotFoundError
+	assert.ErrorAs(t, err, &notFound)
+}
+
+func TestGitLabClient_PullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/group%2Frepo", "/projects/group/repo":
+			fmt.Fprint(w, `{"id":42,"path_with_namespace":"group/repo"}`)
+		case "/projects/42/merge_requests":
+			assert.Equal(t, "opened", r.URL.Query().Get("state"))
+			fmt.Fprint(w, `[{"iid": 5, "title": "WIP", "state": "opened", "sha": "mr-sha",
+				"source_branch": "topic", "target_branch": "main", "labels": ["backend"],
+				"work_in_progress": true, "author": {"username": "dev"}}]`)
+		case "/projects/42/merge_requests/4":
+			fmt.Fprint(w, `{"iid": 4, "state": "closed", "sha": "x", "source_branch": "old", "target_branch": "main"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	repo := types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo"}
+	pulls, err := client.ListPullRequests(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, pulls, 1)
+	assert.Equal(t, 5, pulls[0].Number)
+	assert.Equal(t, "mr-sha", pulls[0].HeadSHA)
+	assert.Equal(t, "topic", pulls[0].SourceBranch)
+	assert.Equal(t, []string{"backend"}, pulls[0].Labels)
+	assert.True(t, pulls[0].Draft, "work_in_progress counts as draft")
+
+	closed, err := client.GetPullRequest(context.Background(), repo, 4)
+	require.NoError(t, err)
+	assert.Equal(t, types.PullRequestStateClosed, closed.State)
+	assert.Equal(t, []string{}, closed.Labels)
+}
+
+func TestBitbucketClient_PullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const repoAPI = "/2.0/repositories/workspace/repo"
+		switch r.URL.Path {
+		case repoAPI + "/pullrequests":
+			assert.Equal(t, "OPEN", r.URL.Query().Get("state"))
+			fmt.Fprint(w, `{"values": [{"id": 3, "title": "Change", "state": "OPEN",
+				"source": {"branch": {"name": "topic"}, "commit": {"hash": "abcdef123456"}},
+				"destination": {"branch": {"name": "main"}}, "author": {"nickname": "dev"},
+				"links": {"html": {"href": "https://bitbucket.org/workspace/repo/pull-requests/3"}}}]}`)
+		case repoAPI + "/pullrequests/2":
+			fmt.Fprint(w, `{"id": 2, "state": "DECLINED"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := newBitbucketTestClient(t, server.URL+"/2.0", "bot:app-password")
+	repo := types.Repository{Name: "repo", URL: "https://bitbucket.org/workspace/repo", Provider: "bitbucket"}
+
+	pulls, err := client.ListPullRequests(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, pulls, 1)
+	assert.Equal(t, "abcdef123456", pulls[0].HeadSHA)
+	assert.Equal(t, "main", pulls[0].TargetBranch)
+	assert.Equal(t, "https://bitbucket.org/workspace/repo/pull-requests/3", pulls[0].URL)
+
+	declined, err := client.GetPullRequest(context.Background(), repo, 2)
+	require.NoError(t, err)
+	assert.Equal(t, types.PullRequestStateClosed, declined.State)
+}
@@ -3,8 +3,10 @@ package gitclient
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/johnnynv/RepoSentry/pkg/types"
 	"golang.org/x/time/rate"
 )
 
@@ -31,9 +33,20 @@ type RateLimitInfo struct {
 	Provider  string    `json:"provider"`
 }
 
+// RateLimitStatus is the state of one shared rate limiter as reported by the factory
+type RateLimitStatus struct {
+	Provider   string    `json:"provider"`
+	Host       string    `json:"host,omitempty"`
+	Credential string    `json:"credential,omitempty"` // Token fingerprint or GitHub App ID, never the secret
+	Limit      int       `json:"limit"`
+	Remaining  int       `json:"remaining"`
+	ResetTime  time.Time `json:"reset_time"`
+}
+
 // GitHubRateLimiter implements rate limiting for GitHub API
 type GitHubRateLimiter struct {
 	limiter   *rate.Limiter
+	baseRate  rate.Limit // Configured rate, lowered while the remaining budget is small
 	mu        sync.RWMutex
 	limit     int
 	remaining int
@@ -44,8 +57,21 @@ type GitHubRateLimiter struct {
 func NewGitHubRateLimiter() *GitHubRateLimiter {
 	// GitHub API allows 5000 requests per hour for authenticated users
 	// We set it slightly lower for safety: 4000 requests/hour = ~1.11 requests/second
+	return newGitHubRateLimiter(rate.Limit(1.0), 10) // 1 req/sec, burst of 10
+}
+
+// NewGitHubRateLimiterFromConfig creates a GitHub rate limiter from rate_limit.github
+func NewGitHubRateLimiterFromConfig(cfg types.GitHubRateLimit) *GitHubRateLimiter {
+	if cfg.RequestsPerHour <= 0 || cfg.Burst <= 0 {
+		return NewGitHubRateLimiter()
+	}
+	return newGitHubRateLimiter(rate.Limit(float64(cfg.RequestsPerHour)/3600), cfg.Burst)
+}
+
+func newGitHubRateLimiter(perSecond rate.Limit, burst int) *GitHubRateLimiter {
 	return &GitHubRateLimiter{
-		limiter:   rate.NewLimiter(rate.Limit(1.0), 10), // 1 req/sec, burst of 10
+		limiter:   rate.NewLimiter(perSecond, burst),
+		baseRate:  perSecond,
 		limit:     5000,
 		remaining: 5000,
 		resetTime: time.Now().Add(time.Hour),
@@ -54,6 +80,7 @@ func NewGitHubRateLimiter() *GitHubRateLimiter {
 
 // Wait blocks until the rate limiter allows the request
 func (r *GitHubRateLimiter) Wait(ctx context.Context) error {
+	countRequest(ctx)
 	return r.limiter.Wait(ctx)
 }
 
@@ -87,19 +114,20 @@ func (r *GitHubRateLimiter) UpdateLimit(limit, remaining int, resetTime time.Tim
 	// Adjust limiter based on remaining requests
 	if remaining < 100 && time.Until(resetTime) > 10*time.Minute {
 		// Slow down significantly if we're running low
-		r.limiter.SetLimit(rate.Limit(0.1)) // 1 request per 10 seconds
+		r.limiter.SetLimit(r.baseRate / 10) // 1 request per 10 seconds at the default rate
 	} else if remaining < 1000 {
 		// Slow down moderately
-		r.limiter.SetLimit(rate.Limit(0.5)) // 1 request per 2 seconds
+		r.limiter.SetLimit(r.baseRate / 2) // 1 request per 2 seconds at the default rate
 	} else {
 		// Normal rate
-		r.limiter.SetLimit(rate.Limit(1.0)) // 1 request per second
+		r.limiter.SetLimit(r.baseRate)
 	}
 }
 
 // GitLabRateLimiter implements rate limiting for GitLab API
 type GitLabRateLimiter struct {
 	limiter   *rate.Limiter
+	baseRate  rate.Limit // Configured rate, lowered while the remaining budget is small
 	mu        sync.RWMutex
 	limit     int
 	remaining int
@@ -110,8 +138,21 @@ type GitLabRateLimiter struct {
 func NewGitLabRateLimiter() *GitLabRateLimiter {
 	// GitLab API allows 2000 requests per minute by default
 	// We set it lower for safety: 8 requests/second with burst of 5
+	return newGitLabRateLimiter(rate.Limit(8.0), 5)
+}
+
+// NewGitLabRateLimiterFromConfig creates a GitLab rate limiter from rate_limit.gitlab
+func NewGitLabRateLimiterFromConfig(cfg types.GitLabRateLimit) *GitLabRateLimiter {
+	if cfg.RequestsPerSecond <= 0 || cfg.Burst <= 0 {
+		return NewGitLabRateLimiter()
+	}
+	return newGitLabRateLimiter(rate.Limit(cfg.RequestsPerSecond), cfg.Burst)
+}
+
+func newGitLabRateLimiter(perSecond rate.Limit, burst int) *GitLabRateLimiter {
 	return &GitLabRateLimiter{
-		limiter:   rate.NewLimiter(rate.Limit(8.0), 5),
+		limiter:   rate.NewLimiter(perSecond, burst),
+		baseRate:  perSecond,
 		limit:     2000,
 		remaining: 2000,
 		resetTime: time.Now().Add(time.Minute),
@@ -120,6 +161,7 @@ func NewGitLabRateLimiter() *GitLabRateLimiter {
 
 // Wait blocks until the rate limiter allows the request
 func (r *GitLabRateLimiter) Wait(ctx context.Context) error {
+	countRequest(ctx)
 	return r.limiter.Wait(ctx)
 }
 
@@ -153,16 +195,138 @@ func (r *GitLabRateLimiter) UpdateLimit(limit, remaining int, resetTime time.Tim
 	// Adjust limiter based on remaining requests
 	if remaining < 50 && time.Until(resetTime) > 30*time.Second {
 		// Slow down significantly if we're running low
-		r.limiter.SetLimit(rate.Limit(0.5)) // 1 request per 2 seconds
+		r.limiter.SetLimit(r.baseRate / 16) // 1 request per 2 seconds at the default rate
+	} else if remaining < 200 {
+		// Slow down moderately
+		r.limiter.SetLimit(r.baseRate / 4) // 2 requests per second at the default rate
+	} else {
+		// Normal rate
+		r.limiter.SetLimit(r.baseRate)
+	}
+}
+
+// BitbucketRateLimiter implements rate limiting for Bitbucket Cloud and Data Center APIs
+type BitbucketRateLimiter struct {
+	limiter   *rate.Limiter
+	mu        sync.RWMutex
+	limit     int
+	remaining int
+	resetTime time.Time
+}
+
+// NewBitbucketRateLimiter creates a Bitbucket rate limiter
+func NewBitbucketRateLimiter() *BitbucketRateLimiter {
+	// Bitbucket Cloud allows 1000 repository data requests per hour (rolling window)
+	// We stay below that: ~0.25 requests/second with burst of 10
+	return &BitbucketRateLimiter{
+		limiter:   rate.NewLimiter(rate.Limit(0.25), 10),
+		limit:     1000,
+		remaining: 1000,
+		resetTime: time.Now().Add(time.Hour),
+	}
+}
+
+// Wait blocks until the rate limiter allows the request
+func (r *BitbucketRateLimiter) Wait(ctx context.Context) error {
+	countRequest(ctx)
+	return r.limiter.Wait(ctx)
+}
+
+// Allow returns true if the request can proceed immediately
+func (r *BitbucketRateLimiter) Allow() bool {
+	return r.limiter.Allow()
+}
+
+// GetLimit returns current rate limit info
+func (r *BitbucketRateLimiter) GetLimit() RateLimitInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return RateLimitInfo{
+		Limit:     r.limit,
+		Remaining: r.remaining,
+		ResetTime: r.resetTime,
+		Provider:  "bitbucket",
+	}
+}
+
+// UpdateLimit updates the rate limit based on API response
+func (r *BitbucketRateLimiter) UpdateLimit(limit, remaining int, resetTime time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limit = limit
+	r.remaining = remaining
+	r.resetTime = resetTime
+
+	// Adjust limiter based on remaining requests
+	if remaining < 50 && time.Until(resetTime) > 10*time.Minute {
+		// Slow down significantly if we're running low
+		r.limiter.SetLimit(rate.Limit(0.05)) // 1 request per 20 seconds
 	} else if remaining < 200 {
 		// Slow down moderately
-		r.limiter.SetLimit(rate.Limit(2.0)) // 2 requests per second
+		r.limiter.SetLimit(rate.Limit(0.1)) // 1 request per 10 seconds
 	} else {
 		// Normal rate
-		r.limiter.SetLimit(rate.Limit(8.0)) // 8 requests per second
+		r.limiter.SetLimit(rate.Limit(0.25)) // 1 request per 4 seconds
+	}
+}
+
+// GiteaRateLimiter implements rate limiting for Gitea and Forgejo APIs
+type GiteaRateLimiter struct {
+	limiter   *rate.Limiter
+	mu        sync.RWMutex
+	limit     int
+	remaining int
+	resetTime time.Time
+}
+
+// NewGiteaRateLimiter creates a Gitea rate limiter
+func NewGiteaRateLimiter() *GiteaRateLimiter {
+	// Gitea has no API rate limit by default, but instances are usually small
+	// self-hosted servers: 5 requests/second with burst of 10
+	return &GiteaRateLimiter{
+		limiter:   rate.NewLimiter(rate.Limit(5.0), 10),
+		limit:     18000,
+		remaining: 18000,
+		resetTime: time.Now().Add(time.Hour),
 	}
 }
 
+// Wait blocks until the rate limiter allows the request
+func (r *GiteaRateLimiter) Wait(ctx context.Context) error {
+	countRequest(ctx)
+	return r.limiter.Wait(ctx)
+}
+
+// Allow returns true if the request can proceed immediately
+func (r *GiteaRateLimiter) Allow() bool {
+	return r.limiter.Allow()
+}
+
+// GetLimit returns current rate limit info
+func (r *GiteaRateLimiter) GetLimit() RateLimitInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return RateLimitInfo{
+		Limit:     r.limit,
+		Remaining: r.remaining,
+		ResetTime: r.resetTime,
+		Provider:  "gitea",
+	}
+}
+
+// UpdateLimit updates the rate limit based on API response
+func (r *GiteaRateLimiter) UpdateLimit(limit, remaining int, resetTime time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.limit = limit
+	r.remaining = remaining
+	r.resetTime = resetTime
+}
+
 // NoOpRateLimiter is a no-operation rate limiter for testing
 type NoOpRateLimiter struct{}
 
@@ -173,6 +337,7 @@ func NewNoOpRateLimiter() *NoOpRateLimiter {
 
 // Wait does nothing for no-op limiter
 func (r *NoOpRateLimiter) Wait(ctx context.Context) error {
+	countRequest(ctx)
 	return nil
 }
 
@@ -195,3 +360,29 @@ func (r *NoOpRateLimiter) GetLimit() RateLimitInfo {
 func (r *NoOpRateLimiter) UpdateLimit(limit, remaining int, resetTime time.Time) {
 	// No-op
 }
+
+// RequestCounter counts the API requests made with a context
+type RequestCounter struct {
+	count int64
+}
+
+// Count returns the number of requests counted so far
+func (c *RequestCounter) Count() int64 {
+	return atomic.LoadInt64(&c.count)
+}
+
+type requestCounterKey struct{}
+
+// WithRequestCounter returns a context whose API requests are counted. Every
+// request waits on its provider's rate limiter, which does the counting.
+func WithRequestCounter(ctx context.Context) (context.Context, *RequestCounter) {
+	counter := &RequestCounter{}
+	return context.WithValue(ctx, requestCounterKey{}, counter), counter
+}
+
+// countRequest adds a request to the context's counter, if it has one
+func countRequest(ctx context.Context) {
+	if counter, ok := ctx.Value(requestCounterKey{}).(*RequestCounter); ok {
+		atomic.AddInt64(&counter.count, 1)
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"testing"
 	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"golang.org/x/time/rate"
 )
 
 func TestGitHubRateLimiter(t *testing.T) {
@@ -209,3 +212,52 @@ func TestRateLimiterContextCancellation(t *testing.T) {
 		t.Errorf("Expected context.Canceled, got %v", err)
 	}
 }
+
+func TestRateLimitersFromConfig(t *testing.T) {
+	github := NewGitHubRateLimiterFromConfig(types.GitHubRateLimit{RequestsPerHour: 1800, Burst: 4})
+	if github.limiter.Limit() != rate.Limit(0.5) {
+		t.Errorf("Expected GitHub rate 0.5/s, got %v", github.limiter.Limit())
+	}
+	if github.limiter.Burst() != 4 {
+		t.Errorf("Expected GitHub burst 4, got %d", github.limiter.Burst())
+	}
+
+	// Adaptive slowdown is relative to the configured rate
+	github.UpdateLimit(5000, 500, time.Now().Add(time.Hour))
+	if github.limiter.Limit() != rate.Limit(0.25) {
+		t.Errorf("Expected GitHub rate 0.25/s while low, got %v", github.limiter.Limit())
+	}
+	github.UpdateLimit(5000, 4000, time.Now().Add(time.Hour))
+	if github.limiter.Limit() != rate.Limit(0.5) {
+		t.Errorf("Expected GitHub rate to recover to 0.5/s, got %v", github.limiter.Limit())
+	}
+
+	gitlab := NewGitLabRateLimiterFromConfig(types.GitLabRateLimit{RequestsPerSecond: 4, Burst: 2})
+	if gitlab.limiter.Limit() != rate.Limit(4) || gitlab.limiter.Burst() != 2 {
+		t.Errorf("Expected GitLab 4/s burst 2, got %v burst %d", gitlab.limiter.Limit(), gitlab.limiter.Burst())
+	}
+
+	// Unset values keep the defaults
+	if NewGitHubRateLimiterFromConfig(types.GitHubRateLimit{}).limiter.Limit() != rate.Limit(1.0) {
+		t.Error("Expected default GitHub rate for empty config")
+	}
+}
+
+func TestWithRequestCounter(t *testing.T) {
+	ctx, counter := WithRequestCounter(context.Background())
+
+	limiter := NewGitLabRateLimiter()
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(ctx); err != nil {
+			t.Fatalf("Wait() error = %v", err)
+		}
+	}
+	// Requests made without the counting context are not counted
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	if counter.Count() != 3 {
+		t.Errorf("Expected 3 counted requests, got %d", counter.Count())
+	}
+}
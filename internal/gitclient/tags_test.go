@@ -0,0 +1,91 @@
+package gitclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLsRemoteTags(t *testing.T) {
+	output := "aaa111\trefs/tags/v1.0.0\n" +
+		"bbb222\trefs/tags/v1.1.0\n" +
+		"ccc333\trefs/tags/v1.1.0^{}\n" +
+		"ddd444\trefs/heads/main\n"
+
+	tags := parseLsRemoteTags(output)
+	assert.Equal(t, []types.Tag{
+		{Name: "v1.0.0", CommitSHA: "aaa111"},
+		{Name: "v1.1.0", CommitSHA: "ccc333"}, // Annotated tags resolve to the peeled commit
+	}, tags)
+
+	assert.Empty(t, parseLsRemoteTags(""))
+}
+
+func TestGitHubClient_GetTags(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/owner/repo/tags", r.URL.Path)
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/owner/repo/tags?per_page=1&page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"name":"v2.0.0","commit":{"sha":"sha2"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"name":"v1.0.0","commit":{"sha":"sha1"}}]`)
+	}))
+	defer server.Close()
+
+	limiter := &countingRateLimiter{}
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.PerPage = 1
+	config.EnableFallback = false
+
+	client, err := NewGitHubClient(config, limiter, nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	tags, err := client.GetTags(context.Background(), types.Repository{Name: "repo", URL: "https://github.com/owner/repo"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.Tag{{Name: "v2.0.0", CommitSHA: "sha2"}, {Name: "v1.0.0", CommitSHA: "sha1"}}, tags)
+	assert.Equal(t, 2, limiter.waits)
+}
+
+func TestGitLabClient_GetTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/projects/group%2Frepo", "/projects/group/repo":
+			fmt.Fprint(w, `{"id":42,"path_with_namespace":"group/repo"}`)
+		case "/projects/42/repository/tags":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+				fmt.Fprint(w, `[{"name":"v2.0.0","commit":{"id":"sha2"}}]`)
+				return
+			}
+			fmt.Fprint(w, `[{"name":"v1.0.0","commit":{"id":"sha1"}}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := GetDefaultConfig()
+	config.Token = "test-token"
+	config.BaseURL = server.URL
+	config.EnableFallback = false
+
+	client, err := NewGitLabClient(config, NewNoOpRateLimiter(), nil, newPaginationTestLogger(t))
+	require.NoError(t, err)
+
+	tags, err := client.GetTags(context.Background(), types.Repository{Name: "repo", URL: "https://gitlab.com/group/repo"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []types.Tag{{Name: "v2.0.0", CommitSHA: "sha2"}, {Name: "v1.0.0", CommitSHA: "sha1"}}, tags)
+}
@@ -0,0 +1,60 @@
+package gitclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
+)
+
+// Transports are shared between clients with the same settings so connections
+// are pooled across polling cycles instead of being rebuilt for every client
+var (
+	transportsMu sync.Mutex
+	transports   = make(map[string]*http.Transport)
+)
+
+// newHTTPClient creates the HTTP client used for a provider API. Without proxy or
+// TLS settings it uses http.DefaultTransport.
+func newHTTPClient(config ClientConfig) (*http.Client, error) {
+	httpClient := &http.Client{
+		Timeout: config.Timeout,
+	}
+	if config.HTTP.IsZero() {
+		return httpClient, nil
+	}
+
+	transport, err := sharedTransport(config.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HTTP client configuration: %w", err)
+	}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
+
+// sharedTransport returns the transport for a set of HTTP settings, creating it
+// on first use
+func sharedTransport(cfg types.HTTPClientConfig) (*http.Transport, error) {
+	encoded, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	key := string(encoded)
+
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	if transport, ok := transports[key]; ok {
+		return transport, nil
+	}
+
+	transport, err := utils.NewHTTPTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	transports[key] = transport
+	return transport, nil
+}
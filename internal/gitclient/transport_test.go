@@ -0,0 +1,69 @@
+package gitclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientFactory_HTTPConfig(t *testing.T) {
+	// Plain HTTP proxies receive the absolute request URL
+	var proxiedHosts []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHosts = append(proxiedHosts, r.URL.Host)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"name":"main","commit":{"sha":"abc123"},"protected":false}]`))
+	}))
+	defer proxy.Close()
+
+	factory := NewClientFactory(newPaginationTestLogger(t))
+	factory.SetHTTPConfig(types.HTTPClientConfig{ProxyURL: "http://unreachable.invalid:3128", TLSMinVersion: "1.2"})
+
+	// The repository overrides the global proxy, keeping the global TLS version
+	repo := types.Repository{
+		Name:     "enterprise",
+		URL:      "http://ghe.example.com/owner/repo",
+		Provider: "github",
+		HTTP:     types.HTTPClientConfig{ProxyURL: proxy.URL},
+	}
+	config := GetDefaultConfig()
+	config.Token = "token"
+	config.BaseURL = "http://ghe.example.com/api/v3"
+	config.EnableFallback = false
+
+	client, err := factory.CreateClient(repo, config)
+	require.NoError(t, err)
+
+	githubClient := client.(*GitHubClient)
+	assert.Equal(t, "1.2", githubClient.config.HTTP.TLSMinVersion)
+	assert.Equal(t, proxy.URL, githubClient.config.HTTP.ProxyURL)
+
+	branches, err := client.GetBranches(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, branches, 1)
+	assert.Equal(t, "main", branches[0].Name)
+	assert.Contains(t, proxiedHosts, "ghe.example.com")
+}
+
+func TestNewHTTPClient_SharesTransports(t *testing.T) {
+	config := GetDefaultConfig()
+	plain, err := newHTTPClient(config)
+	require.NoError(t, err)
+	assert.Nil(t, plain.Transport, "no settings keeps http.DefaultTransport")
+
+	config.HTTP = types.HTTPClientConfig{NoProxy: []string{"internal.example.com"}}
+	first, err := newHTTPClient(config)
+	require.NoError(t, err)
+	second, err := newHTTPClient(config)
+	require.NoError(t, err)
+	assert.Same(t, first.Transport, second.Transport)
+
+	config.HTTP = types.HTTPClientConfig{TLSMinVersion: "2.0"}
+	_, err = newHTTPClient(config)
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package poller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestScheduler_Backoff(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.MaxBackoff = time.Hour
+	scheduler := newTestScheduler(config, 0)
+
+	for failures, expected := range []time.Duration{
+		5 * time.Minute, 10 * time.Minute, 20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour,
+	} {
+		assert.Equal(t, expected, scheduler.backoff(5*time.Minute, failures), "failures %d", failures)
+	}
+
+	// Repositories polled less often than the cap keep their interval
+	assert.Equal(t, 2*time.Hour, scheduler.backoff(2*time.Hour, 3))
+}
+
+func TestScheduler_FailingRepository(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Jitter = 0
+	config.MaxBackoff = time.Hour
+	config.QuarantineAfter = 3
+	scheduler := newTestScheduler(config, 0)
+
+	repo := types.Repository{Name: "flaky", Enabled: true, PollingInterval: 5 * time.Minute}
+	require.NoError(t, scheduler.Schedule(repo))
+	scheduledRepo := scheduler.repositories["flaky"]
+
+	// Each failure doubles the wait before the next poll
+	before := time.Now()
+	scheduler.RecordPollResult("flaky", &gitclient.NetworkError{Provider: "github", Err: assert.AnError})
+	assert.Equal(t, 1, scheduledRepo.ConsecutiveFailures)
+	assert.Equal(t, gitclient.FailureNetwork, scheduledRepo.FailureReason)
+	assert.WithinDuration(t, before.Add(10*time.Minute), scheduledRepo.NextPollTime, time.Second)
+
+	// Rate limits are the provider's problem and do not count against the repository
+	scheduler.RecordPollResult("flaky", &gitclient.RateLimitExceededError{Provider: "github"})
+	assert.Equal(t, 1, scheduledRepo.ConsecutiveFailures)
+	assert.Equal(t, gitclient.FailureRateLimit, scheduledRepo.FailureReason)
+
+	scheduler.RecordPollResult("flaky", &gitclient.NetworkError{Provider: "github", Err: assert.AnError})
+	assert.WithinDuration(t, before.Add(20*time.Minute), scheduledRepo.NextPollTime, time.Second)
+
+	// The third failure in a row quarantines the repository
+	scheduler.RecordPollResult("flaky", &gitclient.AuthenticationError{Provider: "github"})
+	assert.True(t, scheduledRepo.Quarantined)
+	assert.Equal(t, gitclient.FailureAuth, scheduledRepo.FailureReason)
+	assert.False(t, scheduledRepo.QuarantinedAt.IsZero())
+	_, ok := scheduler.NextDue()
+	assert.False(t, ok, "quarantined repositories are not queued")
+
+	// Re-enabling does not lift the quarantine
+	require.NoError(t, scheduler.DisableRepository("flaky"))
+	require.NoError(t, scheduler.EnableRepository("flaky"))
+	_, ok = scheduler.NextDue()
+	assert.False(t, ok)
+
+	// Un-quarantining polls the repository straight away and starts its backoff
+	// afresh; the last error is kept until a poll succeeds
+	require.NoError(t, scheduler.Unquarantine("flaky"))
+	assert.False(t, scheduledRepo.Quarantined)
+	assert.Zero(t, scheduledRepo.ConsecutiveFailures)
+	assert.NotEmpty(t, scheduledRepo.LastError)
+	assert.Equal(t, []string{"flaky"}, repoNames(scheduler.DuePolls(time.Now())))
+
+	scheduler.RecordPollResult("flaky", nil)
+	assert.Zero(t, scheduledRepo.ConsecutiveFailures)
+	assert.Empty(t, scheduledRepo.LastError)
+	assert.Empty(t, scheduledRepo.FailureReason)
+	assert.False(t, scheduledRepo.LastSuccessTime.IsZero())
+
+	assert.Error(t, scheduler.Unquarantine("unknown"))
+}
+
+func TestScheduler_SuccessLiftsQuarantine(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Jitter = 0
+	config.QuarantineAfter = 1
+	scheduler := newTestScheduler(config, 0)
+
+	require.NoError(t, scheduler.Schedule(types.Repository{Name: "moved", Enabled: true}))
+	scheduler.RecordPollResult("moved", &gitclient.RepositoryNotFoundError{Repository: "moved"})
+	require.True(t, scheduler.repositories["moved"].Quarantined)
+	assert.Equal(t, gitclient.FailureNotFound, scheduler.repositories["moved"].FailureReason)
+
+	// A successful poll, such as one triggered by hand, shows the problem is fixed
+	before := time.Now()
+	scheduler.RecordPollResult("moved", nil)
+	assert.False(t, scheduler.repositories["moved"].Quarantined)
+	next, ok := scheduler.NextDue()
+	require.True(t, ok)
+	assert.WithinDuration(t, before.Add(config.Interval), next, time.Second)
+}
@@ -0,0 +1,248 @@
+package poller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
+)
+
+// maxWindowSteps bounds how many back-to-back windows are followed when
+// looking for the end of a blackout or the next allowed poll time
+const maxWindowSteps = 100
+
+// BlackoutState describes the blackout window a repository is in
+type BlackoutState struct {
+	Window string    `json:"window"`
+	Action string    `json:"action"` // queue or drop
+	Ends   time.Time `json:"ends"`
+}
+
+// pollPlan is a repository's compiled schedule and blackout windows
+type pollPlan struct {
+	schedule     *utils.CronSchedule // Nil polls every interval
+	scheduleSpec string              // Cron expression of schedule, for status output
+	windows      []blackoutWindow
+	action       string
+}
+
+// blackoutWindow is a compiled types.BlackoutWindow
+type blackoutWindow struct {
+	name       string
+	start, end time.Time           // Fixed window
+	cron       *utils.CronSchedule // Recurring window starts
+	duration   time.Duration
+}
+
+// compilePollPlan merges a repository's schedule and blackout settings with
+// the global ones. The repository's schedule and action replace the global
+// ones; its blackout windows are added to the global windows.
+func compilePollPlan(config PollerConfig, repo types.Repository) (*pollPlan, error) {
+	plan := &pollPlan{action: types.BlackoutActionQueue}
+
+	schedule := config.Schedule
+	if repo.Schedule != nil {
+		schedule = repo.Schedule
+	}
+	if schedule != nil && schedule.Cron != "" {
+		cron, err := parseCronInZone(schedule.Cron, schedule.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule: %w", err)
+		}
+		if cron.Next(time.Now()).IsZero() {
+			return nil, fmt.Errorf("invalid schedule: %q never matches", schedule.Cron)
+		}
+		plan.schedule = cron
+		plan.scheduleSpec = schedule.Cron
+	}
+
+	windows := append(append([]types.BlackoutWindow{}, config.BlackoutWindows...), repo.BlackoutWindows...)
+	for i, window := range windows {
+		compiled, err := compileBlackoutWindow(window)
+		if err != nil {
+			name := window.Name
+			if name == "" {
+				name = fmt.Sprintf("#%d", i)
+			}
+			return nil, fmt.Errorf("invalid blackout window %s: %w", name, err)
+		}
+		plan.windows = append(plan.windows, compiled)
+	}
+
+	if config.BlackoutAction != "" {
+		plan.action = config.BlackoutAction
+	}
+	if repo.BlackoutAction != "" {
+		plan.action = repo.BlackoutAction
+	}
+	if plan.action != types.BlackoutActionQueue && plan.action != types.BlackoutActionDrop {
+		return nil, fmt.Errorf("invalid blackout action %q, must be one of: queue, drop", plan.action)
+	}
+
+	return plan, nil
+}
+
+// compileBlackoutWindow checks a blackout window and parses its cron expression
+func compileBlackoutWindow(window types.BlackoutWindow) (blackoutWindow, error) {
+	compiled := blackoutWindow{name: window.Name}
+
+	if window.Cron != "" {
+		if !window.Start.IsZero() || !window.End.IsZero() {
+			return compiled, fmt.Errorf("set either cron and duration or start and end, not both")
+		}
+		if window.Duration <= 0 {
+			return compiled, fmt.Errorf("recurring windows need a positive duration")
+		}
+		cron, err := parseCronInZone(window.Cron, window.Timezone)
+		if err != nil {
+			return compiled, err
+		}
+		compiled.cron = cron
+		compiled.duration = window.Duration
+	} else {
+		if window.Start.IsZero() || window.End.IsZero() {
+			return compiled, fmt.Errorf("fixed windows need both start and end")
+		}
+		if !window.End.After(window.Start) {
+			return compiled, fmt.Errorf("end must be after start")
+		}
+		compiled.start, compiled.end = window.Start, window.End
+	}
+
+	if compiled.name == "" {
+		compiled.name = window.Cron
+		if compiled.cron == nil {
+			compiled.name = window.Start.Format(time.RFC3339)
+		}
+	}
+	return compiled, nil
+}
+
+// parseCronInZone parses a cron expression evaluated in an IANA time zone
+// (UTC when empty)
+func parseCronInZone(expr, timezone string) (*utils.CronSchedule, error) {
+	location := time.UTC
+	if timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+		}
+		location = loaded
+	}
+	return utils.ParseCron(expr, location)
+}
+
+// activeUntil returns when the window ends if it is in effect at t
+func (w blackoutWindow) activeUntil(t time.Time) (time.Time, bool) {
+	if w.cron == nil {
+		if !t.Before(w.start) && t.Before(w.end) {
+			return w.end, true
+		}
+		return time.Time{}, false
+	}
+
+	// The window is in effect if it started within the last duration; when
+	// occurrences overlap, the latest one to start ends last
+	start := w.cron.Next(t.Add(-w.duration))
+	if start.IsZero() || start.After(t) {
+		return time.Time{}, false
+	}
+	for step := 0; step < maxWindowSteps; step++ {
+		next := w.cron.Next(start)
+		if next.IsZero() || next.After(t) {
+			break
+		}
+		start = next
+	}
+	return start.Add(w.duration), true
+}
+
+// blackout returns the blackout in effect at t. Windows that overlap or follow
+// on from each other count as one blackout ending when the last of them does.
+func (p *pollPlan) blackout(t time.Time) (BlackoutState, bool) {
+	var state BlackoutState
+	found := false
+
+	for step := 0; step < maxWindowSteps; step++ {
+		at := t
+		if found {
+			at = state.Ends
+		}
+
+		extended := false
+		for _, window := range p.windows {
+			ends, ok := window.activeUntil(at)
+			if ok && (!found || ends.After(state.Ends)) {
+				if !found {
+					state.Window = window.name
+				}
+				state.Ends = ends
+				found = true
+				extended = true
+			}
+		}
+		if !extended {
+			break
+		}
+	}
+
+	if !found {
+		return BlackoutState{}, false
+	}
+	state.Action = p.action
+	return state, true
+}
+
+// release moves a poll time out of queueing blackouts to the end of the
+// window (or the first cron time after it), where the changes made during
+// the blackout are picked up. Dropping blackouts don't stop polling.
+func (p *pollPlan) release(t time.Time) time.Time {
+	if p.action != types.BlackoutActionQueue {
+		return t
+	}
+	for step := 0; step < maxWindowSteps; step++ {
+		state, ok := p.blackout(t)
+		if !ok {
+			return t
+		}
+		t = state.Ends
+		if p.schedule != nil {
+			t = p.schedule.Next(t.Add(-time.Nanosecond))
+		}
+	}
+	return t
+}
+
+// scheduleInterval estimates the gap between a cron schedule's polls, which
+// budget planning uses in place of the polling interval
+func (p *pollPlan) scheduleInterval(now time.Time) time.Duration {
+	first := p.schedule.Next(now)
+	second := p.schedule.Next(first)
+	if first.IsZero() || second.IsZero() {
+		return 0
+	}
+	return second.Sub(first)
+}
+
+// suppressForBlackout marks events detected during a dropping blackout as
+// suppressed, recording the window in their metadata, so none are dispatched
+func (p *PollerImpl) suppressForBlackout(repo types.Repository, events []types.Event, state BlackoutState) {
+	for i := range events {
+		event := &events[i]
+		event.Status = types.EventStatusSuppressed
+		if event.Metadata == nil {
+			event.Metadata = make(map[string]string)
+		}
+		event.Metadata["blackout"] = state.Window
+	}
+
+	p.logger.WithFields(logger.Fields{
+		"operation":   "poll_repository",
+		"repository":  repo.Name,
+		"window":      state.Window,
+		"ends":        state.Ends.Format(time.RFC3339),
+		"event_count": len(events),
+	}).Info("Dropped events detected during blackout window")
+}
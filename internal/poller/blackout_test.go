@@ -0,0 +1,205 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestPollPlan_Blackout(t *testing.T) {
+	// 2024-03-01 is a Friday
+	freezeStart := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	config := GetDefaultPollerConfig()
+	config.BlackoutWindows = []types.BlackoutWindow{
+		{Name: "release-freeze", Start: freezeStart, End: freezeStart.Add(6 * time.Hour)},
+	}
+	repo := types.Repository{
+		Name: "repo",
+		BlackoutWindows: []types.BlackoutWindow{
+			// Nightly maintenance from 22:00 to 06:00 at UTC+8
+			{Name: "maintenance", Cron: "0 22 * * *", Duration: 8 * time.Hour, Timezone: "Asia/Shanghai"},
+		},
+	}
+
+	plan, err := compilePollPlan(config, repo)
+	require.NoError(t, err)
+	assert.Len(t, plan.windows, 2, "repository windows are added to the global windows")
+	assert.Equal(t, types.BlackoutActionQueue, plan.action)
+
+	_, ok := plan.blackout(freezeStart.Add(-time.Minute))
+	assert.False(t, ok)
+
+	// 22:00 at UTC+8 is 14:00 UTC, so the maintenance window starts before the
+	// freeze ends and the blackout lasts until it ends at 22:00 UTC
+	maintenanceEnd := time.Date(2024, 3, 1, 22, 0, 0, 0, time.UTC)
+	state, ok := plan.blackout(freezeStart.Add(time.Hour))
+	require.True(t, ok)
+	assert.Equal(t, "release-freeze", state.Window)
+	assert.True(t, maintenanceEnd.Equal(state.Ends), "blackout ends at %v", state.Ends)
+
+	state, ok = plan.blackout(maintenanceEnd.Add(-time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, "maintenance", state.Window)
+	_, ok = plan.blackout(maintenanceEnd)
+	assert.False(t, ok)
+
+	// Queued polls are released when the blackout ends
+	assert.True(t, maintenanceEnd.Equal(plan.release(freezeStart.Add(time.Hour))))
+	assert.Equal(t, freezeStart.Add(-time.Minute), plan.release(freezeStart.Add(-time.Minute)))
+}
+
+func TestPollPlan_Settings(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Schedule = &types.PollSchedule{Cron: "*/10 * * * *"}
+	config.BlackoutAction = types.BlackoutActionDrop
+
+	plan, err := compilePollPlan(config, types.Repository{Name: "global"})
+	require.NoError(t, err)
+	assert.Equal(t, "*/10 * * * *", plan.scheduleSpec)
+	assert.Equal(t, types.BlackoutActionDrop, plan.action)
+
+	// Repository settings replace the global ones
+	plan, err = compilePollPlan(config, types.Repository{
+		Name:           "own",
+		Schedule:       &types.PollSchedule{Cron: "0 9-17 * * MON-FRI", Timezone: "Europe/Berlin"},
+		BlackoutAction: types.BlackoutActionQueue,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0 9-17 * * MON-FRI", plan.scheduleSpec)
+	assert.Equal(t, "Europe/Berlin", plan.schedule.Location().String())
+	assert.Equal(t, types.BlackoutActionQueue, plan.action)
+
+	now := time.Now()
+	invalid := []types.Repository{
+		{Name: "bad-cron", Schedule: &types.PollSchedule{Cron: "every day"}},
+		{Name: "bad-timezone", Schedule: &types.PollSchedule{Cron: "@daily", Timezone: "Mars/Olympus"}},
+		{Name: "never", Schedule: &types.PollSchedule{Cron: "0 0 30 2 *"}},
+		{Name: "bad-action", BlackoutAction: "pause"},
+		{Name: "no-duration", BlackoutWindows: []types.BlackoutWindow{{Cron: "@daily"}}},
+		{Name: "no-end", BlackoutWindows: []types.BlackoutWindow{{Start: now}}},
+		{Name: "reversed", BlackoutWindows: []types.BlackoutWindow{{Start: now, End: now.Add(-time.Hour)}}},
+	}
+	for _, repo := range invalid {
+		_, err := compilePollPlan(GetDefaultPollerConfig(), repo)
+		assert.Error(t, err, repo.Name)
+	}
+}
+
+func TestScheduler_CronSchedule(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	scheduler := newTestScheduler(config, 0.5)
+
+	repo := types.Repository{Name: "business-hours", Enabled: true, Schedule: &types.PollSchedule{Cron: "*/30 * * * *"}}
+	require.NoError(t, scheduler.Schedule(repo))
+
+	scheduledRepo := scheduler.repositories["business-hours"]
+	assert.Equal(t, 30*time.Minute, scheduledRepo.Interval, "budgets use the gap between cron times")
+	assert.Zero(t, scheduledRepo.NextPollTime.Minute()%30)
+	assert.Zero(t, scheduledRepo.NextPollTime.Second())
+
+	// After a poll the repository comes back at the next cron time, without jitter
+	first := scheduledRepo.NextPollTime
+	assert.Len(t, scheduler.DuePolls(first), 1)
+	assert.Equal(t, first.Add(30*time.Minute), scheduledRepo.NextPollTime)
+
+	assert.Error(t, scheduler.Schedule(types.Repository{Name: "invalid", Enabled: true, Schedule: &types.PollSchedule{Cron: "* *"}}))
+	assert.NotContains(t, scheduler.repositories, "invalid")
+}
+
+func TestScheduler_QueueBlackout(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Jitter = 0
+	scheduler := newTestScheduler(config, 0)
+
+	now := time.Now()
+	ends := now.Add(2 * time.Hour)
+	repo := types.Repository{
+		Name:            "frozen",
+		Enabled:         true,
+		BlackoutWindows: []types.BlackoutWindow{{Name: "freeze", Start: now.Add(-time.Hour), End: ends}},
+	}
+	require.NoError(t, scheduler.Schedule(repo))
+
+	// Polls are held until the window ends, which the status shows as the next poll time
+	next, ok := scheduler.GetNextPollTime(repo)
+	require.True(t, ok)
+	assert.Equal(t, ends, next)
+
+	state, ok := scheduler.Blackout("frozen", now)
+	require.True(t, ok)
+	assert.Equal(t, BlackoutState{Window: "freeze", Action: types.BlackoutActionQueue, Ends: ends}, state)
+
+	// A poll made due during the window, say by an earlier deferral, is held too
+	require.NoError(t, scheduler.UpdateRepositorySchedule(repo, now))
+	assert.Empty(t, scheduler.DuePolls(now))
+	next, _ = scheduler.GetNextPollTime(repo)
+	assert.Equal(t, ends, next)
+
+	assert.Len(t, scheduler.DuePolls(ends), 1, "the repository is polled once the window ends")
+	_, ok = scheduler.Blackout("frozen", ends)
+	assert.False(t, ok)
+}
+
+func TestScheduler_DropBlackout(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Jitter = 0
+	config.BlackoutAction = types.BlackoutActionDrop
+	scheduler := newTestScheduler(config, 0)
+
+	now := time.Now()
+	repo := types.Repository{
+		Name:            "frozen",
+		Enabled:         true,
+		BlackoutWindows: []types.BlackoutWindow{{Name: "freeze", Start: now.Add(-time.Hour), End: now.Add(time.Hour)}},
+	}
+	require.NoError(t, scheduler.Schedule(repo))
+
+	// Polling carries on so changes made during the window are never triggered
+	assert.Len(t, scheduler.DuePolls(time.Now()), 1)
+	state, ok := scheduler.Blackout("frozen", now)
+	require.True(t, ok)
+	assert.Equal(t, types.BlackoutActionDrop, state.Action)
+}
+
+func TestPoller_BlackoutPolls(t *testing.T) {
+	testLogger := logger.GetDefaultLogger().WithField("test", "blackout")
+	now := time.Now()
+
+	config := GetDefaultPollerConfig()
+	config.BlackoutWindows = []types.BlackoutWindow{{Name: "freeze", Start: now.Add(-time.Hour), End: now.Add(time.Hour)}}
+	p := NewPoller(config, testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	repo := types.Repository{Name: "frozen", Provider: "github", Enabled: true}
+	require.NoError(t, p.AddRepository(repo))
+
+	// Queued: the repository is not polled at all
+	result, err := p.PollRepository(context.Background(), repo)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	require.NotNil(t, result.Blackout)
+	assert.Equal(t, "freeze", result.Blackout.Window)
+
+	status := p.GetStatus()
+	require.Len(t, status.Repositories, 1)
+	require.NotNil(t, status.Repositories[0].Blackout)
+	assert.Equal(t, now.Add(time.Hour), status.Repositories[0].NextPollTime)
+
+	// Dropped: events are kept but suppressed
+	events := []types.Event{
+		{ID: "push", Status: types.EventStatusPending, Metadata: map[string]string{}},
+		{ID: "tag", Status: types.EventStatusPending},
+	}
+	p.suppressForBlackout(repo, events, BlackoutState{Window: "freeze", Action: types.BlackoutActionDrop})
+	for _, event := range events {
+		assert.Equal(t, types.EventStatusSuppressed, event.Status)
+		assert.Equal(t, "freeze", event.Metadata["blackout"])
+	}
+}
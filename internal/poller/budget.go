@@ -0,0 +1,241 @@
+package poller
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// pollCostSmoothing weighs the latest poll against the running per-poll cost estimate
+const pollCostSmoothing = 0.3
+
+// RateLimitSource reports the API budget a repository's polls draw from.
+// gitclient.ClientFactory implements it.
+type RateLimitSource interface {
+	RateLimitFor(repo types.Repository, config gitclient.ClientConfig) (gitclient.RateLimitStatus, bool)
+}
+
+// PollBudget is the state of one API budget shared by scheduled repositories
+type PollBudget struct {
+	Provider     string    `json:"provider"`
+	Host         string    `json:"host,omitempty"`
+	Credential   string    `json:"credential,omitempty"`
+	Repositories int       `json:"repositories"`
+	Remaining    int       `json:"remaining"`
+	ResetTime    time.Time `json:"reset_time"`
+	Demand       float64   `json:"demand"`    // Requests the repositories would make before the reset at their normal interval
+	Throttled    bool      `json:"throttled"` // Demand exceeds the remaining budget, so non-critical polls are spread out
+	Deferred     int64     `json:"deferred_polls"`
+
+	// ProjectedExhaustion is when the budget runs out if every repository keeps
+	// its normal interval, or zero when it lasts until the reset
+	ProjectedExhaustion time.Time `json:"projected_exhaustion,omitempty"`
+}
+
+// budgetGroup is a set of scheduled repositories drawing from one API budget
+type budgetGroup struct {
+	status gitclient.RateLimitStatus
+	repos  []*ScheduledRepository
+
+	criticalDemand float64
+	normalDemand   float64
+	requestRate    float64 // Requests per second at the normal interval
+
+	// stretch multiplies the interval of non-critical repositories; 1 means the
+	// budget covers every poll, +Inf that only critical polls fit before the reset
+	stretch float64
+	spent   float64 // Estimated requests of polls claimed in the current round
+}
+
+// estimatePollCost guesses the API requests of one poll before any were measured
+func estimatePollCost(repo types.Repository) float64 {
+	cost := 1.0 // Branch listing
+	if repo.TagRegex != "" {
+		cost++
+	}
+	if repo.PullRequests {
+		cost++
+	}
+	return cost
+}
+
+// pollCost returns the expected API requests of one poll of the repository
+func (sr *ScheduledRepository) pollCost() float64 {
+	if sr.costSamples == 0 {
+		return estimatePollCost(sr.Repository)
+	}
+	return sr.EstimatedCost
+}
+
+// recordCost folds a measured poll into the repository's cost estimate
+func (sr *ScheduledRepository) recordCost(requests int64) {
+	if sr.costSamples == 0 {
+		sr.EstimatedCost = float64(requests)
+	} else {
+		sr.EstimatedCost = pollCostSmoothing*float64(requests) + (1-pollCostSmoothing)*sr.EstimatedCost
+	}
+	sr.costSamples++
+}
+
+// pollsBefore counts the polls a repository makes at interval from its next poll
+// time until deadline
+func pollsBefore(sr *ScheduledRepository, now, deadline time.Time, interval time.Duration) float64 {
+	next := sr.NextPollTime
+	if next.Before(now) {
+		next = now
+	}
+	if next.After(deadline) || interval <= 0 {
+		return 0
+	}
+	return math.Floor(float64(deadline.Sub(next))/float64(interval)) + 1
+}
+
+// budgetGroups groups the enabled repositories by the API budget they draw from.
+// Repositories whose budget is unknown, because no client has used it yet, are
+// left out and polled unconstrained. The caller must hold s.mu.
+func (s *SchedulerImpl) budgetGroups(now time.Time) (map[string]*budgetGroup, map[string]string) {
+	groups := make(map[string]*budgetGroup)
+	membership := make(map[string]string)
+	if s.rateLimits == nil {
+		return groups, membership
+	}
+
+	for name, sr := range s.repositories {
+		if !sr.Enabled {
+			continue
+		}
+		status, ok := s.rateLimits.RateLimitFor(sr.Repository, repoClientConfig(sr.Repository))
+		if !ok {
+			continue
+		}
+
+		key := status.Provider + "|" + status.Host + "|" + status.Credential
+		group, exists := groups[key]
+		if !exists {
+			group = &budgetGroup{status: status}
+			groups[key] = group
+		}
+		group.repos = append(group.repos, sr)
+		membership[name] = key
+	}
+
+	for _, group := range groups {
+		group.plan(now)
+	}
+	return groups, membership
+}
+
+// plan works out how far non-critical polls must be spread so the group's
+// remaining budget lasts until it resets
+func (g *budgetGroup) plan(now time.Time) {
+	g.stretch = 1
+	for _, sr := range g.repos {
+		if sr.Interval > 0 {
+			g.requestRate += sr.pollCost() / sr.Interval.Seconds()
+		}
+		if !g.status.ResetTime.After(now) {
+			continue
+		}
+		demand := sr.pollCost() * pollsBefore(sr, now, g.status.ResetTime, sr.Interval)
+		if sr.Repository.IsCritical() {
+			g.criticalDemand += demand
+		} else {
+			g.normalDemand += demand
+		}
+	}
+
+	// A reset time in the past means the provider has not reported since
+	if !g.status.ResetTime.After(now) {
+		return
+	}
+
+	remaining := float64(g.status.Remaining)
+	switch {
+	case g.criticalDemand+g.normalDemand <= remaining:
+	case g.criticalDemand >= remaining:
+		g.stretch = math.Inf(1)
+	default:
+		g.stretch = g.normalDemand / (remaining - g.criticalDemand)
+	}
+}
+
+// throttled reports whether the group's budget cannot cover every poll
+func (g *budgetGroup) throttled() bool {
+	return g.stretch > 1
+}
+
+// admit decides whether a due repository may be polled now and, if not, until
+// when its poll is deferred
+func (g *budgetGroup) admit(sr *ScheduledRepository, now time.Time) (bool, time.Time) {
+	cost := sr.pollCost()
+	reset := g.status.ResetTime
+
+	if !g.throttled() {
+		return true, time.Time{}
+	}
+
+	// Nothing is polled once the budget is used up
+	if float64(g.status.Remaining)-g.spent < cost {
+		return false, reset
+	}
+	if sr.Repository.IsCritical() {
+		return true, time.Time{}
+	}
+	if math.IsInf(g.stretch, 1) {
+		return false, reset
+	}
+
+	// Space out non-critical polls so the budget lasts until the reset
+	if !sr.LastPollTime.IsZero() {
+		earliest := sr.LastPollTime.Add(time.Duration(float64(sr.Interval) * g.stretch))
+		if now.Before(earliest) {
+			if earliest.After(reset) {
+				earliest = reset
+			}
+			return false, earliest
+		}
+	}
+	return true, time.Time{}
+}
+
+// budget summarizes the group for status reporting
+func (g *budgetGroup) budget(now time.Time) PollBudget {
+	budget := PollBudget{
+		Provider:     g.status.Provider,
+		Host:         g.status.Host,
+		Credential:   g.status.Credential,
+		Repositories: len(g.repos),
+		Remaining:    g.status.Remaining,
+		ResetTime:    g.status.ResetTime,
+		Demand:       g.criticalDemand + g.normalDemand,
+		Throttled:    g.throttled(),
+	}
+	for _, sr := range g.repos {
+		budget.Deferred += sr.DeferredCount
+	}
+
+	if g.requestRate > 0 {
+		exhaustion := now.Add(time.Duration(float64(g.status.Remaining) / g.requestRate * float64(time.Second)))
+		if exhaustion.Before(g.status.ResetTime) {
+			budget.ProjectedExhaustion = exhaustion
+		}
+	}
+	return budget
+}
+
+// sortByPriority orders due repositories critical first, then longest waiting
+func sortByPriority(repos []*ScheduledRepository) {
+	sort.SliceStable(repos, func(i, j int) bool {
+		a, b := repos[i], repos[j]
+		if a.Repository.IsCritical() != b.Repository.IsCritical() {
+			return a.Repository.IsCritical()
+		}
+		if !a.NextPollTime.Equal(b.NextPollTime) {
+			return a.NextPollTime.Before(b.NextPollTime)
+		}
+		return a.Repository.Name < b.Repository.Name
+	})
+}
@@ -0,0 +1,142 @@
+package poller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// staticRateLimits reports one shared budget for every repository
+type staticRateLimits struct {
+	status gitclient.RateLimitStatus
+}
+
+func (s *staticRateLimits) RateLimitFor(repo types.Repository, config gitclient.ClientConfig) (gitclient.RateLimitStatus, bool) {
+	return s.status, true
+}
+
+func newBudgetTestScheduler(t *testing.T, remaining int, reset time.Time, repos ...types.Repository) *SchedulerImpl {
+	config := GetDefaultPollerConfig()
+	scheduler := NewScheduler(config, logger.GetDefaultLogger().WithField("test", "budget"))
+	scheduler.SetRateLimitSource(&staticRateLimits{status: gitclient.RateLimitStatus{
+		Provider:   "github",
+		Host:       "github.com",
+		Credential: "token:abc",
+		Limit:      5000,
+		Remaining:  remaining,
+		ResetTime:  reset,
+	}})
+	for _, repo := range repos {
+		repo.Enabled = true
+		repo.Provider = "github"
+		require.NoError(t, scheduler.Schedule(repo))
+	}
+	return scheduler
+}
+
+// makeDue marks every scheduled repository as due, last polled one interval ago
+func makeDue(scheduler *SchedulerImpl, now time.Time) {
+	for _, scheduledRepo := range scheduler.repositories {
+		scheduledRepo.LastPollTime = now.Add(-scheduler.config.Interval)
+		scheduledRepo.NextPollTime = now.Add(-time.Second)
+	}
+}
+
+func repoNames(repos []types.Repository) []string {
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	return names
+}
+
+func TestScheduler_DuePolls_WithinBudget(t *testing.T) {
+	now := time.Now()
+	scheduler := newBudgetTestScheduler(t, 4000, now.Add(time.Hour),
+		types.Repository{Name: "b"}, types.Repository{Name: "a"}, types.Repository{Name: "ops", Priority: types.PriorityCritical})
+	makeDue(scheduler, now)
+
+	due := scheduler.DuePolls(now)
+	assert.Equal(t, []string{"ops", "a", "b"}, repoNames(due))
+
+	// Claimed repositories are not handed out again
+	assert.Empty(t, scheduler.DuePolls(now.Add(time.Second)))
+
+	budgets := scheduler.GetBudgets(now)
+	require.Len(t, budgets, 1)
+	assert.False(t, budgets[0].Throttled)
+	assert.Equal(t, 3, budgets[0].Repositories)
+	assert.True(t, budgets[0].ProjectedExhaustion.IsZero())
+}
+
+func TestScheduler_DuePolls_DefersNonCriticalWhenLow(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(time.Hour)
+	// Each repository would poll 13 times before the reset at the default 5m interval
+	scheduler := newBudgetTestScheduler(t, 30, reset,
+		types.Repository{Name: "one"}, types.Repository{Name: "two"},
+		types.Repository{Name: "three"}, types.Repository{Name: "four"},
+		types.Repository{Name: "ops", Priority: types.PriorityCritical})
+	makeDue(scheduler, now)
+
+	budgets := scheduler.GetBudgets(now)
+	require.Len(t, budgets, 1)
+	assert.True(t, budgets[0].Throttled)
+	assert.Equal(t, float64(65), budgets[0].Demand)
+	assert.False(t, budgets[0].ProjectedExhaustion.IsZero())
+	assert.True(t, budgets[0].ProjectedExhaustion.Before(reset))
+
+	due := scheduler.DuePolls(now)
+	assert.Equal(t, []string{"ops"}, repoNames(due))
+
+	// Non-critical polls are spread out but stay within the reset window
+	for _, name := range []string{"one", "two", "three", "four"} {
+		scheduledRepo := scheduler.repositories[name]
+		assert.Equal(t, int64(1), scheduledRepo.DeferredCount, name)
+		assert.True(t, scheduledRepo.NextPollTime.After(now.Add(scheduler.config.Interval)), name)
+		assert.False(t, scheduledRepo.NextPollTime.After(reset), name)
+	}
+
+	budgets = scheduler.GetBudgets(now)
+	assert.Equal(t, int64(4), budgets[0].Deferred)
+}
+
+func TestScheduler_DuePolls_ExhaustedBudget(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(20 * time.Minute)
+	scheduler := newBudgetTestScheduler(t, 0, reset,
+		types.Repository{Name: "app"}, types.Repository{Name: "ops", Priority: types.PriorityCritical})
+	makeDue(scheduler, now)
+
+	assert.Empty(t, scheduler.DuePolls(now))
+	for _, scheduledRepo := range scheduler.repositories {
+		assert.True(t, scheduledRepo.NextPollTime.Equal(reset))
+	}
+
+	// Polling resumes once the reset has passed
+	due := scheduler.DuePolls(reset.Add(time.Second))
+	assert.Len(t, due, 2)
+}
+
+func TestScheduler_RecordPollCost(t *testing.T) {
+	scheduler := newBudgetTestScheduler(t, 4000, time.Now().Add(time.Hour),
+		types.Repository{Name: "repo", TagRegex: ".*", PullRequests: true})
+
+	scheduledRepo := scheduler.repositories["repo"]
+	assert.Equal(t, float64(3), scheduledRepo.pollCost())
+
+	scheduler.RecordPollCost("repo", 10)
+	assert.Equal(t, float64(10), scheduledRepo.pollCost())
+
+	scheduler.RecordPollCost("repo", 0)
+	assert.InDelta(t, 7, scheduledRepo.pollCost(), 0.001)
+
+	// Unknown repositories are ignored
+	scheduler.RecordPollCost("missing", 5)
+}
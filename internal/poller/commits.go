@@ -0,0 +1,163 @@
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// DefaultMaxCommits is the number of commits attached to an event when none is configured
+const DefaultMaxCommits = 20
+
+// changedFiles is the metadata form of the files changed across a commit range
+type changedFiles struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
+}
+
+// enrichCommits attaches the commits between each event's previous and current
+// commit to its metadata. Failures only cost the commit details, never the event.
+func (p *PollerImpl) enrichCommits(ctx context.Context, repo types.Repository, events []types.Event) {
+	maxCommits := p.config.MaxCommits
+	if maxCommits == 0 {
+		maxCommits = DefaultMaxCommits
+	}
+	// Path filters still need the changed files when commit details are disabled
+	if maxCommits < 0 && !repo.HasPathFilters() {
+		return
+	}
+
+	// Only GitHub, GitLab and Bitbucket clients implement the compare API
+	if repo.Provider != "github" && repo.Provider != "gitlab" && repo.Provider != "bitbucket" {
+		return
+	}
+
+	var pending []int
+	for i, event := range events {
+		if compareBase(event) != "" {
+			pending = append(pending, i)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	client, err := p.clientFactory.CreateClient(repo, repoClientConfig(repo))
+	if err != nil {
+		p.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "enrich_commits",
+			"repository": repo.Name,
+		}).Warn("Failed to create Git client for commit details")
+		return
+	}
+	defer client.Close()
+
+	for _, i := range pending {
+		event := &events[i]
+		base := compareBase(*event)
+
+		comparison, err := client.CompareCommits(ctx, repo, base, event.CommitSHA)
+		if err != nil {
+			p.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "enrich_commits",
+				"repository": repo.Name,
+				"event_id":   event.ID,
+				"base":       base,
+				"head":       event.CommitSHA,
+			}).Warn("Failed to compare commits, sending event without commit details")
+			continue
+		}
+
+		if err := setCommitMetadata(event, comparison, maxCommits); err != nil {
+			p.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "enrich_commits",
+				"repository": repo.Name,
+				"event_id":   event.ID,
+			}).Warn("Failed to encode commit details")
+		}
+	}
+}
+
+// compareBase returns the revision an event's commit range starts from, or an
+// empty string when the event has no range to compare
+func compareBase(event types.Event) string {
+	if event.CommitSHA == "" {
+		return ""
+	}
+	if event.PrevCommit != "" && event.PrevCommit != event.CommitSHA {
+		return event.PrevCommit
+	}
+	// A new pull request is compared with the branch it targets
+	if event.Type == types.EventTypePullRequestOpened {
+		return event.Metadata["target_branch"]
+	}
+	return ""
+}
+
+// setCommitMetadata stores a comparison in event metadata, keeping only the newest
+// maxCommits commits, or only the changed files when maxCommits is negative. The
+// head commit is also exposed through the flat commit_* and author_* keys read by
+// the trigger transformer. A changed-file list the provider truncated is left
+// out, so the event counts as having no changed files rather than too few.
+func setCommitMetadata(event *types.Event, comparison *types.CommitComparison, maxCommits int) error {
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	if !comparison.FilesTruncated {
+		encodedFiles, err := json.Marshal(changedFiles{
+			Added:    nonNil(comparison.Added),
+			Removed:  nonNil(comparison.Removed),
+			Modified: nonNil(comparison.Modified),
+		})
+		if err != nil {
+			return err
+		}
+		event.Metadata["changed_files"] = string(encodedFiles)
+	}
+
+	if maxCommits < 0 {
+		return nil
+	}
+
+	commits := comparison.Commits
+	if len(commits) > maxCommits {
+		commits = commits[len(commits)-maxCommits:]
+	}
+
+	encodedCommits, err := json.Marshal(commits)
+	if err != nil {
+		return err
+	}
+
+	event.Metadata["commits"] = string(encodedCommits)
+	event.Metadata["total_commits"] = strconv.Itoa(comparison.TotalCommits)
+
+	if len(commits) > 0 {
+		head := commits[len(commits)-1]
+		if head.SHA == event.CommitSHA {
+			event.Metadata["commit_message"] = head.Message
+			event.Metadata["author_name"] = head.AuthorName
+			event.Metadata["author_email"] = head.AuthorEmail
+			event.Metadata["commit_timestamp"] = head.Timestamp.Format(time.RFC3339)
+			if head.URL != "" {
+				event.Metadata["commit_url"] = head.URL
+			}
+		}
+	}
+
+	return nil
+}
+
+// nonNil returns an empty slice for nil so lists encode as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,163 @@
+package poller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestPoller_EnrichCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/app/compare/old...new":
+			fmt.Fprint(w, `{
+				"total_commits": 3,
+				"commits": [
+					{"sha": "c1", "commit": {"message": "one", "author": {"name": "A", "date": "2024-01-01T00:00:00Z"}}},
+					{"sha": "c2", "commit": {"message": "two", "author": {"name": "B", "date": "2024-01-01T01:00:00Z"}}},
+					{"sha": "new", "html_url": "https://github.com/octo/app/commit/new",
+					 "commit": {"message": "three", "author": {"name": "C", "email": "c@example.com", "date": "2024-01-01T02:00:00Z"}}}
+				],
+				"files": [{"filename": "main.go", "status": "modified"}]
+			}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "enrich_commits")
+	config := GetDefaultPollerConfig()
+	config.MaxCommits = 2
+	p := NewPoller(config, testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	repo := types.Repository{
+		Name:       "app",
+		URL:        "https://github.com/octo/app",
+		Provider:   "github",
+		Token:      "token",
+		APIBaseURL: server.URL,
+	}
+	events := []types.Event{
+		{ID: "updated", CommitSHA: "new", PrevCommit: "old", Metadata: map[string]string{}},
+		{ID: "created", CommitSHA: "new", Metadata: map[string]string{}},
+		{ID: "broken", CommitSHA: "x", PrevCommit: "y", Metadata: map[string]string{}},
+	}
+
+	p.enrichCommits(context.Background(), repo, events)
+
+	updated := events[0].Metadata
+	var commits []types.Commit
+	require.NoError(t, json.Unmarshal([]byte(updated["commits"]), &commits))
+	require.Len(t, commits, 2, "commit list is capped to the newest commits")
+	assert.Equal(t, "c2", commits[0].SHA)
+	assert.Equal(t, "new", commits[1].SHA)
+	assert.Equal(t, "3", updated["total_commits"])
+	assert.Equal(t, "three", updated["commit_message"])
+	assert.Equal(t, "C", updated["author_name"])
+	assert.Equal(t, "c@example.com", updated["author_email"])
+	assert.Equal(t, "2024-01-01T02:00:00Z", updated["commit_timestamp"])
+	assert.Equal(t, "https://github.com/octo/app/commit/new", updated["commit_url"])
+	assert.JSONEq(t, `{"added": [], "removed": [], "modified": ["main.go"]}`, updated["changed_files"])
+
+	assert.Empty(t, events[1].Metadata, "events without a previous commit are not compared")
+	assert.Empty(t, events[2].Metadata, "compare failures leave the event untouched")
+}
+
+func TestPoller_EnrichCommitsDisabled(t *testing.T) {
+	testLogger := logger.GetDefaultLogger().WithField("test", "enrich_commits")
+	config := GetDefaultPollerConfig()
+	config.MaxCommits = -1
+	p := NewPoller(config, testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	events := []types.Event{{ID: "updated", CommitSHA: "new", PrevCommit: "old", Metadata: map[string]string{}}}
+	p.enrichCommits(context.Background(), types.Repository{Name: "app", Provider: "github"}, events)
+
+	assert.Empty(t, events[0].Metadata)
+}
+
+func TestPoller_EnrichCommitsChangedFilesOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/app/compare/main...feature-sha":
+			fmt.Fprint(w, `{"total_commits": 1, "commits": [{"sha": "feature-sha", "commit": {"message": "feature"}}],
+				"files": [{"filename": "services/api/main.go", "status": "added"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "enrich_commits")
+	config := GetDefaultPollerConfig()
+	config.MaxCommits = -1
+	p := NewPoller(config, testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	repo := types.Repository{
+		Name:         "app",
+		URL:          "https://github.com/octo/app",
+		Provider:     "github",
+		Token:        "token",
+		APIBaseURL:   server.URL,
+		IncludePaths: []string{"services/**"},
+	}
+	events := []types.Event{{
+		ID:        "pr",
+		Type:      types.EventTypePullRequestOpened,
+		CommitSHA: "feature-sha",
+		Metadata:  map[string]string{"target_branch": "main"},
+	}}
+
+	p.enrichCommits(context.Background(), repo, events)
+
+	assert.JSONEq(t, `{"added": ["services/api/main.go"], "removed": [], "modified": []}`, events[0].Metadata["changed_files"],
+		"new pull requests are compared with their target branch")
+	assert.NotContains(t, events[0].Metadata, "commits", "commit details stay disabled")
+}
+
+func TestPoller_EnrichCommitsTruncatedFiles(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// GitHub lists at most 300 changed files
+		files := make([]string, 300)
+		for i := range files {
+			files[i] = fmt.Sprintf(`{"filename": "docs/page%d.md", "status": "modified"}`, i)
+		}
+		fmt.Fprintf(w, `{"total_commits": 1, "commits": [{"sha": "b", "commit": {"message": "big"}}], "files": [%s]}`,
+			strings.Join(files, ","))
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "enrich_commits")
+	p := NewPoller(GetDefaultPollerConfig(), testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	repo := types.Repository{
+		Name:         "app",
+		URL:          "https://github.com/octo/app",
+		Provider:     "github",
+		Token:        "token",
+		APIBaseURL:   server.URL,
+		IncludePaths: []string{"services/**"},
+	}
+	events := []types.Event{{ID: "push", CommitSHA: "b", PrevCommit: "a", Metadata: map[string]string{}}}
+
+	p.enrichCommits(context.Background(), repo, events)
+	assert.NotContains(t, events[0].Metadata, "changed_files", "a truncated file list is not passed on")
+	assert.Contains(t, events[0].Metadata, "commits")
+
+	// A selected path may be among the files left out, so the event is dispatched
+	dispatch := p.applyPathFilters(repo, events)
+	require.Len(t, dispatch, 1)
+	assert.Equal(t, PathFilterUnavailable, dispatch[0].Metadata["path_filter"])
+}
@@ -3,6 +3,7 @@ package poller
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"time"
@@ -97,16 +98,20 @@ func (eg *EventGeneratorImpl) GenerateEvents(ctx context.Context, repo types.Rep
 	return events, nil
 }
 
-// FilterChanges applies repository-specific filtering to changes
+// FilterChanges applies repository-specific filtering to changes. Branch changes
+// are matched against the branch regex, or the default branch in default-branch
+// mode, and tag changes against the tag regex.
 func (eg *EventGeneratorImpl) FilterChanges(repo types.Repository, changes []BranchChange) ([]BranchChange, error) {
 	eg.logger.WithFields(logger.Fields{
 		"operation":    "filter_changes",
 		"repository":   repo.Name,
 		"input_count":  len(changes),
 		"branch_regex": repo.BranchRegex,
+		"branches":     repo.Branches,
+		"tag_regex":    repo.TagRegex,
 	}).Debug("Applying change filters")
 
-	if repo.BranchRegex == "" {
+	if repo.BranchRegex == "" && repo.TagRegex == "" && !repo.DefaultBranchOnly() {
 		// No regex filter, return all changes
 		eg.logger.WithFields(logger.Fields{
 			"operation":    "filter_changes",
@@ -116,14 +121,23 @@ func (eg *EventGeneratorImpl) FilterChanges(repo types.Repository, changes []Bra
 		return changes, nil
 	}
 
-	regex, err := regexp.Compile(repo.BranchRegex)
+	branchFilter, err := newBranchFilter(repo)
 	if err != nil {
-		return nil, fmt.Errorf("invalid branch regex '%s': %w", repo.BranchRegex, err)
+		return nil, err
+	}
+	tagRegex, err := compileOptionalRegex(repo.TagRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag regex '%s': %w", repo.TagRegex, err)
 	}
 
 	var filtered []BranchChange
 	for _, change := range changes {
-		if regex.MatchString(change.Branch) {
+		matched := branchFilter.matches(change.Branch)
+		if change.IsTag() {
+			matched = tagRegex == nil || tagRegex.MatchString(change.Branch)
+		}
+
+		if matched {
 			filtered = append(filtered, change)
 
 			eg.logger.WithFields(logger.Fields{
@@ -139,6 +153,8 @@ func (eg *EventGeneratorImpl) FilterChanges(repo types.Repository, changes []Bra
 				"branch":       change.Branch,
 				"change_type":  change.ChangeType,
 				"branch_regex": repo.BranchRegex,
+				"branches":     repo.Branches,
+				"tag_regex":    repo.TagRegex,
 			}).Debug("Change filtered out by regex")
 		}
 	}
@@ -154,10 +170,25 @@ func (eg *EventGeneratorImpl) FilterChanges(repo types.Repository, changes []Bra
 	return filtered, nil
 }
 
+// compileOptionalRegex compiles a regex, returning nil for an empty pattern
+func compileOptionalRegex(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(pattern)
+}
+
 // createEventFromChange creates a single event from a branch change
 func (eg *EventGeneratorImpl) createEventFromChange(repo types.Repository, change BranchChange, timestamp time.Time) (types.Event, error) {
-	// Generate unique event ID based on repository, branch, commit, and timestamp
-	eventID := eg.generateEventID(repo.Name, change.Branch, change.NewCommitSHA, timestamp)
+	// Generate unique event ID based on repository, ref, commit, and timestamp.
+	// Tags use their full ref so a tag never collides with a branch of the same name.
+	refName := change.Branch
+	eventType := eg.getEventType(change.ChangeType)
+	if change.IsTag() {
+		refName = "refs/tags/" + change.Branch
+		eventType = eg.getTagEventType(change.ChangeType)
+	}
+	eventID := eg.generateEventID(repo.Name, refName, change.NewCommitSHA, timestamp)
 
 	// Create event metadata
 	metadata := map[string]interface{}{
@@ -168,10 +199,21 @@ func (eg *EventGeneratorImpl) createEventFromChange(repo types.Repository, chang
 		"old_commit_sha": change.OldCommitSHA,
 		"new_commit_sha": change.NewCommitSHA,
 		"protected":      change.Protected,
+		"default_branch": change.Default,
+		"ref_type":       RefTypeBranch,
 		"source":         "reposentry-poller",
 		"poller_version": "1.0.0",
 	}
 
+	if change.IsTag() {
+		metadata["ref_type"] = RefTypeTag
+		metadata["tag"] = change.Branch
+	}
+
+	if repo.DefaultBranch != "" {
+		metadata["repository_default_branch"] = repo.DefaultBranch
+	}
+
 	// Add repository URL if available
 	if repo.URL != "" {
 		metadata["repository_url"] = repo.URL
@@ -185,7 +227,7 @@ func (eg *EventGeneratorImpl) createEventFromChange(repo types.Repository, chang
 
 	event := types.Event{
 		ID:         eventID,
-		Type:       eg.getEventType(change.ChangeType),
+		Type:       eventType,
 		Repository: repo.Name,
 		Branch:     change.Branch,
 		CommitSHA:  change.NewCommitSHA,
@@ -201,6 +243,93 @@ func (eg *EventGeneratorImpl) createEventFromChange(repo types.Repository, chang
 	return event, nil
 }
 
+// GeneratePullRequestEvents creates events from pull request changes. The branch
+// filter applies to the pull request's target branch.
+func (eg *EventGeneratorImpl) GeneratePullRequestEvents(ctx context.Context, repo types.Repository, changes []PullRequestChange) ([]types.Event, error) {
+	branchFilter, err := newBranchFilter(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []types.Event{}
+	timestamp := time.Now()
+
+	for _, change := range changes {
+		if !branchFilter.matches(change.PullRequest.TargetBranch) {
+			eg.logger.WithFields(logger.Fields{
+				"operation":     "generate_pull_request_events",
+				"repository":    repo.Name,
+				"pull_request":  change.PullRequest.Number,
+				"target_branch": change.PullRequest.TargetBranch,
+			}).Debug("Pull request change filtered out by branch regex")
+			continue
+		}
+
+		event := eg.createEventFromPullRequestChange(repo, change, timestamp)
+		events = append(events, event)
+
+		eg.logger.WithFields(logger.Fields{
+			"operation":    "generate_pull_request_events",
+			"repository":   repo.Name,
+			"pull_request": change.PullRequest.Number,
+			"change_type":  change.ChangeType,
+			"event_id":     event.ID,
+		}).Info("Generated event from pull request change")
+	}
+
+	return events, nil
+}
+
+// createEventFromPullRequestChange creates a single event from a pull request change
+func (eg *EventGeneratorImpl) createEventFromPullRequestChange(repo types.Repository, change PullRequestChange, timestamp time.Time) types.Event {
+	pr := change.PullRequest
+
+	labels, _ := json.Marshal(pr.Labels)
+	metadata := map[string]string{
+		"repository":     repo.Name,
+		"provider":       repo.Provider,
+		"change_type":    change.ChangeType,
+		"ref_type":       "pull_request",
+		"pr_number":      fmt.Sprintf("%d", pr.Number),
+		"pr_title":       pr.Title,
+		"pr_state":       pr.State,
+		"pr_author":      pr.Author,
+		"pr_draft":       fmt.Sprintf("%t", pr.Draft),
+		"pr_labels":      string(labels), // JSON array; label names may contain commas
+		"source_branch":  pr.SourceBranch,
+		"target_branch":  pr.TargetBranch,
+		"old_commit_sha": change.OldHeadSHA,
+		"new_commit_sha": pr.HeadSHA,
+		"source":         "reposentry-poller",
+		"poller_version": "1.0.0",
+	}
+	if pr.URL != "" {
+		metadata["pr_url"] = pr.URL
+	}
+	if repo.DefaultBranch != "" {
+		metadata["repository_default_branch"] = repo.DefaultBranch
+	}
+	if repo.URL != "" {
+		metadata["repository_url"] = repo.URL
+	}
+
+	ref := fmt.Sprintf("pull/%d/%s", pr.Number, change.ChangeType)
+	return types.Event{
+		ID:         eg.generateEventID(repo.Name, ref, pr.HeadSHA, timestamp),
+		Type:       eg.getPullRequestEventType(change.ChangeType),
+		Repository: repo.Name,
+		Branch:     pr.SourceBranch,
+		CommitSHA:  pr.HeadSHA,
+		PrevCommit: change.OldHeadSHA,
+		Provider:   repo.Provider,
+		Timestamp:  timestamp,
+		Status:     types.EventStatusPending,
+		Metadata:   metadata,
+		CreatedAt:  timestamp,
+		UpdatedAt:  timestamp,
+	}
+}
+
 // generateEventID creates a unique event ID
 func (eg *EventGeneratorImpl) generateEventID(repository, branch, commitSHA string, timestamp time.Time) string {
 	// Create a unique identifier based on multiple factors to ensure idempotency
@@ -223,6 +352,32 @@ func (eg *EventGeneratorImpl) getEventType(changeType string) types.EventType {
 	}
 }
 
+// getTagEventType maps a tag change type to event type
+func (eg *EventGeneratorImpl) getTagEventType(changeType string) types.EventType {
+	switch changeType {
+	case ChangeTypeNew:
+		return types.EventTypeTagCreated
+	case ChangeTypeDeleted:
+		return types.EventTypeTagDeleted
+	default:
+		return types.EventTypeTagMoved
+	}
+}
+
+// getPullRequestEventType maps a pull request change type to event type
+func (eg *EventGeneratorImpl) getPullRequestEventType(changeType string) types.EventType {
+	switch changeType {
+	case PullRequestOpened:
+		return types.EventTypePullRequestOpened
+	case PullRequestClosed:
+		return types.EventTypePullRequestClosed
+	case PullRequestMerged:
+		return types.EventTypePullRequestMerged
+	default:
+		return types.EventTypePullRequestUpdated
+	}
+}
+
 // EventFilter provides additional filtering capabilities
 type EventFilter struct {
 	IncludeProtected   bool          `yaml:"include_protected" json:"include_protected"`
@@ -178,6 +178,34 @@ func TestEventGenerator_FilterChanges(t *testing.T) {
 			expectedCount: 2,
 			expectError:   false,
 		},
+		{
+			name: "Default branch only ignores the regex",
+			repo: types.Repository{
+				Name:          "test-repo",
+				BranchRegex:   ".*",
+				Branches:      types.BranchesDefault,
+				DefaultBranch: "trunk",
+			},
+			changes: []BranchChange{
+				{Branch: "main"},
+				{Branch: "trunk"},
+				{Branch: "feature/test"},
+			},
+			expectedCount: 1,
+			expectError:   false,
+		},
+		{
+			name: "Default branch only without a known default branch",
+			repo: types.Repository{
+				Name:     "test-repo",
+				Branches: types.BranchesDefault,
+			},
+			changes: []BranchChange{
+				{Branch: "main"},
+			},
+			expectedCount: 0,
+			expectError:   true,
+		},
 		{
 			name: "Invalid regex",
 			repo: types.Repository{
@@ -234,6 +262,91 @@ func TestEventGenerator_getEventType(t *testing.T) {
 	}
 }
 
+func TestEventGenerator_TagEvents(t *testing.T) {
+	generator := NewEventGenerator(logger.GetDefaultLogger().WithField("test", "event_generator"))
+	repo := types.Repository{
+		Name:        "repo",
+		Provider:    "github",
+		URL:         "https://github.com/owner/repo",
+		BranchRegex: "^main$",
+		TagRegex:    `^v\d+\.\d+\.\d+$`,
+	}
+
+	changes := []BranchChange{
+		{Repository: "repo", Branch: "main", NewCommitSHA: "abc", ChangeType: ChangeTypeUpdated},
+		{Repository: "repo", Branch: "v1.0.0", NewCommitSHA: "abc", ChangeType: ChangeTypeNew, RefType: RefTypeTag},
+		{Repository: "repo", Branch: "v0.9.0", OldCommitSHA: "old", NewCommitSHA: "def", ChangeType: ChangeTypeUpdated, RefType: RefTypeTag},
+		{Repository: "repo", Branch: "v0.8.0", OldCommitSHA: "old", ChangeType: ChangeTypeDeleted, RefType: RefTypeTag},
+		{Repository: "repo", Branch: "nightly", NewCommitSHA: "abc", ChangeType: ChangeTypeNew, RefType: RefTypeTag},
+	}
+
+	events, err := generator.GenerateEvents(context.Background(), repo, changes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("Expected 4 events, got %d", len(events))
+	}
+
+	expectedTypes := []types.EventType{
+		types.EventTypeBranchUpdated,
+		types.EventTypeTagCreated,
+		types.EventTypeTagMoved,
+		types.EventTypeTagDeleted,
+	}
+	for i, expected := range expectedTypes {
+		if events[i].Type != expected {
+			t.Errorf("Event %d: expected type %s, got %s", i, expected, events[i].Type)
+		}
+	}
+
+	if events[1].Metadata["ref_type"] != RefTypeTag || events[1].Metadata["tag"] != "v1.0.0" {
+		t.Errorf("Expected tag metadata, got %v", events[1].Metadata)
+	}
+	if events[0].Metadata["ref_type"] != RefTypeBranch {
+		t.Errorf("Expected branch ref_type, got %q", events[0].Metadata["ref_type"])
+	}
+	if events[0].ID == events[1].ID {
+		t.Error("Branch and tag events at the same commit should have different IDs")
+	}
+}
+
+func TestEventGenerator_DefaultBranchMetadata(t *testing.T) {
+	generator := NewEventGenerator(logger.GetDefaultLogger().WithField("test", "event_generator"))
+	repo := types.Repository{
+		Name:          "repo",
+		Provider:      "github",
+		URL:           "https://github.com/owner/repo",
+		BranchRegex:   ".*",
+		DefaultBranch: "main",
+	}
+
+	changes := []BranchChange{
+		{Repository: "repo", Branch: "main", NewCommitSHA: "abc", ChangeType: ChangeTypeUpdated, Default: true},
+		{Repository: "repo", Branch: "feature", NewCommitSHA: "def", ChangeType: ChangeTypeNew},
+	}
+
+	events, err := generator.GenerateEvents(context.Background(), repo, changes)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0].Metadata["default_branch"] != "true" {
+		t.Errorf("Expected main to be marked as the default branch, got %q", events[0].Metadata["default_branch"])
+	}
+	if events[1].Metadata["default_branch"] != "false" {
+		t.Errorf("Expected feature not to be marked as the default branch, got %q", events[1].Metadata["default_branch"])
+	}
+	for _, event := range events {
+		if event.Metadata["repository_default_branch"] != "main" {
+			t.Errorf("Expected repository_default_branch 'main', got %q", event.Metadata["repository_default_branch"])
+		}
+	}
+}
+
 func TestEventGenerator_generateEventID(t *testing.T) {
 	logger := logger.GetDefaultLogger().WithField("test", "event_generator")
 	generator := NewEventGenerator(logger)
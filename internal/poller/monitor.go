@@ -4,19 +4,49 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/johnnynv/RepoSentry/internal/gitclient"
 	"github.com/johnnynv/RepoSentry/internal/storage"
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
+// prefetchMaxAge is how long a batched branch listing may be used in place of a fresh request
+const prefetchMaxAge = 2 * time.Minute
+
+// defaultBranchMaxAge is how long a repository's default branch is cached before
+// it is looked up again
+const defaultBranchMaxAge = time.Hour
+
 // BranchMonitorImpl implements the BranchMonitor interface
 type BranchMonitorImpl struct {
 	storage       storage.Storage
 	clientFactory *gitclient.ClientFactory
 	logger        *logger.Entry
+
+	// Branch listings fetched ahead of polls by PrefetchBranches, keyed by repository name
+	prefetchMu sync.Mutex
+	prefetched map[string]prefetchedBranches
+
+	// Default branches looked up from the provider, keyed by repository name
+	defaultBranchMu sync.Mutex
+	defaultBranches map[string]cachedDefaultBranch
+}
+
+// cachedDefaultBranch is a repository's default branch as last looked up
+type cachedDefaultBranch struct {
+	name      string
+	fetchedAt time.Time
+}
+
+// prefetchedBranches is a branch listing obtained by a batched query
+type prefetchedBranches struct {
+	branches  []types.Branch
+	fetchedAt time.Time
 }
 
 // NewBranchMonitor creates a new branch monitor
@@ -28,6 +58,8 @@ func NewBranchMonitor(storage storage.Storage, clientFactory *gitclient.ClientFa
 			"component": "poller",
 			"module":    "branch_monitor",
 		}),
+		prefetched:      make(map[string]prefetchedBranches),
+		defaultBranches: make(map[string]cachedDefaultBranch),
 	}
 }
 
@@ -41,35 +73,27 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 		"provider":   repo.Provider,
 	}).Info("Starting branch check")
 
-	// Create Git client
-	clientConfig := gitclient.GetDefaultConfig()
-	clientConfig.Token = repo.Token
-
-	// Set provider-specific configuration
-	if repo.Provider == "gitlab" && repo.APIBaseURL != "" {
-		clientConfig.BaseURL = repo.APIBaseURL
-	} else if repo.Provider == "github" && repo.APIBaseURL != "" {
-		clientConfig.BaseURL = repo.APIBaseURL
+	// Only the default branch is monitored, so it has to be known
+	if repo.DefaultBranchOnly() && repo.DefaultBranch == "" {
+		resolved, err := bm.ResolveDefaultBranch(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
+		repo = resolved
 	}
 
-	client, err := bm.clientFactory.CreateClient(repo, clientConfig)
-	if err != nil {
+	// Get current branches from Git provider, or from a batched prefetch
+	currentBranches, err := bm.fetchBranches(ctx, repo)
+	complete := err == nil
+	if gitclient.IsIncompleteListing(err) {
+		// Branches past the page cap were not listed, so none can be told apart
+		// from a deleted one
 		bm.logger.WithError(err).WithFields(logger.Fields{
 			"operation":  "check_branches",
 			"repository": repo.Name,
-		}).Error("Failed to create Git client")
-		return nil, fmt.Errorf("failed to create Git client: %w", err)
-	}
-	defer client.Close()
-
-	// Get current branches from Git provider
-	currentBranches, err := client.GetBranches(ctx, repo)
-	if err != nil {
-		bm.logger.WithError(err).WithFields(logger.Fields{
-			"operation":  "check_branches",
-			"repository": repo.Name,
-		}).Error("Failed to get current branches")
-		return nil, fmt.Errorf("failed to get current branches: %w", err)
+		}).Warn("Branch listing is incomplete, skipping deleted branch detection")
+	} else if err != nil {
+		return nil, err
 	}
 
 	bm.logger.WithFields(logger.Fields{
@@ -94,7 +118,8 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 		"filtered_count": len(filteredBranches),
 		"original_count": len(currentBranches),
 		"branch_regex":   repo.BranchRegex,
-	}).Debug("Filtered branches by regex")
+		"branches":       repo.Branches,
+	}).Debug("Filtered branches")
 
 	// Get stored branch states
 	storedStates, err := bm.storage.GetRepoStates(ctx, repo.Name)
@@ -136,6 +161,7 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 				ChangeType:   ChangeTypeNew,
 				Timestamp:    checkTime,
 				Protected:    branch.Protected,
+				Default:      branch.Default,
 			}
 			changes = append(changes, change)
 
@@ -157,6 +183,7 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 				ChangeType:   ChangeTypeUpdated,
 				Timestamp:    checkTime,
 				Protected:    branch.Protected,
+				Default:      branch.Default,
 			}
 			changes = append(changes, change)
 
@@ -195,7 +222,7 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 	}
 
 	for branchName, oldCommitSHA := range storedBranchMap {
-		if !currentBranchMap[branchName] {
+		if complete && !currentBranchMap[branchName] {
 			// Branch was deleted
 			change := BranchChange{
 				Repository:   repo.Name,
@@ -205,6 +232,7 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 				ChangeType:   ChangeTypeDeleted,
 				Timestamp:    checkTime,
 				Protected:    false, // Unknown, but assuming false
+				Default:      branchName == repo.DefaultBranch,
 			}
 			changes = append(changes, change)
 
@@ -227,6 +255,19 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 		}
 	}
 
+	// Tags are only monitored when the repository opts in with a tag regex. A
+	// failed tag check does not discard the branch changes already detected.
+	if repo.TagRegex != "" {
+		tagChanges, err := bm.checkTags(ctx, repo, checkTime)
+		if err != nil {
+			bm.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "check_tags",
+				"repository": repo.Name,
+			}).Error("Failed to check tags")
+		}
+		changes = append(changes, tagChanges...)
+	}
+
 	duration := time.Since(startTime)
 
 	bm.logger.WithFields(logger.Fields{
@@ -239,6 +280,294 @@ func (bm *BranchMonitorImpl) CheckBranches(ctx context.Context, repo types.Repos
 	return changes, nil
 }
 
+// checkTags detects created, moved and deleted tags matching the repository's tag
+// regex. Deleted tags are only detected when the whole tag list was fetched.
+func (bm *BranchMonitorImpl) checkTags(ctx context.Context, repo types.Repository, checkTime time.Time) ([]BranchChange, error) {
+	regex, err := regexp.Compile(repo.TagRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tag regex '%s': %w", repo.TagRegex, err)
+	}
+
+	client, err := bm.clientFactory.CreateClient(repo, repoClientConfig(repo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Git client: %w", err)
+	}
+	defer client.Close()
+
+	tags, err := client.GetTags(ctx, repo)
+	complete := err == nil
+	if gitclient.IsIncompleteListing(err) {
+		// Tags past the page cap were not listed, so none can be told apart
+		// from a deleted one
+		bm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "check_tags",
+			"repository": repo.Name,
+		}).Warn("Tag listing is incomplete, skipping deleted tag detection")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get current tags: %w", err)
+	}
+
+	storedStates, err := bm.storage.GetTagStates(ctx, repo.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored tag states: %w", err)
+	}
+
+	storedTagMap := make(map[string]string) // tag -> commit_sha
+	for _, state := range storedStates {
+		storedTagMap[state.Tag] = state.CommitSHA
+	}
+
+	var changes []BranchChange
+	currentTagMap := make(map[string]bool)
+	for _, tag := range tags {
+		if !regex.MatchString(tag.Name) {
+			continue
+		}
+		currentTagMap[tag.Name] = true
+
+		oldCommitSHA, exists := storedTagMap[tag.Name]
+		if exists && oldCommitSHA == tag.CommitSHA {
+			continue
+		}
+
+		changeType := ChangeTypeNew
+		if exists {
+			changeType = ChangeTypeUpdated
+		}
+		changes = append(changes, BranchChange{
+			Repository:   repo.Name,
+			Branch:       tag.Name,
+			OldCommitSHA: oldCommitSHA,
+			NewCommitSHA: tag.CommitSHA,
+			ChangeType:   changeType,
+			Timestamp:    checkTime,
+			RefType:      RefTypeTag,
+		})
+
+		bm.logger.WithFields(logger.Fields{
+			"operation":   "check_tags",
+			"repository":  repo.Name,
+			"tag":         tag.Name,
+			"old_commit":  oldCommitSHA,
+			"new_commit":  tag.CommitSHA,
+			"change_type": changeType,
+		}).Info("Detected tag change")
+
+		tagState := storage.TagState{
+			Repository: repo.Name,
+			Tag:        tag.Name,
+			CommitSHA:  tag.CommitSHA,
+			LastCheck:  checkTime,
+		}
+		if err := bm.storage.UpsertTagState(ctx, tagState); err != nil {
+			bm.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "check_tags",
+				"repository": repo.Name,
+				"tag":        tag.Name,
+			}).Error("Failed to update tag state")
+		}
+	}
+
+	// Stored tags that no longer exist, or no longer match the regex, are deleted
+	for tagName, oldCommitSHA := range storedTagMap {
+		if !complete || currentTagMap[tagName] {
+			continue
+		}
+
+		changes = append(changes, BranchChange{
+			Repository:   repo.Name,
+			Branch:       tagName,
+			OldCommitSHA: oldCommitSHA,
+			ChangeType:   ChangeTypeDeleted,
+			Timestamp:    checkTime,
+			RefType:      RefTypeTag,
+		})
+
+		bm.logger.WithFields(logger.Fields{
+			"operation":   "check_tags",
+			"repository":  repo.Name,
+			"tag":         tagName,
+			"old_commit":  oldCommitSHA,
+			"change_type": ChangeTypeDeleted,
+		}).Info("Detected deleted tag")
+
+		if err := bm.storage.DeleteTagState(ctx, repo.Name, tagName); err != nil {
+			bm.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "check_tags",
+				"repository": repo.Name,
+				"tag":        tagName,
+			}).Error("Failed to delete tag state")
+		}
+	}
+
+	return changes, nil
+}
+
+// fetchBranches returns a prefetched branch listing if one is available, otherwise
+// it lists the branches through the provider API. A listing cut short by the
+// page cap is returned along with its IncompleteListingError.
+func (bm *BranchMonitorImpl) fetchBranches(ctx context.Context, repo types.Repository) ([]types.Branch, error) {
+	if branches, ok := bm.takePrefetched(repo.Name); ok {
+		bm.logger.WithFields(logger.Fields{
+			"operation":  "check_branches",
+			"repository": repo.Name,
+		}).Debug("Using prefetched branches")
+		return branches, nil
+	}
+
+	client, err := bm.clientFactory.CreateClient(repo, repoClientConfig(repo))
+	if err != nil {
+		bm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "check_branches",
+			"repository": repo.Name,
+		}).Error("Failed to create Git client")
+		return nil, fmt.Errorf("failed to create Git client: %w", err)
+	}
+	defer client.Close()
+
+	branches, err := client.GetBranches(ctx, repo)
+	if gitclient.IsIncompleteListing(err) {
+		// Let the caller use the branches that were listed
+		return branches, err
+	}
+	if err != nil {
+		entry := bm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "check_branches",
+			"repository": repo.Name,
+		})
+		if gitclient.IsCircuitOpen(err) {
+			// The provider is down, not the branches gone: keep the stored states as they are
+			entry.Warn("Skipping branch check while the provider API circuit is open")
+		} else {
+			entry.Error("Failed to get current branches")
+		}
+		return nil, fmt.Errorf("failed to get current branches: %w", err)
+	}
+
+	return branches, nil
+}
+
+// repoClientConfig builds the Git client configuration for a repository
+func repoClientConfig(repo types.Repository) gitclient.ClientConfig {
+	clientConfig := gitclient.GetDefaultConfig()
+	clientConfig.Token = repo.Token
+
+	// Set provider-specific configuration
+	if repo.APIBaseURL != "" {
+		clientConfig.BaseURL = repo.APIBaseURL
+	}
+
+	return clientConfig
+}
+
+// PrefetchBranches fetches the branches of ready GitHub repositories with batched
+// GraphQL queries so the following CheckBranches calls need no request of their own.
+// Repositories whose batch fails are polled individually as usual.
+func (bm *BranchMonitorImpl) PrefetchBranches(ctx context.Context, repos []types.Repository, batchSize int) int {
+	if batchSize <= 0 {
+		batchSize = DefaultGraphQLBatchSize
+	}
+
+	// Repositories can only share a query when they share an endpoint and credentials
+	groups := make(map[string][]types.Repository)
+	var keys []string
+	for _, repo := range repos {
+		if repo.Provider != "github" {
+			continue
+		}
+		key := graphQLBatchKey(repo)
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], repo)
+	}
+
+	prefetched := 0
+	for _, key := range keys {
+		group := groups[key]
+		for start := 0; start < len(group); start += batchSize {
+			end := start + batchSize
+			if end > len(group) {
+				end = len(group)
+			}
+			prefetched += bm.prefetchBatch(ctx, group[start:end])
+		}
+	}
+
+	bm.logger.WithFields(logger.Fields{
+		"operation":        "prefetch_branches",
+		"repository_count": len(repos),
+		"prefetched":       prefetched,
+	}).Debug("Prefetched branches with GraphQL")
+
+	return prefetched
+}
+
+// prefetchBatch runs one batched query for repositories sharing credentials
+func (bm *BranchMonitorImpl) prefetchBatch(ctx context.Context, batch []types.Repository) int {
+	client, err := bm.clientFactory.CreateClient(batch[0], repoClientConfig(batch[0]))
+	if err != nil {
+		bm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "prefetch_branches",
+			"repository": batch[0].Name,
+		}).Warn("Failed to create Git client for batch")
+		return 0
+	}
+	defer client.Close()
+
+	githubClient, ok := client.(*gitclient.GitHubClient)
+	if !ok {
+		return 0
+	}
+
+	results, err := githubClient.GetBranchesBatch(ctx, batch)
+	if err != nil {
+		bm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":        "prefetch_branches",
+			"repository_count": len(batch),
+		}).Warn("Batched branch query failed, repositories will be polled individually")
+		return 0
+	}
+
+	fetchedAt := time.Now()
+	bm.prefetchMu.Lock()
+	defer bm.prefetchMu.Unlock()
+	for name, branches := range results {
+		bm.prefetched[name] = prefetchedBranches{branches: branches, fetchedAt: fetchedAt}
+	}
+	return len(results)
+}
+
+// takePrefetched returns and removes a fresh prefetched branch listing
+func (bm *BranchMonitorImpl) takePrefetched(repoName string) ([]types.Branch, bool) {
+	bm.prefetchMu.Lock()
+	defer bm.prefetchMu.Unlock()
+
+	entry, exists := bm.prefetched[repoName]
+	if !exists {
+		return nil, false
+	}
+	delete(bm.prefetched, repoName)
+
+	if time.Since(entry.fetchedAt) > prefetchMaxAge {
+		return nil, false
+	}
+	return entry.branches, true
+}
+
+// graphQLBatchKey groups repositories that can be queried with the same credentials.
+// GitHub App installations are per owner, so app-authenticated repositories are also
+// grouped by owner.
+func graphQLBatchKey(repo types.Repository) string {
+	key := repo.APIBaseURL + "|" + repo.Token
+	if repo.Token == "" {
+		if parsed, err := utils.ParseGitURL(repo.URL); err == nil {
+			key += "|" + strings.Split(strings.Trim(parsed.Path, "/"), "/")[0]
+		}
+	}
+	return key
+}
+
 // GetLastCheckTime returns the last time the repository was checked
 func (bm *BranchMonitorImpl) GetLastCheckTime(repo types.Repository) (time.Time, bool) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -273,24 +602,96 @@ func (bm *BranchMonitorImpl) UpdateLastCheck(repo types.Repository, checkTime ti
 	return nil
 }
 
-// filterBranches applies the repository's branch regex filter
+// filterBranches applies the repository's branch filter and marks its default branch
 func (bm *BranchMonitorImpl) filterBranches(repo types.Repository, branches []types.Branch) ([]types.Branch, error) {
-	if repo.BranchRegex == "" {
-		// No filter specified, return all branches
-		return branches, nil
-	}
-
-	regex, err := regexp.Compile(repo.BranchRegex)
+	filter, err := newBranchFilter(repo)
 	if err != nil {
-		return nil, fmt.Errorf("invalid branch regex '%s': %w", repo.BranchRegex, err)
+		return nil, err
 	}
 
 	var filtered []types.Branch
 	for _, branch := range branches {
-		if regex.MatchString(branch.Name) {
+		if filter.matches(branch.Name) {
+			branch.Default = repo.DefaultBranch != "" && branch.Name == repo.DefaultBranch
 			filtered = append(filtered, branch)
 		}
 	}
 
 	return filtered, nil
 }
+
+// ResolveDefaultBranch returns the repository with its default branch filled in.
+// A configured default branch is used as is; otherwise it is looked up from the
+// provider and cached for defaultBranchMaxAge.
+func (bm *BranchMonitorImpl) ResolveDefaultBranch(ctx context.Context, repo types.Repository) (types.Repository, error) {
+	if repo.DefaultBranch != "" {
+		return repo, nil
+	}
+
+	bm.defaultBranchMu.Lock()
+	cached, ok := bm.defaultBranches[repo.Name]
+	bm.defaultBranchMu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < defaultBranchMaxAge {
+		repo.DefaultBranch = cached.name
+		return repo, nil
+	}
+
+	client, err := bm.clientFactory.CreateClient(repo, repoClientConfig(repo))
+	if err != nil {
+		return repo, fmt.Errorf("failed to create Git client: %w", err)
+	}
+	defer client.Close()
+
+	name, err := client.GetDefaultBranch(ctx, repo)
+	if err != nil {
+		return repo, fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	if ok && cached.name != name {
+		bm.logger.WithFields(logger.Fields{
+			"operation":  "resolve_default_branch",
+			"repository": repo.Name,
+			"old_branch": cached.name,
+			"new_branch": name,
+		}).Info("Repository default branch changed")
+	}
+
+	bm.defaultBranchMu.Lock()
+	bm.defaultBranches[repo.Name] = cachedDefaultBranch{name: name, fetchedAt: time.Now()}
+	bm.defaultBranchMu.Unlock()
+
+	repo.DefaultBranch = name
+	return repo, nil
+}
+
+// branchFilter selects the branches a repository monitors: either only its
+// default branch, or the branches matching its branch regex
+type branchFilter struct {
+	defaultBranch string
+	regex         *regexp.Regexp // nil matches every branch
+}
+
+// newBranchFilter creates the branch filter for a repository. In default-branch
+// mode the repository's default branch must already be resolved.
+func newBranchFilter(repo types.Repository) (*branchFilter, error) {
+	if repo.DefaultBranchOnly() {
+		if repo.DefaultBranch == "" {
+			return nil, fmt.Errorf("default branch of repository %s is unknown", repo.Name)
+		}
+		return &branchFilter{defaultBranch: repo.DefaultBranch}, nil
+	}
+
+	regex, err := compileOptionalRegex(repo.BranchRegex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid branch regex '%s': %w", repo.BranchRegex, err)
+	}
+	return &branchFilter{regex: regex}, nil
+}
+
+// matches reports whether a branch is monitored
+func (f *branchFilter) matches(branch string) bool {
+	if f.defaultBranch != "" {
+		return branch == f.defaultBranch
+	}
+	return f.regex == nil || f.regex.MatchString(branch)
+}
@@ -0,0 +1,316 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/storage"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestBranchMonitor_PrefetchBranches(t *testing.T) {
+	graphQLRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql" {
+			// Individual REST polls are not expected once branches were prefetched
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		graphQLRequests++
+		fmt.Fprint(w, `{"data": {
+			"r0": {"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [{"name": "main", "target": {"oid": "new-sha"}}]}},
+			"r1": {"refs": {"pageInfo": {"hasNextPage": false}, "nodes": [{"name": "main", "target": {"oid": "same-sha"}}]}}
+		}}`)
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "branch_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetRepoStates", testutils.MockAny, "one").Return([]*types.RepoState{{Repository: "one", Branch: "main", CommitSHA: "old-sha"}}, nil)
+	mockStorage.On("GetRepoStates", testutils.MockAny, "two").Return([]*types.RepoState{{Repository: "two", Branch: "main", CommitSHA: "same-sha"}}, nil)
+	mockStorage.On("UpsertRepoState", testutils.MockAny, testutils.MockAny).Return(nil)
+
+	monitor := NewBranchMonitor(mockStorage, gitclient.NewClientFactory(testLogger), testLogger)
+
+	repos := []types.Repository{
+		{Name: "one", URL: "https://github.com/octo/one", Provider: "github", Token: "token", APIBaseURL: server.URL},
+		{Name: "two", URL: "https://github.com/octo/two", Provider: "github", Token: "token", APIBaseURL: server.URL},
+		{Name: "lab", URL: "https://gitlab.com/group/lab", Provider: "gitlab", Token: "token"},
+	}
+
+	prefetched := monitor.PrefetchBranches(context.Background(), repos, 10)
+	assert.Equal(t, 2, prefetched)
+	assert.Equal(t, 1, graphQLRequests, "both GitHub repositories share one query")
+
+	changes, err := monitor.CheckBranches(context.Background(), repos[0])
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, ChangeTypeUpdated, changes[0].ChangeType)
+	assert.Equal(t, "new-sha", changes[0].NewCommitSHA)
+
+	changes, err = monitor.CheckBranches(context.Background(), repos[1])
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// Prefetched listings are consumed by the poll that uses them
+	_, ok := monitor.takePrefetched("one")
+	assert.False(t, ok)
+}
+
+func TestBranchMonitor_CheckTags(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/one/branches":
+			fmt.Fprint(w, `[{"name":"main","commit":{"sha":"main-sha"}}]`)
+		case "/repos/octo/one/tags":
+			fmt.Fprint(w, `[
+				{"name":"v1.1.0","commit":{"sha":"new-sha"}},
+				{"name":"v1.0.0","commit":{"sha":"moved-sha"}},
+				{"name":"nightly","commit":{"sha":"main-sha"}}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "branch_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetRepoStates", testutils.MockAny, "one").Return([]*types.RepoState{{Repository: "one", Branch: "main", CommitSHA: "main-sha"}}, nil)
+	mockStorage.On("UpsertRepoState", testutils.MockAny, testutils.MockAny).Return(nil)
+	mockStorage.On("GetTagStates", testutils.MockAny, "one").Return([]storage.TagState{
+		{Repository: "one", Tag: "v1.0.0", CommitSHA: "old-sha"},
+		{Repository: "one", Tag: "v0.9.0", CommitSHA: "gone-sha"},
+	}, nil)
+	mockStorage.On("UpsertTagState", testutils.MockAny, testutils.MockAny).Return(nil)
+	mockStorage.On("DeleteTagState", testutils.MockAny, "one", "v0.9.0").Return(nil)
+
+	monitor := NewBranchMonitor(mockStorage, gitclient.NewClientFactory(testLogger), testLogger)
+
+	repo := types.Repository{
+		Name:       "one",
+		URL:        "https://github.com/octo/one",
+		Provider:   "github",
+		Token:      "token",
+		APIBaseURL: server.URL,
+		TagRegex:   `^v`,
+	}
+
+	changes, err := monitor.CheckBranches(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, changes, 3, "unchanged branch and non-matching tag produce no changes")
+
+	byTag := make(map[string]BranchChange)
+	for _, change := range changes {
+		assert.Equal(t, RefTypeTag, change.RefType)
+		byTag[change.Branch] = change
+	}
+	assert.Equal(t, ChangeTypeNew, byTag["v1.1.0"].ChangeType)
+	assert.Equal(t, ChangeTypeUpdated, byTag["v1.0.0"].ChangeType)
+	assert.Equal(t, "old-sha", byTag["v1.0.0"].OldCommitSHA)
+	assert.Equal(t, ChangeTypeDeleted, byTag["v0.9.0"].ChangeType)
+
+	mockStorage.AssertNumberOfCalls(t, "UpsertTagState", 2)
+	mockStorage.AssertCalled(t, "DeleteTagState", testutils.MockAny, "one", "v0.9.0")
+}
+
+func TestBranchMonitor_IncompleteTagListing(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/one/branches":
+			fmt.Fprint(w, `[{"name":"main","commit":{"sha":"main-sha"}}]`)
+		case "/repos/octo/one/tags":
+			// Every page advertises another, so the listing stops at the page cap
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/octo/one/tags?page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"name":"v1.1.0","commit":{"sha":"new-sha"}}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "branch_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetRepoStates", testutils.MockAny, "one").Return([]*types.RepoState{{Repository: "one", Branch: "main", CommitSHA: "main-sha"}}, nil)
+	mockStorage.On("UpsertRepoState", testutils.MockAny, testutils.MockAny).Return(nil)
+	mockStorage.On("GetTagStates", testutils.MockAny, "one").Return([]storage.TagState{
+		{Repository: "one", Tag: "v0.9.0", CommitSHA: "old-sha"},
+	}, nil)
+	mockStorage.On("UpsertTagState", testutils.MockAny, testutils.MockAny).Return(nil)
+
+	factory := gitclient.NewClientFactory(testLogger)
+	factory.SetPagination(1, 1)
+	monitor := NewBranchMonitor(mockStorage, factory, testLogger)
+
+	repo := types.Repository{
+		Name:       "one",
+		URL:        "https://github.com/octo/one",
+		Provider:   "github",
+		Token:      "token",
+		APIBaseURL: server.URL,
+		TagRegex:   `^v`,
+	}
+
+	// The listed tag is new, the stored one may be on a page that was not fetched
+	changes, err := monitor.CheckBranches(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "v1.1.0", changes[0].Branch)
+	assert.Equal(t, ChangeTypeNew, changes[0].ChangeType)
+	mockStorage.AssertNotCalled(t, "DeleteTagState", testutils.MockAny, testutils.MockAny, testutils.MockAny)
+}
+
+func TestBranchMonitor_TakePrefetchedExpires(t *testing.T) {
+	monitor := NewBranchMonitor(nil, nil, logger.GetDefaultLogger().WithField("test", "branch_monitor"))
+	monitor.prefetched["stale"] = prefetchedBranches{
+		branches:  []types.Branch{{Name: "main"}},
+		fetchedAt: time.Now().Add(-2 * prefetchMaxAge),
+	}
+
+	_, ok := monitor.takePrefetched("stale")
+	assert.False(t, ok)
+}
+
+func TestGraphQLBatchKey(t *testing.T) {
+	withToken := types.Repository{URL: "https://github.com/octo/one", Token: "a"}
+	sameToken := types.Repository{URL: "https://github.com/other/two", Token: "a"}
+	assert.Equal(t, graphQLBatchKey(withToken), graphQLBatchKey(sameToken))
+
+	// App-authenticated repositories are grouped per owner
+	appOne := types.Repository{URL: "https://github.com/octo/one"}
+	appOther := types.Repository{URL: "https://github.com/other/two"}
+	assert.NotEqual(t, graphQLBatchKey(appOne), graphQLBatchKey(appOther))
+}
+
+func TestBranchMonitor_OpenCircuitKeepsBranches(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "branch_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetRepoStates", testutils.MockAny, "one").Return([]*types.RepoState{
+		{Repository: "one", Branch: "main", CommitSHA: "main-sha"},
+		{Repository: "one", Branch: "dev", CommitSHA: "dev-sha"},
+	}, nil)
+
+	factory := gitclient.NewClientFactory(testLogger)
+	factory.SetCircuitBreaker(types.CircuitBreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour})
+	monitor := NewBranchMonitor(mockStorage, factory, testLogger)
+
+	repo := types.Repository{Name: "one", URL: "https://github.com/octo/one", Provider: "github", Token: "token", APIBaseURL: server.URL}
+
+	// The first failure opens the circuit, so the retry fails fast instead of
+	// falling back to git
+	for i := 0; i < 2; i++ {
+		changes, err := monitor.CheckBranches(context.Background(), repo)
+		require.Error(t, err)
+		assert.True(t, gitclient.IsCircuitOpen(err))
+		assert.Empty(t, changes)
+	}
+
+	assert.Equal(t, 1, requests)
+	mockStorage.AssertNotCalled(t, "DeleteRepoState", testutils.MockAny, testutils.MockAny, testutils.MockAny)
+}
+
+func TestBranchMonitor_IncompleteListingKeepsBranches(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every page advertises another, so the listing stops at the page cap
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/octo/one/branches?page=2>; rel="next"`, server.URL))
+		fmt.Fprint(w, `[{"name":"main","commit":{"sha":"new-sha"}}]`)
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "branch_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetRepoStates", testutils.MockAny, "one").Return([]*types.RepoState{
+		{Repository: "one", Branch: "main", CommitSHA: "main-sha"},
+		{Repository: "one", Branch: "dev", CommitSHA: "dev-sha"},
+	}, nil)
+	mockStorage.On("UpsertRepoState", testutils.MockAny, testutils.MockAny).Return(nil)
+
+	factory := gitclient.NewClientFactory(testLogger)
+	factory.SetPagination(1, 1)
+	monitor := NewBranchMonitor(mockStorage, factory, testLogger)
+
+	repo := types.Repository{Name: "one", URL: "https://github.com/octo/one", Provider: "github", Token: "token", APIBaseURL: server.URL}
+
+	// Branches that were listed are still checked, but a branch past the cap
+	// is not reported as deleted
+	changes, err := monitor.CheckBranches(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "main", changes[0].Branch)
+	assert.Equal(t, ChangeTypeUpdated, changes[0].ChangeType)
+	mockStorage.AssertNotCalled(t, "DeleteRepoState", testutils.MockAny, testutils.MockAny, testutils.MockAny)
+}
+
+func TestBranchMonitor_DefaultBranchOnly(t *testing.T) {
+	repoRequests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/one":
+			repoRequests++
+			fmt.Fprint(w, `{"full_name":"octo/one","default_branch":"trunk"}`)
+		case "/repos/octo/one/branches":
+			fmt.Fprint(w, `[{"name":"main","commit":{"sha":"main-sha"}},{"name":"trunk","commit":{"sha":"trunk-sha"}}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "branch_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetRepoStates", testutils.MockAny, "one").Return([]*types.RepoState{}, nil)
+	mockStorage.On("UpsertRepoState", testutils.MockAny, testutils.MockAny).Return(nil)
+
+	monitor := NewBranchMonitor(mockStorage, gitclient.NewClientFactory(testLogger), testLogger)
+
+	repo := types.Repository{
+		Name:       "one",
+		URL:        "https://github.com/octo/one",
+		Provider:   "github",
+		Token:      "token",
+		APIBaseURL: server.URL,
+		Branches:   types.BranchesDefault,
+	}
+
+	changes, err := monitor.CheckBranches(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "trunk", changes[0].Branch)
+	assert.True(t, changes[0].Default)
+
+	// The default branch is cached between polls
+	resolved, err := monitor.ResolveDefaultBranch(context.Background(), repo)
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", resolved.DefaultBranch)
+	assert.Equal(t, 1, repoRequests)
+
+	// A configured default branch is used without asking the provider
+	repo.DefaultBranch = "main"
+	changes, err = monitor.CheckBranches(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "main", changes[0].Branch)
+	assert.Equal(t, 1, repoRequests)
+}
@@ -0,0 +1,113 @@
+package poller
+
+import (
+	"encoding/json"
+
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
+)
+
+// Path filter outcomes recorded in event metadata under "path_filter"
+const (
+	PathFilterMatched     = "matched"
+	PathFilterSuppressed  = "suppressed"
+	PathFilterUnavailable = "unavailable" // Changed files unknown, event dispatched unfiltered
+)
+
+// applyPathFilters checks events against the repository's include_paths and
+// exclude_paths and returns the events to dispatch. Events whose changes touch no
+// selected path are marked suppressed and left out. Events without a changed-file
+// list, such as new branches, failed comparisons or comparisons too large for the
+// provider to list every file, are dispatched unfiltered.
+func (p *PollerImpl) applyPathFilters(repo types.Repository, events []types.Event) []types.Event {
+	if !repo.HasPathFilters() {
+		return events
+	}
+
+	dispatch := make([]types.Event, 0, len(events))
+	for i := range events {
+		event := &events[i]
+
+		raw, ok := event.Metadata["changed_files"]
+		if !ok {
+			if compareBase(*event) != "" {
+				event.Metadata["path_filter"] = PathFilterUnavailable
+				p.logger.WithFields(logger.Fields{
+					"operation":  "apply_path_filters",
+					"repository": repo.Name,
+					"event_id":   event.ID,
+				}).Warn("Changed files unavailable, dispatching event without path filtering")
+			}
+			dispatch = append(dispatch, *event)
+			continue
+		}
+
+		var files changedFiles
+		if err := json.Unmarshal([]byte(raw), &files); err != nil {
+			event.Metadata["path_filter"] = PathFilterUnavailable
+			dispatch = append(dispatch, *event)
+			continue
+		}
+
+		matched := matchChangedPaths(repo, files)
+		if len(matched) == 0 {
+			event.Status = types.EventStatusSuppressed
+			event.Metadata["path_filter"] = PathFilterSuppressed
+
+			p.logger.WithFields(logger.Fields{
+				"operation":  "apply_path_filters",
+				"repository": repo.Name,
+				"event_id":   event.ID,
+				"event_type": event.Type,
+				"branch":     event.Branch,
+				"commit_sha": event.CommitSHA,
+			}).Info("Suppressed event: no changed paths match the path filters")
+			continue
+		}
+
+		encoded, err := json.Marshal(matched)
+		if err != nil {
+			dispatch = append(dispatch, *event)
+			continue
+		}
+		event.Metadata["path_filter"] = PathFilterMatched
+		event.Metadata["matched_paths"] = string(encoded)
+		dispatch = append(dispatch, *event)
+	}
+
+	return dispatch
+}
+
+// matchChangedPaths returns the changed files selected by the repository's path
+// filters. A file is selected when it matches an include pattern (or none are set)
+// and matches no exclude pattern.
+func matchChangedPaths(repo types.Repository, files changedFiles) []string {
+	var matched []string
+	for _, list := range [][]string{files.Added, files.Modified, files.Removed} {
+		for _, file := range list {
+			if pathSelected(repo, file) {
+				matched = append(matched, file)
+			}
+		}
+	}
+	return matched
+}
+
+// pathSelected reports whether a single file passes the repository's path filters
+func pathSelected(repo types.Repository, file string) bool {
+	for _, pattern := range repo.ExcludePaths {
+		if utils.MatchGlob(pattern, file) {
+			return false
+		}
+	}
+	if len(repo.IncludePaths) == 0 {
+		return true
+	}
+	for _, pattern := range repo.IncludePaths {
+		if utils.MatchGlob(pattern, file) {
+			return true
+		}
+	}
+	return false
+}
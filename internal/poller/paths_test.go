@@ -0,0 +1,74 @@
+package poller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestPoller_ApplyPathFilters(t *testing.T) {
+	testLogger := logger.GetDefaultLogger().WithField("test", "path_filters")
+	p := NewPoller(GetDefaultPollerConfig(), testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	repo := types.Repository{
+		Name:         "monorepo",
+		IncludePaths: []string{"services/api/**", "go.mod"},
+		ExcludePaths: []string{"**/*.md"},
+	}
+
+	events := []types.Event{
+		{
+			ID: "api", CommitSHA: "b", PrevCommit: "a", Status: types.EventStatusPending,
+			Metadata: map[string]string{
+				"changed_files": `{"added": ["services/api/handler.go"], "removed": [], "modified": ["services/web/app.ts"]}`,
+			},
+		},
+		{
+			ID: "docs", CommitSHA: "c", PrevCommit: "b", Status: types.EventStatusPending,
+			Metadata: map[string]string{
+				"changed_files": `{"added": [], "removed": [], "modified": ["services/api/README.md", "services/web/app.ts"]}`,
+			},
+		},
+		{
+			ID: "new-branch", CommitSHA: "d", Status: types.EventStatusPending,
+			Metadata: map[string]string{},
+		},
+		{
+			ID: "compare-failed", CommitSHA: "f", PrevCommit: "e", Status: types.EventStatusPending,
+			Metadata: map[string]string{},
+		},
+	}
+
+	dispatch := p.applyPathFilters(repo, events)
+
+	require.Len(t, dispatch, 3)
+	assert.Equal(t, "api", dispatch[0].ID)
+	assert.Equal(t, "new-branch", dispatch[1].ID)
+	assert.Equal(t, "compare-failed", dispatch[2].ID)
+
+	assert.Equal(t, PathFilterMatched, events[0].Metadata["path_filter"])
+	assert.Equal(t, `["services/api/handler.go"]`, events[0].Metadata["matched_paths"])
+
+	assert.Equal(t, types.EventStatusSuppressed, events[1].Status)
+	assert.Equal(t, PathFilterSuppressed, events[1].Metadata["path_filter"])
+
+	assert.NotContains(t, events[2].Metadata, "path_filter", "events without a commit range are not filtered")
+	assert.Equal(t, PathFilterUnavailable, events[3].Metadata["path_filter"])
+}
+
+func TestPoller_ApplyPathFiltersWithoutFilters(t *testing.T) {
+	testLogger := logger.GetDefaultLogger().WithField("test", "path_filters")
+	p := NewPoller(GetDefaultPollerConfig(), testutils.NewMockStorage(), gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+
+	events := []types.Event{{ID: "any", Metadata: map[string]string{"changed_files": `{"modified": ["README.md"]}`}}}
+	dispatch := p.applyPathFilters(types.Repository{Name: "repo"}, events)
+
+	assert.Equal(t, events, dispatch)
+	assert.NotContains(t, events[0].Metadata, "path_filter")
+}
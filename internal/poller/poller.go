@@ -2,6 +2,7 @@ package poller
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/johnnynv/RepoSentry/pkg/types"
@@ -26,6 +27,9 @@ type Poller interface {
 
 	// GetScheduler returns the scheduler instance
 	GetScheduler() Scheduler
+
+	// PollNow polls a scheduled repository immediately, outside its schedule
+	PollNow(ctx context.Context, repoName string) (*PollResult, error)
 }
 
 // BranchMonitor defines the interface for monitoring repository branches
@@ -40,6 +44,27 @@ type BranchMonitor interface {
 	UpdateLastCheck(repo types.Repository, checkTime time.Time) error
 }
 
+// PullRequestMonitor defines the interface for monitoring repository pull requests
+type PullRequestMonitor interface {
+	// CheckPullRequests detects opened, updated, closed and merged pull requests
+	CheckPullRequests(ctx context.Context, repo types.Repository) ([]PullRequestChange, error)
+}
+
+// BranchPrefetcher is implemented by branch monitors that can list the branches of
+// many repositories at once ahead of their individual polls
+type BranchPrefetcher interface {
+	// PrefetchBranches fetches branches for a set of repositories in batches and
+	// returns how many repositories were prefetched
+	PrefetchBranches(ctx context.Context, repos []types.Repository, batchSize int) int
+}
+
+// DefaultBranchResolver is implemented by branch monitors that can look up a
+// repository's default branch
+type DefaultBranchResolver interface {
+	// ResolveDefaultBranch returns the repository with DefaultBranch filled in
+	ResolveDefaultBranch(ctx context.Context, repo types.Repository) (types.Repository, error)
+}
+
 // EventGenerator defines the interface for generating events from repository changes
 type EventGenerator interface {
 	// GenerateEvents creates events from branch changes
@@ -47,6 +72,9 @@ type EventGenerator interface {
 
 	// FilterChanges applies repository-specific filtering to changes
 	FilterChanges(repo types.Repository, changes []BranchChange) ([]BranchChange, error)
+
+	// GeneratePullRequestEvents creates events from pull request changes
+	GeneratePullRequestEvents(ctx context.Context, repo types.Repository, changes []PullRequestChange) ([]types.Event, error)
 }
 
 // Scheduler defines the interface for managing polling schedules
@@ -71,19 +99,61 @@ type Scheduler interface {
 
 	// GetScheduledRepositories returns all currently scheduled repositories
 	GetScheduledRepositories() []ScheduledRepository
+
+	// DuePolls claims the repositories to poll now, deferring polls that would
+	// overrun their API budget
+	DuePolls(now time.Time) []types.Repository
+
+	// RecordPollCost records the API requests a poll of a repository made
+	RecordPollCost(repoName string, requests int64)
+
+	// RecordPollResult records the outcome of a poll, backing off and
+	// eventually quarantining repositories that keep failing
+	RecordPollResult(repoName string, pollErr error)
+
+	// Unquarantine resumes polling a quarantined repository
+	Unquarantine(repoName string) error
+
+	// ClaimPoll claims an on-demand poll of a repository outside its schedule,
+	// within the repository's API budget
+	ClaimPoll(repoName string, now time.Time) (types.Repository, error)
+
+	// GetBudgets returns the API budgets shared by scheduled repositories
+	GetBudgets(now time.Time) []PollBudget
+
+	// NextDue returns when the earliest enabled repository is due for polling
+	NextDue() (time.Time, bool)
+
+	// Due returns a channel that receives when repositories are due for polling
+	Due() <-chan struct{}
+
+	// Blackout returns the blackout window a repository is in at now, if any
+	Blackout(repoName string, now time.Time) (BlackoutState, bool)
 }
 
 // PollResult represents the result of polling a repository
 type PollResult struct {
-	Repository   types.Repository `json:"repository"`
-	Success      bool             `json:"success"`
-	Error        error            `json:"error,omitempty"`
-	BranchCount  int              `json:"branch_count"`
-	Changes      []BranchChange   `json:"changes"`
-	Events       []types.Event    `json:"events"`
-	Duration     time.Duration    `json:"duration"`
-	Timestamp    time.Time        `json:"timestamp"`
-	UsedFallback bool             `json:"used_fallback"`
+	Repository   types.Repository    `json:"repository"`
+	Success      bool                `json:"success"`
+	Error        error               `json:"error,omitempty"`
+	BranchCount  int                 `json:"branch_count"`
+	Changes      []BranchChange      `json:"changes"`
+	PullRequests []PullRequestChange `json:"pull_request_changes,omitempty"`
+	Events       []types.Event       `json:"events"`
+	Duration     time.Duration       `json:"duration"`
+	Timestamp    time.Time           `json:"timestamp"`
+	UsedFallback bool                `json:"used_fallback"`
+	Blackout     *BlackoutState      `json:"blackout,omitempty"` // Blackout window the poll fell in
+}
+
+// PollInProgressError is returned for on-demand polls of a repository that is
+// already being polled
+type PollInProgressError struct {
+	Repository string
+}
+
+func (e *PollInProgressError) Error() string {
+	return fmt.Sprintf("repository %s is already being polled", e.Repository)
 }
 
 // BranchChange represents a change detected in a repository branch
@@ -95,6 +165,17 @@ type BranchChange struct {
 	ChangeType   string    `json:"change_type"` // new, updated, deleted
 	Timestamp    time.Time `json:"timestamp"`
 	Protected    bool      `json:"protected"`
+	Default      bool      `json:"default"`            // The branch is the repository's default branch
+	RefType      string    `json:"ref_type,omitempty"` // branch (default) or tag; Branch holds the tag name for tags
+}
+
+// PullRequestChange represents a change detected in a repository pull request
+type PullRequestChange struct {
+	Repository  string            `json:"repository"`
+	PullRequest types.PullRequest `json:"pull_request"`
+	OldHeadSHA  string            `json:"old_head_sha,omitempty"`
+	ChangeType  string            `json:"change_type"` // opened, updated, closed, merged
+	Timestamp   time.Time         `json:"timestamp"`
 }
 
 // PollerStatus represents the current status of the poller
@@ -106,20 +187,35 @@ type PollerStatus struct {
 	WorkerCount        int                `json:"worker_count"`
 	QueueSize          int                `json:"queue_size"`
 	Repositories       []RepositoryStatus `json:"repositories"`
+	DeferredPolls      int64              `json:"deferred_polls"`    // Polls postponed to save API budget
+	Budgets            []PollBudget       `json:"budgets,omitempty"` // API budgets shared by the repositories
 }
 
 // RepositoryStatus represents the status of a specific repository
 type RepositoryStatus struct {
-	Name         string    `json:"name"`
-	Provider     string    `json:"provider"`
-	Enabled      bool      `json:"enabled"`
-	LastPollTime time.Time `json:"last_poll_time,omitempty"`
-	NextPollTime time.Time `json:"next_poll_time,omitempty"`
-	LastSuccess  bool      `json:"last_success"`
-	LastError    string    `json:"last_error,omitempty"`
-	PollCount    int64     `json:"poll_count"`
-	ChangeCount  int64     `json:"change_count"`
-	EventCount   int64     `json:"event_count"`
+	Name         string        `json:"name"`
+	Provider     string        `json:"provider"`
+	Enabled      bool          `json:"enabled"`
+	Interval     time.Duration `json:"interval"`
+	LastPollTime time.Time     `json:"last_poll_time,omitempty"`
+	NextPollTime time.Time     `json:"next_poll_time,omitempty"`
+	LastSuccess  bool          `json:"last_success"`
+	LastError    string        `json:"last_error,omitempty"`
+	PollCount    int64         `json:"poll_count"`
+	ChangeCount  int64         `json:"change_count"`
+	EventCount   int64         `json:"event_count"`
+
+	Priority      string  `json:"priority,omitempty"`
+	EstimatedCost float64 `json:"estimated_cost"` // Average API requests per poll
+	DeferredPolls int64   `json:"deferred_polls"`
+
+	Schedule string         `json:"schedule,omitempty"` // Cron expression polls follow
+	Blackout *BlackoutState `json:"blackout,omitempty"` // Blackout window in effect
+
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	FailureReason       string    `json:"failure_reason,omitempty"` // auth, not_found, network, rate_limit or unknown
+	Quarantined         bool      `json:"quarantined"`
+	QuarantinedAt       time.Time `json:"quarantined_at,omitempty"`
 }
 
 // PollerMetrics represents polling performance metrics
@@ -134,6 +230,13 @@ type PollerMetrics struct {
 	Uptime              time.Duration `json:"uptime"`
 	APICallCount        int64         `json:"api_call_count"`
 	FallbackCount       int64         `json:"fallback_count"`
+	CacheHits           int64         `json:"cache_hits"`   // Conditional requests answered with 304 Not Modified
+	CacheMisses         int64         `json:"cache_misses"` // Conditional requests that downloaded a full response
+	QueueDepth          int           `json:"queue_depth"`  // Repositories waiting for a worker
+	QueueWaitAverage    time.Duration `json:"queue_wait_average"`
+	QueueWaitMax        time.Duration `json:"queue_wait_max"`
+	DedupedPolls        int64         `json:"deduped_polls"` // Polls merged into one already queued for the repository
+	SkippedPolls        int64         `json:"skipped_polls"` // Polls dropped because the repository was already being polled
 }
 
 // PollerConfig represents configuration for the poller
@@ -145,18 +248,61 @@ type PollerConfig struct {
 	EnableFallback bool          `yaml:"enable_fallback" json:"enable_fallback"`
 	RetryAttempts  int           `yaml:"retry_attempts" json:"retry_attempts"`
 	RetryBackoff   time.Duration `yaml:"retry_backoff" json:"retry_backoff"`
+
+	// GitHubGraphQL fetches GitHub branch heads for a batch of repositories per GraphQL query
+	GitHubGraphQL    bool `yaml:"github_graphql" json:"github_graphql"`
+	GraphQLBatchSize int  `yaml:"graphql_batch_size" json:"graphql_batch_size"`
+
+	// MaxCommits caps the commits attached to an event (0 = DefaultMaxCommits, negative disables)
+	MaxCommits int `yaml:"max_commits" json:"max_commits"`
+
+	// Jitter is the fraction of a repository's interval its poll times vary by
+	// at random (0 or negative disables), and Splay the window first polls are
+	// spread over after scheduling (0 = the repository's interval)
+	Jitter float64       `yaml:"jitter" json:"jitter"`
+	Splay  time.Duration `yaml:"splay" json:"splay"`
+
+	// Schedule, BlackoutWindows and BlackoutAction apply to every repository.
+	// A repository's own schedule and action replace these, and its blackout
+	// windows are added to them.
+	Schedule        *types.PollSchedule    `yaml:"schedule,omitempty" json:"schedule,omitempty"`
+	BlackoutWindows []types.BlackoutWindow `yaml:"blackout_windows,omitempty" json:"blackout_windows,omitempty"`
+	BlackoutAction  string                 `yaml:"blackout_action,omitempty" json:"blackout_action,omitempty"`
+
+	// MaxBackoff caps the delay between polls of a repository that keeps
+	// failing, and QuarantineAfter is the number of failures in a row after
+	// which it is no longer polled (0 or negative never quarantines)
+	MaxBackoff      time.Duration `yaml:"max_backoff" json:"max_backoff"`
+	QuarantineAfter int           `yaml:"quarantine_after" json:"quarantine_after"`
 }
 
+// DefaultGraphQLBatchSize is the number of repositories queried per GraphQL request
+const DefaultGraphQLBatchSize = 50
+
+// DefaultJitter is the fraction of the interval poll times vary by
+const DefaultJitter = 0.1
+
+// Failure backoff defaults
+const (
+	DefaultMaxBackoff      = time.Hour
+	DefaultQuarantineAfter = 10
+)
+
 // GetDefaultPollerConfig returns default poller configuration
 func GetDefaultPollerConfig() PollerConfig {
 	return PollerConfig{
-		Interval:       5 * time.Minute,
-		Timeout:        30 * time.Second,
-		MaxWorkers:     5,
-		BatchSize:      10,
-		EnableFallback: true,
-		RetryAttempts:  3,
-		RetryBackoff:   1 * time.Second,
+		Interval:         5 * time.Minute,
+		Timeout:          30 * time.Second,
+		MaxWorkers:       5,
+		BatchSize:        10,
+		EnableFallback:   true,
+		RetryAttempts:    3,
+		RetryBackoff:     1 * time.Second,
+		GraphQLBatchSize: DefaultGraphQLBatchSize,
+		MaxCommits:       DefaultMaxCommits,
+		Jitter:           DefaultJitter,
+		MaxBackoff:       DefaultMaxBackoff,
+		QuarantineAfter:  DefaultQuarantineAfter,
 	}
 }
 
@@ -167,6 +313,20 @@ const (
 	ChangeTypeDeleted = "deleted"
 )
 
+// Pull request change type constants
+const (
+	PullRequestOpened  = "opened"
+	PullRequestUpdated = "updated"
+	PullRequestClosed  = "closed"
+	PullRequestMerged  = "merged"
+)
+
+// RefType constants
+const (
+	RefTypeBranch = "branch"
+	RefTypeTag    = "tag"
+)
+
 // Validation functions
 func (pr *PollResult) IsValid() bool {
 	return pr.Repository.Name != "" && pr.Repository.Provider != ""
@@ -187,3 +347,7 @@ func (bc *BranchChange) IsUpdated() bool {
 func (bc *BranchChange) IsDeleted() bool {
 	return bc.ChangeType == ChangeTypeDeleted
 }
+
+func (bc *BranchChange) IsTag() bool {
+	return bc.RefType == RefTypeTag
+}
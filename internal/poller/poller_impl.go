@@ -16,24 +16,30 @@ import (
 
 // PollerImpl implements the Poller interface
 type PollerImpl struct {
-	config         PollerConfig
-	storage        storage.Storage
-	branchMonitor  BranchMonitor
-	eventGenerator EventGenerator
-	scheduler      Scheduler
-	clientFactory  *gitclient.ClientFactory
-	trigger        trigger.Trigger
-	tektonManager  *tekton.TektonTriggerManager // Added Tekton integration
-	logger         *logger.Entry
+	config             PollerConfig
+	storage            storage.Storage
+	branchMonitor      BranchMonitor
+	pullRequestMonitor PullRequestMonitor
+	eventGenerator     EventGenerator
+	scheduler          Scheduler
+	clientFactory      *gitclient.ClientFactory
+	trigger            trigger.Trigger
+	tektonManager      *tekton.TektonTriggerManager // Added Tekton integration
+	logger             *logger.Entry
 
 	// Runtime state
 	mu        sync.RWMutex
 	running   bool
 	startTime time.Time
 	stopChan  chan struct{}
-	workQueue chan types.Repository
+	workQueue *workQueue
 	workers   []*worker
 	metrics   PollerMetrics
+
+	// Repositories being polled, so scheduled and on-demand polls of the same
+	// repository never overlap
+	pollingMu sync.Mutex
+	polling   map[string]struct{}
 }
 
 // worker represents a polling worker
@@ -48,23 +54,28 @@ func NewPoller(config PollerConfig, storage storage.Storage, clientFactory *gitc
 	branchMonitor := NewBranchMonitor(storage, clientFactory, parentLogger)
 	eventGenerator := NewEventGenerator(parentLogger)
 	scheduler := NewScheduler(config, parentLogger)
+	if clientFactory != nil {
+		scheduler.SetRateLimitSource(clientFactory)
+	}
 
 	poller := &PollerImpl{
-		config:         config,
-		storage:        storage,
-		branchMonitor:  branchMonitor,
-		eventGenerator: eventGenerator,
-		scheduler:      scheduler,
-		clientFactory:  clientFactory,
-		trigger:        trigger,
-		tektonManager:  tektonManager,
+		config:             config,
+		storage:            storage,
+		branchMonitor:      branchMonitor,
+		pullRequestMonitor: NewPullRequestMonitor(storage, clientFactory, parentLogger),
+		eventGenerator:     eventGenerator,
+		scheduler:          scheduler,
+		clientFactory:      clientFactory,
+		trigger:            trigger,
+		tektonManager:      tektonManager,
 		logger: parentLogger.WithFields(logger.Fields{
 			"component": "poller",
 			"module":    "poller_impl",
 		}),
 
 		stopChan:  make(chan struct{}),
-		workQueue: make(chan types.Repository, config.BatchSize*2), // Buffer for work queue
+		workQueue: newWorkQueue(),
+		polling:   make(map[string]struct{}),
 		metrics: PollerMetrics{
 			LastResetTime: time.Now(),
 		},
@@ -140,8 +151,8 @@ func (p *PollerImpl) Stop(ctx context.Context) error {
 	// Signal stop to all workers
 	close(p.stopChan)
 
-	// Close work queue
-	close(p.workQueue)
+	// Close work queue, failing on-demand polls still waiting in it
+	p.workQueue.close()
 
 	p.logger.Info("Poller stopped")
 	return nil
@@ -162,6 +173,56 @@ func (p *PollerImpl) PollRepository(ctx context.Context, repo types.Repository)
 		Timestamp:  startTime,
 	}
 
+	// Changes made during a queueing blackout are left for the first poll
+	// after the window ends
+	blackout, inBlackout := p.scheduler.Blackout(repo.Name, startTime)
+	if inBlackout && blackout.Action == types.BlackoutActionQueue {
+		result.Success = true
+		result.Blackout = &blackout
+		result.Duration = time.Since(startTime)
+
+		p.logger.WithFields(logger.Fields{
+			"operation":  "poll_repository",
+			"repository": repo.Name,
+			"window":     blackout.Window,
+			"ends":       blackout.Ends.Format(time.RFC3339),
+		}).Info("Skipped poll during blackout window")
+		return result, nil
+	}
+
+	// Measure the poll's API usage for budget planning
+	ctx, requests := gitclient.WithRequestCounter(ctx)
+	defer func() {
+		p.scheduler.RecordPollCost(repo.Name, requests.Count())
+		p.scheduler.RecordPollResult(repo.Name, result.Error)
+	}()
+
+	// Look up the default branch for filtering and event metadata. Only
+	// default-branch repositories can't be polled without it.
+	if resolver, ok := p.branchMonitor.(DefaultBranchResolver); ok {
+		resolved, err := resolver.ResolveDefaultBranch(ctx, repo)
+		if err != nil && !repo.DefaultBranchOnly() {
+			p.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "poll_repository",
+				"repository": repo.Name,
+			}).Warn("Failed to resolve default branch, continuing without it")
+		} else if err != nil {
+			result.Success = false
+			result.Error = err
+			result.Duration = time.Since(startTime)
+
+			p.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "poll_repository",
+				"repository": repo.Name,
+				"duration":   result.Duration.String(),
+			}).Error("Failed to resolve default branch")
+
+			p.updateMetrics(result)
+			return result, err
+		}
+		repo = resolved
+	}
+
 	// Check for branch changes
 	changes, err := p.branchMonitor.CheckBranches(ctx, repo)
 	if err != nil {
@@ -183,8 +244,9 @@ func (p *PollerImpl) PollRepository(ctx context.Context, repo types.Repository)
 	result.BranchCount = len(changes)
 
 	// Generate events from changes
+	var events []types.Event
 	if len(changes) > 0 {
-		events, err := p.eventGenerator.GenerateEvents(ctx, repo, changes)
+		branchEvents, err := p.eventGenerator.GenerateEvents(ctx, repo, changes)
 		if err != nil {
 			p.logger.WithError(err).WithFields(logger.Fields{
 				"operation":    "poll_repository",
@@ -193,110 +255,113 @@ func (p *PollerImpl) PollRepository(ctx context.Context, repo types.Repository)
 			}).Error("Failed to generate events")
 			// Don't fail the entire poll if event generation fails
 		} else {
-			result.Events = events
-
-			// Store events in storage
-			for _, event := range events {
-				if err := p.storage.CreateEvent(ctx, event); err != nil {
-					p.logger.WithError(err).WithFields(logger.Fields{
-						"operation":  "poll_repository",
-						"repository": repo.Name,
-						"event_id":   event.ID,
-					}).Error("Failed to store event")
-				}
-			}
+			events = branchEvents
+		}
+	}
+
+	// Pull request monitoring is opt-in and its failures don't fail the branch poll
+	if repo.PullRequests {
+		events = append(events, p.pollPullRequests(ctx, repo, result)...)
+	}
 
-			// Process with Tekton if available
-			if p.tektonManager != nil {
-				for _, event := range events {
-					go func(e types.Event) {
-						tektonCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-						defer cancel()
+	if len(events) > 0 {
+		p.enrichCommits(ctx, repo, events)
+		var dispatch []types.Event
+		if inBlackout {
+			result.Blackout = &blackout
+			p.suppressForBlackout(repo, events, blackout)
+		} else {
+			dispatch = p.applyPathFilters(repo, events)
+		}
+		result.Events = events
+
+		// Store events in storage
+		for _, event := range events {
+			if err := p.storage.CreateEvent(ctx, event); err != nil {
+				p.logger.WithError(err).WithFields(logger.Fields{
+					"operation":  "poll_repository",
+					"repository": repo.Name,
+					"event_id":   event.ID,
+				}).Error("Failed to store event")
+			}
+		}
 
-						p.logger.WithFields(logger.Fields{
+		// Process with Tekton if available
+		if p.tektonManager != nil {
+			for _, event := range dispatch {
+				go func(e types.Event) {
+					tektonCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+
+					p.logger.WithFields(logger.Fields{
+						"operation":  "tekton_process",
+						"event_id":   e.ID,
+						"repository": e.Repository,
+						"branch":     e.Branch,
+					}).Info("Processing repository change with Tekton")
+
+					request := tektonRequest(repo, e)
+					tektonResult, err := p.tektonManager.ProcessRepositoryChange(tektonCtx, request)
+					if err != nil {
+						p.logger.WithError(err).WithFields(logger.Fields{
 							"operation":  "tekton_process",
 							"event_id":   e.ID,
 							"repository": e.Repository,
-							"branch":     e.Branch,
-						}).Info("Processing repository change with Tekton")
-
-						// Create Tekton process request
-						request := &tekton.TektonProcessRequest{
-							Repository: types.Repository{
-								Name:        e.Repository,
-								URL:         repo.URL,         // Use the original repo URL
-								Provider:    repo.Provider,    // Use the original repo provider
-								Token:       repo.Token,       // Include the token for authentication
-								APIBaseURL:  repo.APIBaseURL,  // Include API base URL if set
-								BranchRegex: repo.BranchRegex, // Include branch regex for completeness
-								Enabled:     repo.Enabled,     // Include enabled status
-							},
-							CommitSHA: e.CommitSHA,
-							Branch:    e.Branch,
-						}
-
-						tektonResult, err := p.tektonManager.ProcessRepositoryChange(tektonCtx, request)
-						if err != nil {
-							p.logger.WithError(err).WithFields(logger.Fields{
-								"operation":  "tekton_process",
-								"event_id":   e.ID,
-								"repository": e.Repository,
-							}).Error("Tekton processing failed")
-						} else {
-							p.logger.WithFields(logger.Fields{
-								"operation":       "tekton_process",
-								"event_id":        e.ID,
-								"repository":      e.Repository,
-								"detection":       tektonResult.Detection.EstimatedAction,
-								"event_sent":      tektonResult.EventSent,
-								"resources_found": len(tektonResult.Detection.Resources),
-								"has_tekton_dir":  tektonResult.Detection.HasTektonDirectory,
-							}).Info("Tekton processing completed")
-						}
-					}(event)
-				}
+						}).Error("Tekton processing failed")
+					} else {
+						p.logger.WithFields(logger.Fields{
+							"operation":       "tekton_process",
+							"event_id":        e.ID,
+							"repository":      e.Repository,
+							"detection":       tektonResult.Detection.EstimatedAction,
+							"event_sent":      tektonResult.EventSent,
+							"resources_found": len(tektonResult.Detection.Resources),
+							"has_tekton_dir":  tektonResult.Detection.HasTektonDirectory,
+						}).Info("Tekton processing completed")
+					}
+				}(event)
 			}
+		}
 
-			// Fallback to regular trigger if no Tekton manager
-			if p.tektonManager == nil && p.trigger != nil {
-				for _, event := range events {
-					go func(e types.Event) {
-						triggerCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-						defer cancel()
-
-						p.logger.WithFields(logger.Fields{
+		// Fallback to regular trigger if no Tekton manager
+		if p.tektonManager == nil && p.trigger != nil {
+			for _, event := range dispatch {
+				go func(e types.Event) {
+					triggerCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+					defer cancel()
+
+					p.logger.WithFields(logger.Fields{
+						"operation":  "auto_trigger",
+						"event_id":   e.ID,
+						"repository": e.Repository,
+						"branch":     e.Branch,
+					}).Info("Automatically triggering pipeline for event (fallback mode)")
+
+					result, err := p.trigger.SendEvent(triggerCtx, e)
+					if err != nil {
+						p.logger.WithError(err).WithFields(logger.Fields{
 							"operation":  "auto_trigger",
 							"event_id":   e.ID,
 							"repository": e.Repository,
-							"branch":     e.Branch,
-						}).Info("Automatically triggering pipeline for event (fallback mode)")
-
-						result, err := p.trigger.SendEvent(triggerCtx, e)
-						if err != nil {
-							p.logger.WithError(err).WithFields(logger.Fields{
-								"operation":  "auto_trigger",
-								"event_id":   e.ID,
-								"repository": e.Repository,
-							}).Error("Failed to trigger pipeline")
-						} else if result.Success {
-							p.logger.WithFields(logger.Fields{
-								"operation":   "auto_trigger",
-								"event_id":    e.ID,
-								"repository":  e.Repository,
-								"status_code": result.StatusCode,
-								"duration":    result.Duration,
-							}).Info("Successfully triggered pipeline")
-						} else {
-							p.logger.WithFields(logger.Fields{
-								"operation":   "auto_trigger",
-								"event_id":    e.ID,
-								"repository":  e.Repository,
-								"status_code": result.StatusCode,
-								"error":       result.Error,
-							}).Error("Pipeline trigger failed")
-						}
-					}(event)
-				}
+						}).Error("Failed to trigger pipeline")
+					} else if result.Success {
+						p.logger.WithFields(logger.Fields{
+							"operation":   "auto_trigger",
+							"event_id":    e.ID,
+							"repository":  e.Repository,
+							"status_code": result.StatusCode,
+							"duration":    result.Duration,
+						}).Info("Successfully triggered pipeline")
+					} else {
+						p.logger.WithFields(logger.Fields{
+							"operation":   "auto_trigger",
+							"event_id":    e.ID,
+							"repository":  e.Repository,
+							"status_code": result.StatusCode,
+							"error":       result.Error,
+						}).Error("Pipeline trigger failed")
+					}
+				}(event)
 			}
 		}
 	}
@@ -317,6 +382,127 @@ func (p *PollerImpl) PollRepository(ctx context.Context, repo types.Repository)
 	return result, nil
 }
 
+// PollNow polls a scheduled repository immediately, outside its schedule, and
+// waits for the result. The poll goes to the front of the work queue and
+// draws from the repository's API budget like scheduled ones; it is refused
+// while the repository is already being polled. A nil result means the poll
+// did not run.
+func (p *PollerImpl) PollNow(ctx context.Context, repoName string) (*PollResult, error) {
+	if p.isPolling(repoName) {
+		return nil, &PollInProgressError{Repository: repoName}
+	}
+
+	repo, err := p.scheduler.ClaimPoll(repoName, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	p.logger.WithFields(logger.Fields{
+		"operation":  "poll_now",
+		"repository": repoName,
+	}).Info("Polling repository on demand")
+
+	p.mu.RLock()
+	running := p.running
+	p.mu.RUnlock()
+
+	// Without workers the poll runs in the caller
+	if !running {
+		if !p.beginPoll(repoName) {
+			return nil, &PollInProgressError{Repository: repoName}
+		}
+		defer p.endPoll(repoName)
+
+		pollCtx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+		defer cancel()
+		return p.PollRepository(pollCtx, repo)
+	}
+
+	waiter := make(chan pollOutcome, 1)
+	if err := p.workQueue.pushOnDemand(repo, time.Now(), waiter); err != nil {
+		return nil, err
+	}
+
+	// A caller that stops waiting does not cancel the poll
+	select {
+	case outcome := <-waiter:
+		return outcome.result, outcome.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// isPolling reports whether a poll of a repository is in progress
+func (p *PollerImpl) isPolling(repoName string) bool {
+	p.pollingMu.Lock()
+	defer p.pollingMu.Unlock()
+
+	_, busy := p.polling[repoName]
+	return busy
+}
+
+// beginPoll marks a repository as being polled, returning false when a poll
+// of it is already in progress
+func (p *PollerImpl) beginPoll(repoName string) bool {
+	p.pollingMu.Lock()
+	defer p.pollingMu.Unlock()
+
+	if _, busy := p.polling[repoName]; busy {
+		return false
+	}
+	p.polling[repoName] = struct{}{}
+	return true
+}
+
+// endPoll marks a repository's poll as finished
+func (p *PollerImpl) endPoll(repoName string) {
+	p.pollingMu.Lock()
+	defer p.pollingMu.Unlock()
+
+	delete(p.polling, repoName)
+}
+
+// tektonRequest builds the Tekton process request for an event. The whole
+// repository is forwarded so detection authenticates and connects the way
+// polling does.
+func tektonRequest(repo types.Repository, event types.Event) *tekton.TektonProcessRequest {
+	repo.Name = event.Repository
+	return &tekton.TektonProcessRequest{
+		Repository: repo,
+		CommitSHA:  event.CommitSHA,
+		Branch:     event.Branch,
+		Metadata:   event.Metadata,
+	}
+}
+
+// pollPullRequests checks a repository's pull requests and returns the resulting events
+func (p *PollerImpl) pollPullRequests(ctx context.Context, repo types.Repository, result *PollResult) []types.Event {
+	prChanges, err := p.pullRequestMonitor.CheckPullRequests(ctx, repo)
+	if err != nil {
+		p.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "poll_repository",
+			"repository": repo.Name,
+		}).Error("Failed to check pull requests")
+		return nil
+	}
+	result.PullRequests = prChanges
+
+	if len(prChanges) == 0 {
+		return nil
+	}
+
+	events, err := p.eventGenerator.GeneratePullRequestEvents(ctx, repo, prChanges)
+	if err != nil {
+		p.logger.WithError(err).WithFields(logger.Fields{
+			"operation":    "poll_repository",
+			"repository":   repo.Name,
+			"change_count": len(prChanges),
+		}).Error("Failed to generate pull request events")
+		return nil
+	}
+	return events
+}
+
 // GetStatus returns the current status of the poller
 func (p *PollerImpl) GetStatus() PollerStatus {
 	p.mu.RLock()
@@ -324,28 +510,53 @@ func (p *PollerImpl) GetStatus() PollerStatus {
 
 	schedulerStatus := p.scheduler.GetSchedulerStatus()
 
+	now := time.Now()
 	var repositories []RepositoryStatus
+	var deferredPolls int64
+	var lastPollTime time.Time
 	for _, scheduledRepo := range p.scheduler.GetScheduledRepositories() {
+		if scheduledRepo.LastPollTime.After(lastPollTime) {
+			lastPollTime = scheduledRepo.LastPollTime
+		}
 		repoStatus := RepositoryStatus{
-			Name:         scheduledRepo.Repository.Name,
-			Provider:     scheduledRepo.Repository.Provider,
-			Enabled:      scheduledRepo.Enabled,
-			LastPollTime: scheduledRepo.LastPollTime,
-			NextPollTime: scheduledRepo.NextPollTime,
-			PollCount:    scheduledRepo.PollCount,
-			LastSuccess:  true, // TODO: Track success/failure per repository
+			Name:          scheduledRepo.Repository.Name,
+			Provider:      scheduledRepo.Repository.Provider,
+			Enabled:       scheduledRepo.Enabled,
+			Interval:      scheduledRepo.Interval,
+			LastPollTime:  scheduledRepo.LastPollTime,
+			NextPollTime:  scheduledRepo.NextPollTime,
+			PollCount:     scheduledRepo.PollCount,
+			LastSuccess:   scheduledRepo.LastError == "",
+			LastError:     scheduledRepo.LastError,
+			Priority:      scheduledRepo.Repository.Priority,
+			EstimatedCost: scheduledRepo.pollCost(),
+			DeferredPolls: scheduledRepo.DeferredCount,
+
+			ConsecutiveFailures: scheduledRepo.ConsecutiveFailures,
+			FailureReason:       scheduledRepo.FailureReason,
+			Quarantined:         scheduledRepo.Quarantined,
+			QuarantinedAt:       scheduledRepo.QuarantinedAt,
+		}
+		if scheduledRepo.plan.schedule != nil {
+			repoStatus.Schedule = scheduledRepo.plan.scheduleSpec
+		}
+		if blackout, ok := scheduledRepo.plan.blackout(now); ok {
+			repoStatus.Blackout = &blackout
 		}
 		repositories = append(repositories, repoStatus)
+		deferredPolls += scheduledRepo.DeferredCount
 	}
 
 	return PollerStatus{
 		Running:            p.running,
 		StartTime:          p.startTime,
-		LastPollTime:       time.Now(), // TODO: Track actual last poll time
+		LastPollTime:       lastPollTime,
 		ActiveRepositories: schedulerStatus.EnabledRepositories,
 		WorkerCount:        len(p.workers),
-		QueueSize:          len(p.workQueue),
+		QueueSize:          p.workQueue.Len(),
 		Repositories:       repositories,
+		DeferredPolls:      deferredPolls,
+		Budgets:            p.scheduler.GetBudgets(time.Now()),
 	}
 }
 
@@ -358,6 +569,17 @@ func (p *PollerImpl) GetMetrics() PollerMetrics {
 	if p.running {
 		metrics.Uptime = time.Since(p.startTime)
 	}
+	if p.clientFactory != nil {
+		cacheStats := p.clientFactory.HTTPCacheStats()
+		metrics.CacheHits = cacheStats.Hits
+		metrics.CacheMisses = cacheStats.Misses
+	}
+
+	queueStats := p.workQueue.stats()
+	metrics.QueueDepth = queueStats.Depth
+	metrics.QueueWaitAverage = queueStats.AverageWait
+	metrics.QueueWaitMax = queueStats.MaxWait
+	metrics.DedupedPolls = queueStats.Deduped
 
 	return metrics
 }
@@ -404,13 +626,11 @@ func (p *PollerImpl) RemoveRepository(repo types.Repository) error {
 	return nil
 }
 
-// run is the main polling loop
+// run is the main polling loop, woken by the scheduler whenever repositories
+// fall due
 func (p *PollerImpl) run(ctx context.Context) {
 	p.logger.Info("Poller main loop started")
 
-	ticker := time.NewTicker(p.config.Interval)
-	defer ticker.Stop()
-
 	for {
 		select {
 		case <-ctx.Done():
@@ -419,7 +639,7 @@ func (p *PollerImpl) run(ctx context.Context) {
 		case <-p.stopChan:
 			p.logger.Info("Poller stopped")
 			return
-		case <-ticker.C:
+		case <-p.scheduler.Due():
 			p.processScheduledPolls(ctx)
 		}
 	}
@@ -427,15 +647,8 @@ func (p *PollerImpl) run(ctx context.Context) {
 
 // processScheduledPolls processes repositories that are ready for polling
 func (p *PollerImpl) processScheduledPolls(ctx context.Context) {
-	scheduledRepos := p.scheduler.GetScheduledRepositories()
-	now := time.Now()
-
-	var readyRepos []types.Repository
-	for _, scheduledRepo := range scheduledRepos {
-		if scheduledRepo.Enabled && now.After(scheduledRepo.NextPollTime) {
-			readyRepos = append(readyRepos, scheduledRepo.Repository)
-		}
-	}
+	// Claim due repositories, critical first and within their API budgets
+	readyRepos := p.scheduler.DuePolls(time.Now())
 
 	if len(readyRepos) == 0 {
 		return
@@ -446,22 +659,43 @@ func (p *PollerImpl) processScheduledPolls(ctx context.Context) {
 		"ready_count": len(readyRepos),
 	}).Debug("Processing scheduled polls")
 
-	// Queue repositories for polling
+	// Fetch GitHub branch heads for the whole batch up front. The loop waits
+	// for it, so it gets no longer than a poll; repositories it has not
+	// fetched by then are listed by their own polls.
+	if p.config.GitHubGraphQL {
+		if prefetcher, ok := p.branchMonitor.(BranchPrefetcher); ok {
+			prefetchCtx, cancel := context.WithTimeout(ctx, p.config.Timeout)
+			prefetcher.PrefetchBranches(prefetchCtx, readyRepos, p.config.GraphQLBatchSize)
+			cancel()
+		}
+	}
+
+	// Queue repositories for the workers. The scheduler wakes as polls fall
+	// due, so the time they are queued is the time they became overdue.
+	now := time.Now()
 	for _, repo := range readyRepos {
-		select {
-		case p.workQueue <- repo:
-			// Successfully queued
-		case <-ctx.Done():
+		added, err := p.workQueue.push(repo, now)
+		if err != nil {
 			return
-		default:
+		}
+		if !added {
 			p.logger.WithFields(logger.Fields{
 				"operation":  "process_scheduled_polls",
 				"repository": repo.Name,
-			}).Warn("Work queue is full, skipping repository")
+			}).Debug("Repository is already queued for polling")
 		}
 	}
 }
 
+// recordSkippedPoll counts a poll dropped because its repository was already
+// being polled
+func (p *PollerImpl) recordSkippedPoll() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.metrics.SkippedPolls++
+}
+
 // updateMetrics updates polling metrics
 func (p *PollerImpl) updateMetrics(result *PollResult) {
 	p.mu.Lock()
@@ -504,28 +738,45 @@ func (w *worker) run(ctx context.Context) {
 		case <-w.poller.stopChan:
 			w.logger.Info("Worker stopped")
 			return
-		case repo, ok := <-w.poller.workQueue:
+		case <-w.poller.workQueue.Ready():
+			item, ok := w.poller.workQueue.pop(time.Now())
 			if !ok {
-				w.logger.Info("Work queue closed, worker stopping")
-				return
+				continue
 			}
-			w.processRepository(ctx, repo)
+			w.processRepository(ctx, item)
 		}
 	}
 }
 
-// processRepository processes a single repository
-func (w *worker) processRepository(ctx context.Context, repo types.Repository) {
+// processRepository polls a queued repository and hands the result to any
+// on-demand callers waiting for it
+func (w *worker) processRepository(ctx context.Context, item *workItem) {
+	repo := item.repo
+
 	w.logger.WithFields(logger.Fields{
 		"operation":  "process_repository",
 		"repository": repo.Name,
 		"worker_id":  w.id,
 	}).Debug("Processing repository")
 
+	if !w.poller.beginPoll(repo.Name) {
+		w.poller.recordSkippedPoll()
+		item.deliver(nil, &PollInProgressError{Repository: repo.Name})
+
+		w.logger.WithFields(logger.Fields{
+			"operation":  "process_repository",
+			"repository": repo.Name,
+			"worker_id":  w.id,
+		}).Debug("Repository is already being polled, skipping")
+		return
+	}
+	defer w.poller.endPoll(repo.Name)
+
 	pollCtx, cancel := context.WithTimeout(ctx, w.poller.config.Timeout)
 	defer cancel()
 
-	_, err := w.poller.PollRepository(pollCtx, repo)
+	result, err := w.poller.PollRepository(pollCtx, repo)
+	item.deliver(result, err)
 	if err != nil {
 		w.logger.WithError(err).WithFields(logger.Fields{
 			"operation":  "process_repository",
@@ -1,11 +1,17 @@
 package poller
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPollerConfig_DefaultValues(t *testing.T) {
@@ -371,3 +377,74 @@ func TestBranchChange_Equality(t *testing.T) {
 	assert.Equal(t, change1.ChangeType, change2.ChangeType)
 	assert.Equal(t, change1.Timestamp, change2.Timestamp)
 }
+
+// stubBranchMonitor reports the same branch changes on every check
+type stubBranchMonitor struct {
+	changes []BranchChange
+}
+
+func (m *stubBranchMonitor) CheckBranches(ctx context.Context, repo types.Repository) ([]BranchChange, error) {
+	return m.changes, nil
+}
+
+func (m *stubBranchMonitor) GetLastCheckTime(repo types.Repository) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (m *stubBranchMonitor) UpdateLastCheck(repo types.Repository, checkTime time.Time) error {
+	return nil
+}
+
+func TestPoller_PollNow(t *testing.T) {
+	testLogger := logger.GetDefaultLogger().WithField("test", "poll_now")
+	storage := testutils.NewMockStorage()
+	storage.On("CreateEvent", mock.Anything, mock.Anything).Return(nil)
+	p := NewPoller(GetDefaultPollerConfig(), storage, gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+	p.branchMonitor = &stubBranchMonitor{changes: []BranchChange{
+		{Repository: "org/hotfix", Branch: "main", OldCommitSHA: "abc123", NewCommitSHA: "def456", ChangeType: ChangeTypeUpdated},
+	}}
+
+	repo := types.Repository{Name: "org/hotfix", Provider: "github", Enabled: true, BranchRegex: ".*"}
+	require.NoError(t, p.AddRepository(repo))
+	assert.True(t, p.GetStatus().LastPollTime.IsZero(), "nothing has been polled yet")
+
+	before := time.Now()
+	result, err := p.PollNow(context.Background(), "org/hotfix")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Len(t, result.Changes, 1)
+	require.Len(t, result.Events, 1)
+	assert.NotEmpty(t, result.Events[0].ID)
+	assert.WithinDuration(t, before, p.GetStatus().LastPollTime, time.Second)
+
+	// Polls of a repository never overlap
+	require.True(t, p.beginPoll("org/hotfix"))
+	_, err = p.PollNow(context.Background(), "org/hotfix")
+	var inProgress *PollInProgressError
+	assert.ErrorAs(t, err, &inProgress)
+	p.endPoll("org/hotfix")
+
+	result, err = p.PollNow(context.Background(), "unknown")
+	assert.Error(t, err)
+	assert.Nil(t, result, "polls that cannot start have no result")
+}
+
+func TestTektonRequest(t *testing.T) {
+	repo := types.Repository{
+		Name:          "org/private",
+		URL:           "https://github.com/org/private",
+		Provider:      "github",
+		GitHubApp:     &types.GitHubAppConfig{AppID: 42, PrivateKeyPath: "/etc/reposentry/app.pem"},
+		HTTP:          types.HTTPClientConfig{ProxyURL: "http://proxy.internal:3128", CAFile: "/etc/ssl/ghe-ca.pem"},
+		DefaultBranch: "main",
+		Enabled:       true,
+	}
+	event := types.Event{Repository: "org/private", Branch: "main", CommitSHA: "abc123", Metadata: map[string]string{"pr_number": "7"}}
+
+	request := tektonRequest(repo, event)
+	assert.Equal(t, repo, request.Repository, "detection authenticates like polling, including with a GitHub App")
+	assert.Equal(t, repo.HTTP, request.Repository.HTTP, "detection connects through the repository's proxy and TLS settings")
+	assert.Equal(t, "abc123", request.CommitSHA)
+	assert.Equal(t, "main", request.Branch)
+	assert.Equal(t, "7", request.Metadata["pr_number"])
+}
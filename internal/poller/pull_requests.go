@@ -0,0 +1,192 @@
+package poller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/storage"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// PullRequestMonitorImpl implements the PullRequestMonitor interface
+type PullRequestMonitorImpl struct {
+	storage       storage.Storage
+	clientFactory *gitclient.ClientFactory
+	logger        *logger.Entry
+}
+
+// NewPullRequestMonitor creates a new pull request monitor
+func NewPullRequestMonitor(storage storage.Storage, clientFactory *gitclient.ClientFactory, parentLogger *logger.Entry) *PullRequestMonitorImpl {
+	return &PullRequestMonitorImpl{
+		storage:       storage,
+		clientFactory: clientFactory,
+		logger: parentLogger.WithFields(logger.Fields{
+			"component": "poller",
+			"module":    "pull_request_monitor",
+		}),
+	}
+}
+
+// CheckPullRequests compares the open pull requests of a repository with the stored
+// ones. Pull requests that are no longer open are looked up individually to tell
+// merged from closed, unless the open list stopped at the page cap.
+func (pm *PullRequestMonitorImpl) CheckPullRequests(ctx context.Context, repo types.Repository) ([]PullRequestChange, error) {
+	client, err := pm.clientFactory.CreateClient(repo, repoClientConfig(repo))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Git client: %w", err)
+	}
+	defer client.Close()
+
+	openPulls, err := client.ListPullRequests(ctx, repo)
+	complete := err == nil
+	if gitclient.IsIncompleteListing(err) {
+		// Pull requests past the page cap were not listed, so none can be told
+		// apart from a closed one
+		pm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":  "check_pull_requests",
+			"repository": repo.Name,
+		}).Warn("Pull request listing is incomplete, skipping closed pull request detection")
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to list pull requests: %w", err)
+	}
+
+	storedStates, err := pm.storage.GetPullRequestStates(ctx, repo.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored pull request states: %w", err)
+	}
+
+	storedByNumber := make(map[int]storage.PullRequestState)
+	for _, state := range storedStates {
+		storedByNumber[state.Number] = state
+	}
+
+	var changes []PullRequestChange
+	checkTime := time.Now()
+	openNumbers := make(map[int]bool)
+
+	for _, pr := range openPulls {
+		openNumbers[pr.Number] = true
+
+		stored, exists := storedByNumber[pr.Number]
+		if exists && stored.HeadSHA == pr.HeadSHA {
+			continue
+		}
+
+		change := PullRequestChange{
+			Repository:  repo.Name,
+			PullRequest: pr,
+			ChangeType:  PullRequestOpened,
+			Timestamp:   checkTime,
+		}
+		if exists {
+			change.ChangeType = PullRequestUpdated
+			change.OldHeadSHA = stored.HeadSHA
+		}
+		changes = append(changes, change)
+
+		pm.logger.WithFields(logger.Fields{
+			"operation":    "check_pull_requests",
+			"repository":   repo.Name,
+			"pull_request": pr.Number,
+			"head_sha":     pr.HeadSHA,
+			"change_type":  change.ChangeType,
+		}).Info("Detected pull request change")
+
+		state := storage.PullRequestState{
+			Repository:   repo.Name,
+			Number:       pr.Number,
+			HeadSHA:      pr.HeadSHA,
+			SourceBranch: pr.SourceBranch,
+			TargetBranch: pr.TargetBranch,
+			Title:        pr.Title,
+			Author:       pr.Author,
+			LastCheck:    checkTime,
+		}
+		if err := pm.storage.UpsertPullRequestState(ctx, state); err != nil {
+			pm.logger.WithError(err).WithFields(logger.Fields{
+				"operation":    "check_pull_requests",
+				"repository":   repo.Name,
+				"pull_request": pr.Number,
+			}).Error("Failed to update pull request state")
+		}
+	}
+
+	for number, stored := range storedByNumber {
+		if !complete || openNumbers[number] {
+			continue
+		}
+
+		change, ok := pm.resolveClosed(ctx, client, repo, stored, checkTime)
+		if !ok {
+			continue
+		}
+		changes = append(changes, change)
+
+		pm.logger.WithFields(logger.Fields{
+			"operation":    "check_pull_requests",
+			"repository":   repo.Name,
+			"pull_request": number,
+			"change_type":  change.ChangeType,
+		}).Info("Detected pull request change")
+
+		if err := pm.storage.DeletePullRequestState(ctx, repo.Name, number); err != nil {
+			pm.logger.WithError(err).WithFields(logger.Fields{
+				"operation":    "check_pull_requests",
+				"repository":   repo.Name,
+				"pull_request": number,
+			}).Error("Failed to delete pull request state")
+		}
+	}
+
+	return changes, nil
+}
+
+// resolveClosed determines whether a pull request missing from the open list was
+// merged or closed. It returns false when the outcome is unknown, leaving the stored
+// state in place for the next poll.
+func (pm *PullRequestMonitorImpl) resolveClosed(ctx context.Context, client gitclient.GitClient, repo types.Repository, stored storage.PullRequestState, checkTime time.Time) (PullRequestChange, bool) {
+	change := PullRequestChange{
+		Repository: repo.Name,
+		PullRequest: types.PullRequest{
+			Number:       stored.Number,
+			Title:        stored.Title,
+			State:        types.PullRequestStateClosed,
+			HeadSHA:      stored.HeadSHA,
+			SourceBranch: stored.SourceBranch,
+			TargetBranch: stored.TargetBranch,
+			Author:       stored.Author,
+		},
+		OldHeadSHA: stored.HeadSHA,
+		ChangeType: PullRequestClosed,
+		Timestamp:  checkTime,
+	}
+
+	pr, err := client.GetPullRequest(ctx, repo, stored.Number)
+	if err != nil {
+		// A pull request that no longer exists at all is reported as closed
+		var notFound *gitclient.RepositoryNotFoundError
+		if errors.As(err, &notFound) {
+			return change, true
+		}
+		pm.logger.WithError(err).WithFields(logger.Fields{
+			"operation":    "check_pull_requests",
+			"repository":   repo.Name,
+			"pull_request": stored.Number,
+		}).Warn("Failed to look up pull request, will retry next poll")
+		return change, false
+	}
+
+	switch pr.State {
+	case types.PullRequestStateOpen:
+		// Still open, e.g. it was opened again since the list was fetched
+		return change, false
+	case types.PullRequestStateMerged:
+		change.ChangeType = PullRequestMerged
+	}
+	change.PullRequest = *pr
+	return change, true
+}
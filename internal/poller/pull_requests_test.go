@@ -0,0 +1,166 @@
+package poller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/storage"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestPullRequestMonitor_CheckPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/one/pulls":
+			fmt.Fprint(w, `[
+				{"number": 1, "state": "open", "head": {"sha": "same", "ref": "a"}, "base": {"ref": "main"}},
+				{"number": 2, "state": "open", "head": {"sha": "pushed", "ref": "b"}, "base": {"ref": "main"}},
+				{"number": 5, "state": "open", "head": {"sha": "new", "ref": "e"}, "base": {"ref": "main"}}]`)
+		case "/repos/octo/one/pulls/3":
+			fmt.Fprint(w, `{"number": 3, "state": "closed", "merged_at": "2024-01-01T00:00:00Z", "head": {"sha": "c-sha", "ref": "c"}, "base": {"ref": "main"}}`)
+		case "/repos/octo/one/pulls/4":
+			fmt.Fprint(w, `{"number": 4, "state": "closed", "merged_at": null, "head": {"sha": "d-sha", "ref": "d"}, "base": {"ref": "main"}}`)
+		case "/repos/octo/one/pulls/6":
+			// Still open but missing from the listing, e.g. reopened since it was fetched
+			fmt.Fprint(w, `{"number": 6, "state": "open", "head": {"sha": "f-sha", "ref": "f"}, "base": {"ref": "main"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "pull_request_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetPullRequestStates", testutils.MockAny, "one").Return([]storage.PullRequestState{
+		{Repository: "one", Number: 1, HeadSHA: "same", SourceBranch: "a", TargetBranch: "main"},
+		{Repository: "one", Number: 2, HeadSHA: "before", SourceBranch: "b", TargetBranch: "main"},
+		{Repository: "one", Number: 3, HeadSHA: "c-sha", SourceBranch: "c", TargetBranch: "main"},
+		{Repository: "one", Number: 4, HeadSHA: "d-sha", SourceBranch: "d", TargetBranch: "main"},
+		{Repository: "one", Number: 6, HeadSHA: "f-sha", SourceBranch: "f", TargetBranch: "main"},
+	}, nil)
+	mockStorage.On("UpsertPullRequestState", testutils.MockAny, testutils.MockAny).Return(nil)
+	mockStorage.On("DeletePullRequestState", testutils.MockAny, "one", testutils.MockAny).Return(nil)
+
+	monitor := NewPullRequestMonitor(mockStorage, gitclient.NewClientFactory(testLogger), testLogger)
+	repo := types.Repository{
+		Name:         "one",
+		URL:          "https://github.com/octo/one",
+		Provider:     "github",
+		Token:        "token",
+		APIBaseURL:   server.URL,
+		PullRequests: true,
+	}
+
+	changes, err := monitor.CheckPullRequests(context.Background(), repo)
+	require.NoError(t, err)
+
+	byNumber := make(map[int]PullRequestChange)
+	for _, change := range changes {
+		byNumber[change.PullRequest.Number] = change
+	}
+	assert.Len(t, byNumber, 4)
+	assert.Equal(t, PullRequestUpdated, byNumber[2].ChangeType)
+	assert.Equal(t, "before", byNumber[2].OldHeadSHA)
+	assert.Equal(t, PullRequestMerged, byNumber[3].ChangeType)
+	assert.Equal(t, PullRequestClosed, byNumber[4].ChangeType)
+	assert.Equal(t, PullRequestOpened, byNumber[5].ChangeType)
+
+	mockStorage.AssertNumberOfCalls(t, "UpsertPullRequestState", 2)
+	mockStorage.AssertNumberOfCalls(t, "DeletePullRequestState", 2)
+	mockStorage.AssertNotCalled(t, "DeletePullRequestState", testutils.MockAny, "one", 6)
+}
+
+func TestPullRequestMonitor_IncompleteListing(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octo/one/pulls":
+			// Every page advertises another, so the listing stops at the page cap
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/octo/one/pulls?page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"number": 2, "state": "open", "head": {"sha": "pushed", "ref": "b"}, "base": {"ref": "main"}}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	testLogger := logger.GetDefaultLogger().WithField("test", "pull_request_monitor")
+	mockStorage := testutils.NewMockStorage()
+	mockStorage.On("GetPullRequestStates", testutils.MockAny, "one").Return([]storage.PullRequestState{
+		{Repository: "one", Number: 2, HeadSHA: "before", SourceBranch: "b", TargetBranch: "main"},
+		{Repository: "one", Number: 3, HeadSHA: "c-sha", SourceBranch: "c", TargetBranch: "main"},
+	}, nil)
+	mockStorage.On("UpsertPullRequestState", testutils.MockAny, testutils.MockAny).Return(nil)
+
+	factory := gitclient.NewClientFactory(testLogger)
+	factory.SetPagination(1, 1)
+	monitor := NewPullRequestMonitor(mockStorage, factory, testLogger)
+	repo := types.Repository{
+		Name:         "one",
+		URL:          "https://github.com/octo/one",
+		Provider:     "github",
+		Token:        "token",
+		APIBaseURL:   server.URL,
+		PullRequests: true,
+	}
+
+	// The listed pull request is checked, the one past the cap is neither
+	// looked up nor reported as closed
+	changes, err := monitor.CheckPullRequests(context.Background(), repo)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, 2, changes[0].PullRequest.Number)
+	assert.Equal(t, PullRequestUpdated, changes[0].ChangeType)
+	mockStorage.AssertNotCalled(t, "DeletePullRequestState", testutils.MockAny, testutils.MockAny, testutils.MockAny)
+}
+
+func TestEventGenerator_GeneratePullRequestEvents(t *testing.T) {
+	generator := NewEventGenerator(logger.GetDefaultLogger().WithField("test", "event_generator"))
+	repo := types.Repository{
+		Name:        "repo",
+		Provider:    "github",
+		URL:         "https://github.com/owner/repo",
+		BranchRegex: "^main$",
+	}
+
+	changes := []PullRequestChange{
+		{
+			Repository: "repo",
+			ChangeType: PullRequestOpened,
+			PullRequest: types.PullRequest{
+				Number: 7, Title: "Add feature", State: types.PullRequestStateOpen, HeadSHA: "abc",
+				SourceBranch: "feature", TargetBranch: "main", Author: "octocat", Labels: []string{"ci"},
+			},
+		},
+		{
+			Repository: "repo",
+			ChangeType: PullRequestMerged,
+			PullRequest: types.PullRequest{
+				Number: 8, State: types.PullRequestStateMerged, HeadSHA: "def", SourceBranch: "x", TargetBranch: "release",
+			},
+		},
+	}
+
+	events, err := generator.GeneratePullRequestEvents(context.Background(), repo, changes)
+	require.NoError(t, err)
+	require.Len(t, events, 1, "pull requests into branches outside the branch regex are skipped")
+
+	event := events[0]
+	assert.Equal(t, types.EventTypePullRequestOpened, event.Type)
+	assert.Equal(t, "feature", event.Branch)
+	assert.Equal(t, "abc", event.CommitSHA)
+	assert.Equal(t, "7", event.Metadata["pr_number"])
+	assert.Equal(t, "main", event.Metadata["target_branch"])
+	assert.Equal(t, `["ci"]`, event.Metadata["pr_labels"])
+	assert.Equal(t, "false", event.Metadata["pr_draft"])
+}
@@ -1,33 +1,64 @@
 package poller
 
 import (
+	"container/heap"
 	"context"
 	"fmt"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
 )
 
-// SchedulerImpl implements the Scheduler interface
+// minSchedulerWait keeps the scheduler from spinning while due repositories
+// wait to be claimed
+const minSchedulerWait = 100 * time.Millisecond
+
+// SchedulerImpl implements the Scheduler interface. Enabled repositories are
+// kept in a min-heap by next poll time, and the scheduler sleeps until the
+// earliest one is due instead of ticking at a fixed interval.
 type SchedulerImpl struct {
 	repositories map[string]*ScheduledRepository
+	queue        pollQueue
 	config       PollerConfig
 	logger       *logger.Entry
 	mu           sync.RWMutex
 	stopChan     chan struct{}
+	changed      chan struct{} // Wakes the loop when the earliest due time may have moved
+	due          chan struct{} // Signals the poller that repositories are due
 	running      bool
-	ticker       *time.Ticker
+	rateLimits   RateLimitSource
+	random       func() float64 // Source of jitter and splay, in [0, 1)
 }
 
 // ScheduledRepository represents a repository with scheduling information
 type ScheduledRepository struct {
 	Repository   types.Repository `json:"repository"`
+	Interval     time.Duration    `json:"interval"`
 	NextPollTime time.Time        `json:"next_poll_time"`
 	LastPollTime time.Time        `json:"last_poll_time,omitempty"`
 	PollCount    int64            `json:"poll_count"`
 	Enabled      bool             `json:"enabled"`
+
+	// API budget accounting
+	EstimatedCost float64 `json:"estimated_cost"` // Average API requests per poll
+	DeferredCount int64   `json:"deferred_count"` // Polls postponed to save API budget
+	costSamples   int64
+
+	// Failure tracking
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+	LastError           string    `json:"last_error,omitempty"`
+	FailureReason       string    `json:"failure_reason,omitempty"` // auth, not_found, network, rate_limit or unknown
+	LastSuccessTime     time.Time `json:"last_success_time,omitempty"`
+	Quarantined         bool      `json:"quarantined"` // Polling stopped until un-quarantined
+	QuarantinedAt       time.Time `json:"quarantined_at,omitempty"`
+
+	plan  *pollPlan // Cron schedule and blackout windows
+	index int       // Position in the scheduler's queue, -1 while not queued
 }
 
 // NewScheduler creates a new scheduler
@@ -40,9 +71,22 @@ func NewScheduler(config PollerConfig, parentLogger *logger.Entry) *SchedulerImp
 			"module":    "scheduler",
 		}),
 		stopChan: make(chan struct{}),
+		changed:  make(chan struct{}, 1),
+		due:      make(chan struct{}, 1),
+		random:   rand.Float64,
 	}
 }
 
+// SetRateLimitSource sets where the scheduler reads the API budgets of
+// repositories from. Without one, repositories are polled at their interval
+// regardless of budget.
+func (s *SchedulerImpl) SetRateLimitSource(source RateLimitSource) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.rateLimits = source
+}
+
 // Schedule schedules a repository for polling
 func (s *SchedulerImpl) Schedule(repo types.Repository) error {
 	s.mu.Lock()
@@ -56,34 +100,130 @@ func (s *SchedulerImpl) Schedule(repo types.Repository) error {
 		return nil
 	}
 
-	nextPollTime := time.Now().Add(s.config.Interval)
+	plan, err := compilePollPlan(s.config, repo)
+	if err != nil {
+		return fmt.Errorf("repository %s: %w", repo.Name, err)
+	}
+
+	if existing, exists := s.repositories[repo.Name]; exists {
+		s.dequeue(existing)
+	}
+
+	// Repositories on a cron schedule are first polled at its next time
+	now := time.Now()
+	interval := s.intervalFor(repo)
+	var nextPollTime time.Time
+	if plan.schedule != nil {
+		if gap := plan.scheduleInterval(now); gap > 0 {
+			interval = gap
+		}
+		nextPollTime = plan.schedule.Next(now)
+	} else {
+		nextPollTime = now.Add(s.splay(interval))
+	}
+	nextPollTime = plan.release(nextPollTime)
 
 	scheduledRepo := &ScheduledRepository{
 		Repository:   repo,
+		Interval:     interval,
 		NextPollTime: nextPollTime,
 		PollCount:    0,
 		Enabled:      true,
+		plan:         plan,
+		index:        -1,
 	}
 
 	s.repositories[repo.Name] = scheduledRepo
+	heap.Push(&s.queue, scheduledRepo)
+	s.notifyChanged()
 
 	s.logger.WithFields(logger.Fields{
 		"operation":      "schedule",
 		"repository":     repo.Name,
 		"provider":       repo.Provider,
 		"next_poll_time": nextPollTime.Format(time.RFC3339),
-		"interval":       s.config.Interval.String(),
+		"interval":       interval.String(),
 	}).Info("Scheduled repository for polling")
 
 	return nil
 }
 
+// intervalFor returns how often a repository is polled
+func (s *SchedulerImpl) intervalFor(repo types.Repository) time.Duration {
+	if repo.PollingInterval > 0 {
+		return repo.PollingInterval
+	}
+	return s.config.Interval
+}
+
+// splay returns how long after being scheduled a repository is first polled.
+// First polls are spread at random over the splay window, capped at the
+// repository's interval, so repositories scheduled together (at startup, say)
+// are not all polled at once.
+func (s *SchedulerImpl) splay(interval time.Duration) time.Duration {
+	window := interval
+	if s.config.Splay > 0 && s.config.Splay < window {
+		window = s.config.Splay
+	}
+	return time.Duration(s.random() * float64(window))
+}
+
+// jitter shifts an interval at random by up to the configured fraction either
+// way, so repositories sharing an interval drift apart instead of polling in lockstep
+func (s *SchedulerImpl) jitter(interval time.Duration) time.Duration {
+	if s.config.Jitter <= 0 {
+		return interval
+	}
+	fraction := s.config.Jitter
+	if fraction > 1 {
+		fraction = 1
+	}
+	return interval + time.Duration((2*s.random()-1)*fraction*float64(interval))
+}
+
+// nextPollTime returns when a repository is polled next after from: at its
+// next cron time, or one jittered interval later. Polls falling in a queueing
+// blackout are held until the window ends.
+func (s *SchedulerImpl) nextPollTime(scheduledRepo *ScheduledRepository, from time.Time) time.Time {
+	var next time.Time
+	if scheduledRepo.plan.schedule != nil {
+		next = scheduledRepo.plan.schedule.Next(from)
+	} else {
+		next = from.Add(s.jitter(scheduledRepo.Interval))
+	}
+	return scheduledRepo.plan.release(next)
+}
+
+// setNextPollTime moves a repository in the queue. The caller must hold s.mu.
+func (s *SchedulerImpl) setNextPollTime(scheduledRepo *ScheduledRepository, nextPollTime time.Time) {
+	scheduledRepo.NextPollTime = nextPollTime
+	if scheduledRepo.index >= 0 {
+		heap.Fix(&s.queue, scheduledRepo.index)
+	}
+}
+
+// dequeue removes a repository from the queue. The caller must hold s.mu.
+func (s *SchedulerImpl) dequeue(scheduledRepo *ScheduledRepository) {
+	if scheduledRepo.index >= 0 {
+		heap.Remove(&s.queue, scheduledRepo.index)
+	}
+}
+
+// notifyChanged wakes the scheduler loop to recompute when it next fires
+func (s *SchedulerImpl) notifyChanged() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
 // Unschedule removes a repository from polling
 func (s *SchedulerImpl) Unschedule(repo types.Repository) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	if _, exists := s.repositories[repo.Name]; !exists {
+	scheduledRepo, exists := s.repositories[repo.Name]
+	if !exists {
 		s.logger.WithFields(logger.Fields{
 			"operation":  "unschedule",
 			"repository": repo.Name,
@@ -91,6 +231,7 @@ func (s *SchedulerImpl) Unschedule(repo types.Repository) error {
 		return nil
 	}
 
+	s.dequeue(scheduledRepo)
 	delete(s.repositories, repo.Name)
 
 	s.logger.WithFields(logger.Fields{
@@ -127,11 +268,9 @@ func (s *SchedulerImpl) Start(ctx context.Context) error {
 	s.logger.WithFields(logger.Fields{
 		"operation": "start",
 		"interval":  s.config.Interval.String(),
+		"jitter":    s.config.Jitter,
 	}).Info("Starting scheduler")
 
-	// Create ticker for polling interval
-	s.ticker = time.NewTicker(s.config.Interval)
-
 	go s.run(ctx)
 
 	return nil
@@ -151,21 +290,20 @@ func (s *SchedulerImpl) Stop(ctx context.Context) error {
 		"operation": "stop",
 	}).Info("Stopping scheduler")
 
-	// Stop ticker
-	if s.ticker != nil {
-		s.ticker.Stop()
-	}
-
 	// Signal stop
 	close(s.stopChan)
 
 	return nil
 }
 
-// run is the main scheduler loop
+// run is the main scheduler loop. It sleeps until the earliest repository is
+// due, or until the schedule changes.
 func (s *SchedulerImpl) run(ctx context.Context) {
 	s.logger.Info("Scheduler started")
 
+	timer := time.NewTimer(s.untilNextDue(time.Now()))
+	defer timer.Stop()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -174,54 +312,364 @@ func (s *SchedulerImpl) run(ctx context.Context) {
 		case <-s.stopChan:
 			s.logger.Info("Scheduler stopped")
 			return
-		case <-s.ticker.C:
+		case <-s.changed:
+			timer.Reset(s.untilNextDue(time.Now()))
+		case <-timer.C:
 			s.processPendingPolls(ctx)
+			wait := s.untilNextDue(time.Now())
+			if wait < minSchedulerWait {
+				wait = minSchedulerWait
+			}
+			timer.Reset(wait)
 		}
 	}
 }
 
-// processPendingPolls checks for repositories that need to be polled
+// untilNextDue returns how long until the earliest repository is due. With
+// nothing scheduled the loop sleeps one polling interval.
+func (s *SchedulerImpl) untilNextDue(now time.Time) time.Duration {
+	next, ok := s.NextDue()
+	if !ok {
+		if s.config.Interval > 0 {
+			return s.config.Interval
+		}
+		return time.Minute
+	}
+	if wait := next.Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// NextDue returns when the earliest enabled repository is due for polling
+func (s *SchedulerImpl) NextDue() (time.Time, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.queue) == 0 {
+		return time.Time{}, false
+	}
+	return s.queue[0].NextPollTime, true
+}
+
+// Due returns a channel that receives when repositories are due for polling
+func (s *SchedulerImpl) Due() <-chan struct{} {
+	return s.due
+}
+
+// processPendingPolls signals the poller when repositories are due. The
+// poller claims them through DuePolls.
 func (s *SchedulerImpl) processPendingPolls(ctx context.Context) {
 	s.mu.RLock()
 	now := time.Now()
-	var readyRepos []*ScheduledRepository
+	var readyCount int
 
-	for _, scheduledRepo := range s.repositories {
-		if scheduledRepo.Enabled && now.After(scheduledRepo.NextPollTime) {
-			readyRepos = append(readyRepos, scheduledRepo)
+	for _, scheduledRepo := range s.queue {
+		if !scheduledRepo.NextPollTime.After(now) {
+			readyCount++
 		}
 	}
 	s.mu.RUnlock()
 
-	if len(readyRepos) == 0 {
+	if readyCount == 0 {
 		s.logger.Debug("No repositories ready for polling")
 		return
 	}
 
 	s.logger.WithFields(logger.Fields{
 		"operation":   "process_pending_polls",
-		"ready_count": len(readyRepos),
-	}).Info("Processing pending polls")
+		"ready_count": readyCount,
+	}).Debug("Repositories due for polling")
 
-	// Update poll times for ready repositories
+	select {
+	case s.due <- struct{}{}:
+	default:
+	}
+}
+
+// DuePolls claims the repositories to poll now, critical repositories first.
+// When a repository's API budget cannot cover every poll until it resets,
+// non-critical polls are deferred so the remaining requests are spread across
+// the reset window, and nothing is polled once the budget is used up.
+func (s *SchedulerImpl) DuePolls(now time.Time) []types.Repository {
 	s.mu.Lock()
-	for _, scheduledRepo := range readyRepos {
+	defer s.mu.Unlock()
+
+	var due []*ScheduledRepository
+	for len(s.queue) > 0 && !s.queue[0].NextPollTime.After(now) {
+		due = append(due, heap.Pop(&s.queue).(*ScheduledRepository))
+	}
+	if len(due) == 0 {
+		return nil
+	}
+	sortByPriority(due)
+
+	// Every claimed or deferred repository goes back into the queue at its new time
+	defer func() {
+		for _, scheduledRepo := range due {
+			heap.Push(&s.queue, scheduledRepo)
+		}
+	}()
+
+	groups, membership := s.budgetGroups(now)
+
+	var repos []types.Repository
+	for _, scheduledRepo := range due {
+		// Windows may have been entered since the poll time was set, for
+		// example by a deferral
+		if state, ok := scheduledRepo.plan.blackout(now); ok && state.Action == types.BlackoutActionQueue {
+			scheduledRepo.NextPollTime = scheduledRepo.plan.release(now)
+
+			s.logger.WithFields(logger.Fields{
+				"operation":      "due_polls",
+				"repository":     scheduledRepo.Repository.Name,
+				"window":         state.Window,
+				"next_poll_time": scheduledRepo.NextPollTime.Format(time.RFC3339),
+			}).Info("Held poll until blackout window ends")
+			continue
+		}
+
+		group := groups[membership[scheduledRepo.Repository.Name]]
+		if group != nil {
+			if ok, until := group.admit(scheduledRepo, now); !ok {
+				scheduledRepo.NextPollTime = scheduledRepo.plan.release(until)
+				scheduledRepo.DeferredCount++
+
+				s.logger.WithFields(logger.Fields{
+					"operation":      "due_polls",
+					"repository":     scheduledRepo.Repository.Name,
+					"provider":       scheduledRepo.Repository.Provider,
+					"remaining":      group.status.Remaining,
+					"reset_time":     group.status.ResetTime.Format(time.RFC3339),
+					"next_poll_time": scheduledRepo.NextPollTime.Format(time.RFC3339),
+				}).Info("Deferred poll to stay within API rate limit")
+				continue
+			}
+			group.spent += scheduledRepo.pollCost()
+		}
+
 		scheduledRepo.LastPollTime = now
-		scheduledRepo.NextPollTime = now.Add(s.config.Interval)
+		scheduledRepo.NextPollTime = s.nextPollTime(scheduledRepo, now)
 		scheduledRepo.PollCount++
+		repos = append(repos, scheduledRepo.Repository)
 	}
-	s.mu.Unlock()
 
-	// Log each repository being polled
-	for _, scheduledRepo := range readyRepos {
+	return repos
+}
+
+// ClaimPoll claims an on-demand poll of a repository outside its schedule.
+// The poll still draws from the repository's API budget and is refused with a
+// gitclient.RateLimitExceededError when the remaining requests cannot cover
+// it. The next scheduled poll follows one interval after the claimed one.
+func (s *SchedulerImpl) ClaimPoll(repoName string, now time.Time) (types.Repository, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scheduledRepo, exists := s.repositories[repoName]
+	if !exists {
+		return types.Repository{}, fmt.Errorf("repository %s is not scheduled", repoName)
+	}
+	if !scheduledRepo.Enabled {
+		return types.Repository{}, fmt.Errorf("repository %s is disabled", repoName)
+	}
+
+	if s.rateLimits != nil {
+		status, ok := s.rateLimits.RateLimitFor(scheduledRepo.Repository, repoClientConfig(scheduledRepo.Repository))
+		if ok && status.ResetTime.After(now) && float64(status.Remaining) < scheduledRepo.pollCost() {
+			return types.Repository{}, &gitclient.RateLimitExceededError{Provider: status.Provider, ResetTime: status.ResetTime}
+		}
+	}
+
+	scheduledRepo.LastPollTime = now
+	scheduledRepo.PollCount++
+	if !scheduledRepo.Quarantined {
+		s.setNextPollTime(scheduledRepo, s.nextPollTime(scheduledRepo, now))
+		s.notifyChanged()
+	}
+
+	return scheduledRepo.Repository, nil
+}
+
+// Blackout returns the blackout window a repository is in at now, if any
+func (s *SchedulerImpl) Blackout(repoName string, now time.Time) (BlackoutState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	scheduledRepo, exists := s.repositories[repoName]
+	if !exists {
+		return BlackoutState{}, false
+	}
+	return scheduledRepo.plan.blackout(now)
+}
+
+// RecordPollResult records the outcome of a poll. A repository that fails
+// keeps backing off, its delay doubling with each consecutive failure up to
+// MaxBackoff, and is quarantined after QuarantineAfter failures in a row.
+// Open circuits and exhausted rate limits are already paced by the circuit
+// breaker and API budget, so they don't count against the repository.
+func (s *SchedulerImpl) RecordPollResult(repoName string, pollErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scheduledRepo, exists := s.repositories[repoName]
+	if !exists {
+		return
+	}
+
+	now := time.Now()
+	if pollErr == nil {
+		if scheduledRepo.ConsecutiveFailures > 0 {
+			s.logger.WithFields(logger.Fields{
+				"operation":            "record_poll_result",
+				"repository":           repoName,
+				"consecutive_failures": scheduledRepo.ConsecutiveFailures,
+			}).Info("Repository recovered after failed polls")
+		}
+		scheduledRepo.ConsecutiveFailures = 0
+		scheduledRepo.LastError = ""
+		scheduledRepo.FailureReason = ""
+		scheduledRepo.LastSuccessTime = now
+
+		// A successful poll, such as one requested through the API, shows the
+		// problem is fixed
+		if scheduledRepo.Quarantined {
+			s.liftQuarantine(scheduledRepo, scheduledRepo.plan.release(now.Add(s.jitter(scheduledRepo.Interval))))
+		}
+		return
+	}
+
+	reason := gitclient.ClassifyError(pollErr)
+	scheduledRepo.LastError = pollErr.Error()
+	scheduledRepo.FailureReason = reason
+	if reason == gitclient.FailureRateLimit || gitclient.IsCircuitOpen(pollErr) {
+		return
+	}
+	scheduledRepo.ConsecutiveFailures++
+
+	if s.config.QuarantineAfter > 0 && scheduledRepo.ConsecutiveFailures >= s.config.QuarantineAfter {
+		scheduledRepo.Quarantined = true
+		scheduledRepo.QuarantinedAt = now
+		s.dequeue(scheduledRepo)
+
 		s.logger.WithFields(logger.Fields{
-			"operation":      "process_pending_polls",
-			"repository":     scheduledRepo.Repository.Name,
-			"provider":       scheduledRepo.Repository.Provider,
-			"poll_count":     scheduledRepo.PollCount,
-			"next_poll_time": scheduledRepo.NextPollTime.Format(time.RFC3339),
-		}).Info("Repository ready for polling")
+			"operation":            "record_poll_result",
+			"repository":           repoName,
+			"reason":               reason,
+			"consecutive_failures": scheduledRepo.ConsecutiveFailures,
+			"error":                scheduledRepo.LastError,
+		}).Warn("Quarantined repository after repeated poll failures")
+		return
+	}
+
+	delay := s.backoff(scheduledRepo.Interval, scheduledRepo.ConsecutiveFailures)
+	next := now.Add(delay)
+	if scheduledRepo.plan.schedule != nil {
+		next = scheduledRepo.plan.schedule.Next(next.Add(-time.Nanosecond))
+	}
+	next = scheduledRepo.plan.release(next)
+	if next.After(scheduledRepo.NextPollTime) {
+		s.setNextPollTime(scheduledRepo, next)
+		s.notifyChanged()
 	}
+
+	s.logger.WithFields(logger.Fields{
+		"operation":            "record_poll_result",
+		"repository":           repoName,
+		"reason":               reason,
+		"consecutive_failures": scheduledRepo.ConsecutiveFailures,
+		"next_poll_time":       scheduledRepo.NextPollTime.Format(time.RFC3339),
+	}).Warn("Backing off failing repository")
+}
+
+// backoff returns how long to wait before polling a repository again after
+// failures consecutive failures: its interval doubled for each failure,
+// capped at MaxBackoff. Repositories polled less often than that are polled
+// at their interval.
+func (s *SchedulerImpl) backoff(interval time.Duration, failures int) time.Duration {
+	if s.config.MaxBackoff <= interval {
+		return interval
+	}
+	delay := interval
+	for i := 0; i < failures && delay < s.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxBackoff {
+		delay = s.config.MaxBackoff
+	}
+	return delay
+}
+
+// Unquarantine resumes polling a quarantined repository, starting with a
+// poll straight away
+func (s *SchedulerImpl) Unquarantine(repoName string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	scheduledRepo, exists := s.repositories[repoName]
+	if !exists {
+		return fmt.Errorf("repository %s is not scheduled", repoName)
+	}
+
+	if !scheduledRepo.Quarantined {
+		return nil // Not quarantined
+	}
+
+	s.liftQuarantine(scheduledRepo, scheduledRepo.plan.release(time.Now()))
+	return nil
+}
+
+// liftQuarantine puts a quarantined repository back in the queue at
+// nextPollTime. The caller must hold s.mu.
+func (s *SchedulerImpl) liftQuarantine(scheduledRepo *ScheduledRepository, nextPollTime time.Time) {
+	scheduledRepo.Quarantined = false
+	scheduledRepo.QuarantinedAt = time.Time{}
+	scheduledRepo.ConsecutiveFailures = 0
+	if scheduledRepo.Enabled && scheduledRepo.index < 0 {
+		scheduledRepo.NextPollTime = nextPollTime
+		heap.Push(&s.queue, scheduledRepo)
+		s.notifyChanged()
+	}
+
+	s.logger.WithFields(logger.Fields{
+		"operation":      "unquarantine",
+		"repository":     scheduledRepo.Repository.Name,
+		"next_poll_time": scheduledRepo.NextPollTime.Format(time.RFC3339),
+	}).Info("Un-quarantined repository")
+}
+
+// RecordPollCost records the API requests a poll of a repository made
+func (s *SchedulerImpl) RecordPollCost(repoName string, requests int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if scheduledRepo, exists := s.repositories[repoName]; exists {
+		scheduledRepo.recordCost(requests)
+	}
+}
+
+// GetBudgets returns the API budgets shared by scheduled repositories, ordered
+// by provider, host and credential
+func (s *SchedulerImpl) GetBudgets(now time.Time) []PollBudget {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	groups, _ := s.budgetGroups(now)
+
+	budgets := make([]PollBudget, 0, len(groups))
+	for _, group := range groups {
+		budgets = append(budgets, group.budget(now))
+	}
+	sort.Slice(budgets, func(i, j int) bool {
+		a, b := budgets[i], budgets[j]
+		if a.Provider != b.Provider {
+			return a.Provider < b.Provider
+		}
+		if a.Host != b.Host {
+			return a.Host < b.Host
+		}
+		return a.Credential < b.Credential
+	})
+	return budgets
 }
 
 // GetScheduledRepositories returns all currently scheduled repositories
@@ -292,7 +740,8 @@ func (s *SchedulerImpl) UpdateRepositorySchedule(repo types.Repository, nextPoll
 	}
 
 	oldNextPollTime := scheduledRepo.NextPollTime
-	scheduledRepo.NextPollTime = nextPollTime
+	s.setNextPollTime(scheduledRepo, nextPollTime)
+	s.notifyChanged()
 
 	s.logger.WithFields(logger.Fields{
 		"operation":          "update_schedule",
@@ -319,7 +768,11 @@ func (s *SchedulerImpl) EnableRepository(repoName string) error {
 	}
 
 	scheduledRepo.Enabled = true
-	scheduledRepo.NextPollTime = time.Now().Add(s.config.Interval)
+	scheduledRepo.NextPollTime = s.nextPollTime(scheduledRepo, time.Now())
+	if !scheduledRepo.Quarantined {
+		heap.Push(&s.queue, scheduledRepo)
+		s.notifyChanged()
+	}
 
 	s.logger.WithFields(logger.Fields{
 		"operation":      "enable_repository",
@@ -345,6 +798,7 @@ func (s *SchedulerImpl) DisableRepository(repoName string) error {
 	}
 
 	scheduledRepo.Enabled = false
+	s.dequeue(scheduledRepo)
 
 	s.logger.WithFields(logger.Fields{
 		"operation":  "disable_repository",
@@ -380,7 +834,7 @@ func (s *SchedulerImpl) GetRepositoryStats(repoName string) (*RepositorySchedule
 		LastPollTime: scheduledRepo.LastPollTime,
 		NextPollTime: scheduledRepo.NextPollTime,
 		NextPollIn:   nextPollIn,
-		Interval:     s.config.Interval,
+		Interval:     scheduledRepo.Interval,
 	}, nil
 }
 
@@ -395,3 +849,35 @@ type RepositoryScheduleStats struct {
 	NextPollIn   time.Duration `json:"next_poll_in"`
 	Interval     time.Duration `json:"interval"`
 }
+
+// pollQueue is a min-heap of enabled repositories ordered by next poll time.
+// It implements heap.Interface.
+type pollQueue []*ScheduledRepository
+
+func (q pollQueue) Len() int { return len(q) }
+
+func (q pollQueue) Less(i, j int) bool {
+	return q[i].NextPollTime.Before(q[j].NextPollTime)
+}
+
+func (q pollQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *pollQueue) Push(x interface{}) {
+	scheduledRepo := x.(*ScheduledRepository)
+	scheduledRepo.index = len(*q)
+	*q = append(*q, scheduledRepo)
+}
+
+func (q *pollQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	scheduledRepo := old[n-1]
+	old[n-1] = nil
+	scheduledRepo.index = -1
+	*q = old[:n-1]
+	return scheduledRepo
+}
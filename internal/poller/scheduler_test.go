@@ -0,0 +1,176 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// newTestScheduler creates a scheduler whose random source always returns value
+func newTestScheduler(config PollerConfig, value float64) *SchedulerImpl {
+	scheduler := NewScheduler(config, logger.GetDefaultLogger().WithField("test", "scheduler"))
+	scheduler.random = func() float64 { return value }
+	return scheduler
+}
+
+func TestScheduler_PerRepositoryInterval(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Jitter = 0
+	scheduler := newTestScheduler(config, 0.5)
+
+	before := time.Now()
+	require.NoError(t, scheduler.Schedule(types.Repository{Name: "fast", Enabled: true, PollingInterval: time.Minute}))
+	require.NoError(t, scheduler.Schedule(types.Repository{Name: "default", Enabled: true}))
+
+	fast := scheduler.repositories["fast"]
+	assert.Equal(t, time.Minute, fast.Interval)
+	assert.Equal(t, config.Interval, scheduler.repositories["default"].Interval)
+
+	// First polls are splayed over each repository's own interval
+	assert.WithinDuration(t, before.Add(30*time.Second), fast.NextPollTime, time.Second)
+	assert.WithinDuration(t, before.Add(config.Interval/2), scheduler.repositories["default"].NextPollTime, time.Second)
+
+	next, ok := scheduler.NextDue()
+	require.True(t, ok)
+	assert.Equal(t, fast.NextPollTime, next, "the earliest repository is at the head of the queue")
+
+	// Only the fast repository is due after its first poll time, and it comes
+	// back one minute later
+	now := fast.NextPollTime
+	assert.Equal(t, []string{"fast"}, repoNames(scheduler.DuePolls(now)))
+	assert.Equal(t, now.Add(time.Minute), fast.NextPollTime)
+
+	stats, err := scheduler.GetRepositoryStats("fast")
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, stats.Interval)
+}
+
+func TestScheduler_Jitter(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Interval = 10 * time.Minute
+	config.Jitter = 0.2
+
+	now := time.Now()
+	for _, tt := range []struct {
+		random   float64
+		expected time.Duration
+	}{
+		{random: 0, expected: 8 * time.Minute},
+		{random: 0.5, expected: 10 * time.Minute},
+		{random: 0.75, expected: 11 * time.Minute},
+	} {
+		scheduler := newTestScheduler(config, tt.random)
+		require.NoError(t, scheduler.Schedule(types.Repository{Name: "repo", Enabled: true}))
+
+		due := scheduler.DuePolls(now.Add(config.Interval))
+		require.Len(t, due, 1)
+		assert.Equal(t, now.Add(config.Interval).Add(tt.expected), scheduler.repositories["repo"].NextPollTime, "random %v", tt.random)
+	}
+}
+
+func TestScheduler_Splay(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Interval = 10 * time.Minute
+	config.Splay = time.Minute
+	scheduler := newTestScheduler(config, 0.99)
+
+	before := time.Now()
+	require.NoError(t, scheduler.Schedule(types.Repository{Name: "repo", Enabled: true}))
+
+	next, ok := scheduler.NextDue()
+	require.True(t, ok)
+	assert.True(t, next.Before(before.Add(time.Minute+time.Second)), "first polls are spread over the splay window")
+	assert.True(t, next.After(before.Add(50*time.Second)))
+}
+
+func TestScheduler_QueueFollowsChanges(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	config.Jitter = 0
+	scheduler := newTestScheduler(config, 0)
+
+	for _, name := range []string{"a", "b", "c"} {
+		require.NoError(t, scheduler.Schedule(types.Repository{Name: name, Enabled: true}))
+	}
+	now := time.Now()
+	require.NoError(t, scheduler.UpdateRepositorySchedule(types.Repository{Name: "a"}, now.Add(time.Hour)))
+	require.NoError(t, scheduler.UpdateRepositorySchedule(types.Repository{Name: "b"}, now.Add(time.Minute)))
+	require.NoError(t, scheduler.UpdateRepositorySchedule(types.Repository{Name: "c"}, now.Add(2*time.Minute)))
+
+	next, _ := scheduler.NextDue()
+	assert.Equal(t, now.Add(time.Minute), next)
+
+	// Disabled and unscheduled repositories leave the queue
+	require.NoError(t, scheduler.DisableRepository("b"))
+	next, _ = scheduler.NextDue()
+	assert.Equal(t, now.Add(2*time.Minute), next)
+
+	require.NoError(t, scheduler.Unschedule(types.Repository{Name: "c"}))
+	next, _ = scheduler.NextDue()
+	assert.Equal(t, now.Add(time.Hour), next)
+
+	assert.Empty(t, scheduler.DuePolls(now.Add(30*time.Minute)))
+	assert.Equal(t, []string{"a"}, repoNames(scheduler.DuePolls(now.Add(time.Hour))))
+
+	require.NoError(t, scheduler.Unschedule(types.Repository{Name: "a"}))
+	_, ok := scheduler.NextDue()
+	assert.False(t, ok, "disabled repositories are not queued")
+	assert.Len(t, scheduler.GetScheduledRepositories(), 1)
+}
+
+func TestScheduler_SignalsDue(t *testing.T) {
+	config := GetDefaultPollerConfig()
+	scheduler := newTestScheduler(config, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, scheduler.Start(ctx))
+	defer scheduler.Stop(ctx)
+
+	// Scheduling wakes the loop, which signals as soon as the repository is due
+	require.NoError(t, scheduler.Schedule(types.Repository{Name: "repo", Enabled: true, PollingInterval: 50 * time.Millisecond}))
+
+	select {
+	case <-scheduler.Due():
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not signal the due repository")
+	}
+	assert.Len(t, scheduler.DuePolls(time.Now()), 1)
+}
+
+func TestScheduler_ClaimPoll(t *testing.T) {
+	now := time.Now()
+	scheduler := newBudgetTestScheduler(t, 1, now.Add(time.Hour), types.Repository{Name: "hotfix"})
+	scheduler.config.Jitter = 0
+
+	// An on-demand poll ignores the schedule and restarts it
+	repo, err := scheduler.ClaimPoll("hotfix", now)
+	require.NoError(t, err)
+	assert.Equal(t, "hotfix", repo.Name)
+	scheduledRepo := scheduler.repositories["hotfix"]
+	assert.Equal(t, now, scheduledRepo.LastPollTime)
+	assert.Equal(t, int64(1), scheduledRepo.PollCount)
+	assert.Equal(t, now.Add(scheduledRepo.Interval), scheduledRepo.NextPollTime)
+
+	// But not the rate limit: a budget that cannot cover the poll refuses it
+	// until the reset
+	scheduledRepo.recordCost(3)
+	_, err = scheduler.ClaimPoll("hotfix", now)
+	var rateLimited *gitclient.RateLimitExceededError
+	require.ErrorAs(t, err, &rateLimited)
+	assert.Equal(t, now.Add(time.Hour), rateLimited.ResetTime)
+	assert.Equal(t, int64(1), scheduledRepo.PollCount)
+
+	_, err = scheduler.ClaimPoll("unknown", now)
+	assert.Error(t, err)
+
+	require.NoError(t, scheduler.DisableRepository("hotfix"))
+	_, err = scheduler.ClaimPoll("hotfix", now.Add(2*time.Hour))
+	assert.Error(t, err, "disabled repositories are not polled")
+}
@@ -0,0 +1,234 @@
+package poller
+
+import (
+	"container/heap"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// workItem is a repository waiting for a worker
+type workItem struct {
+	repo     types.Repository
+	onDemand bool      // Requested through PollNow, ahead of every scheduled poll
+	queuedAt time.Time // When the poll was queued, which is when it fell due for scheduled polls
+	seq      uint64    // Keeps polls queued together in their queued order
+	waiters  []chan pollOutcome
+	index    int
+}
+
+// pollOutcome is delivered to the callers waiting for an on-demand poll
+type pollOutcome struct {
+	result *PollResult
+	err    error
+}
+
+// workQueue is a deduplicating priority queue of repositories waiting for a
+// worker. A repository has at most one entry: queueing it again merges into
+// the existing one. On-demand polls come first, then critical repositories,
+// then the polls that have waited longest. Nothing is dropped when workers
+// fall behind.
+type workQueue struct {
+	mu     sync.Mutex
+	items  workHeap
+	byName map[string]*workItem
+	seq    uint64
+	closed bool
+	ready  chan struct{} // Holds a signal while entries are waiting
+
+	deduped    int64
+	waitTotal  time.Duration
+	waitMax    time.Duration
+	dispatched int64
+}
+
+// workQueueStats describes the work queue for metrics
+type workQueueStats struct {
+	Depth       int           `json:"depth"`
+	Dispatched  int64         `json:"dispatched"`
+	Deduped     int64         `json:"deduped"`      // Polls merged into one already queued for the repository
+	AverageWait time.Duration `json:"average_wait"` // Time entries waited for a worker
+	MaxWait     time.Duration `json:"max_wait"`
+}
+
+func newWorkQueue() *workQueue {
+	return &workQueue{
+		byName: make(map[string]*workItem),
+		ready:  make(chan struct{}, 1),
+	}
+}
+
+// push queues a scheduled poll of a repository, returning false when the
+// repository was already queued and the poll merged into that entry
+func (q *workQueue) push(repo types.Repository, now time.Time) (bool, error) {
+	return q.add(repo, now, false, nil)
+}
+
+// pushOnDemand queues an on-demand poll ahead of scheduled ones. The outcome
+// is delivered on waiter.
+func (q *workQueue) pushOnDemand(repo types.Repository, now time.Time, waiter chan pollOutcome) error {
+	_, err := q.add(repo, now, true, waiter)
+	return err
+}
+
+func (q *workQueue) add(repo types.Repository, now time.Time, onDemand bool, waiter chan pollOutcome) (bool, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		return false, fmt.Errorf("poller is stopped")
+	}
+
+	added := true
+	item, exists := q.byName[repo.Name]
+	if exists {
+		// Poll with the latest settings, keeping the entry's place unless it
+		// moves up
+		item.repo = repo
+		if onDemand && !item.onDemand {
+			item.onDemand = true
+			heap.Fix(&q.items, item.index)
+		}
+		q.deduped++
+		added = false
+	} else {
+		q.seq++
+		item = &workItem{repo: repo, onDemand: onDemand, queuedAt: now, seq: q.seq}
+		heap.Push(&q.items, item)
+		q.byName[repo.Name] = item
+	}
+	if waiter != nil {
+		item.waiters = append(item.waiters, waiter)
+	}
+
+	q.signal()
+	return added, nil
+}
+
+// pop takes the first entry, if any, recording how long it waited
+func (q *workQueue) pop(now time.Time) (*workItem, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.items) == 0 {
+		return nil, false
+	}
+	item := heap.Pop(&q.items).(*workItem)
+	delete(q.byName, item.repo.Name)
+
+	wait := now.Sub(item.queuedAt)
+	q.waitTotal += wait
+	if wait > q.waitMax {
+		q.waitMax = wait
+	}
+	q.dispatched++
+
+	// Wake another worker for the remaining entries
+	if len(q.items) > 0 {
+		q.signal()
+	}
+	return item, true
+}
+
+// signal marks entries as waiting. The caller must hold q.mu.
+func (q *workQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready returns a channel that receives when entries may be waiting
+func (q *workQueue) Ready() <-chan struct{} {
+	return q.ready
+}
+
+// Len returns the number of queued repositories
+func (q *workQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.items)
+}
+
+// close stops accepting polls and fails the on-demand polls still waiting
+func (q *workQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	for _, item := range q.items {
+		item.deliver(nil, fmt.Errorf("poller stopped before polling %s", item.repo.Name))
+	}
+	q.items = nil
+	q.byName = make(map[string]*workItem)
+}
+
+// stats summarizes the queue for metrics
+func (q *workQueue) stats() workQueueStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	stats := workQueueStats{
+		Depth:      len(q.items),
+		Dispatched: q.dispatched,
+		Deduped:    q.deduped,
+		MaxWait:    q.waitMax,
+	}
+	if q.dispatched > 0 {
+		stats.AverageWait = q.waitTotal / time.Duration(q.dispatched)
+	}
+	return stats
+}
+
+// deliver sends a poll's outcome to the callers waiting for it
+func (item *workItem) deliver(result *PollResult, err error) {
+	for _, waiter := range item.waiters {
+		waiter <- pollOutcome{result: result, err: err}
+	}
+	item.waiters = nil
+}
+
+// workHeap orders queued polls: on-demand first, then critical repositories,
+// then the longest waiting
+type workHeap []*workItem
+
+func (h workHeap) Len() int { return len(h) }
+
+func (h workHeap) Less(i, j int) bool {
+	a, b := h[i], h[j]
+	if a.onDemand != b.onDemand {
+		return a.onDemand
+	}
+	if a.repo.IsCritical() != b.repo.IsCritical() {
+		return a.repo.IsCritical()
+	}
+	if !a.queuedAt.Equal(b.queuedAt) {
+		return a.queuedAt.Before(b.queuedAt)
+	}
+	return a.seq < b.seq
+}
+
+func (h workHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *workHeap) Push(x interface{}) {
+	item := x.(*workItem)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *workHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,145 @@
+package poller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// drain pops every queued repository in order
+func drain(q *workQueue, now time.Time) []string {
+	var names []string
+	for {
+		item, ok := q.pop(now)
+		if !ok {
+			return names
+		}
+		names = append(names, item.repo.Name)
+	}
+}
+
+func TestWorkQueue_Order(t *testing.T) {
+	q := newWorkQueue()
+	now := time.Now()
+
+	for _, repo := range []types.Repository{
+		{Name: "late"},
+		{Name: "late-critical", Priority: types.PriorityCritical},
+	} {
+		_, err := q.push(repo, now)
+		require.NoError(t, err)
+	}
+	_, err := q.push(types.Repository{Name: "overdue"}, now.Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = q.push(types.Repository{Name: "same-batch"}, now)
+	require.NoError(t, err)
+	require.NoError(t, q.pushOnDemand(types.Repository{Name: "hotfix"}, now, make(chan pollOutcome, 1)))
+
+	// On-demand first, then critical, then longest waiting, keeping the
+	// order polls were queued in
+	assert.Equal(t, []string{"hotfix", "late-critical", "overdue", "late", "same-batch"}, drain(q, now))
+}
+
+func TestWorkQueue_Dedup(t *testing.T) {
+	q := newWorkQueue()
+	now := time.Now()
+
+	added, err := q.push(types.Repository{Name: "repo", Branches: "all"}, now)
+	require.NoError(t, err)
+	assert.True(t, added)
+	_, err = q.push(types.Repository{Name: "other"}, now)
+	require.NoError(t, err)
+
+	// Queueing the repository again keeps one entry with the latest settings
+	added, err = q.push(types.Repository{Name: "repo", Branches: "default"}, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, added)
+	assert.Equal(t, 2, q.Len())
+
+	// An on-demand poll of a queued repository moves the entry to the front
+	waiter := make(chan pollOutcome, 1)
+	require.NoError(t, q.pushOnDemand(types.Repository{Name: "other"}, now.Add(time.Minute), waiter))
+	assert.Equal(t, 2, q.Len())
+
+	item, ok := q.pop(now.Add(2 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, "other", item.repo.Name)
+	item.deliver(&PollResult{Success: true}, nil)
+	assert.True(t, (<-waiter).result.Success)
+
+	item, ok = q.pop(now.Add(4 * time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, "default", item.repo.Branches)
+
+	stats := q.stats()
+	assert.Equal(t, 0, stats.Depth)
+	assert.Equal(t, int64(2), stats.Deduped)
+	assert.Equal(t, int64(2), stats.Dispatched)
+	assert.Equal(t, 3*time.Minute, stats.AverageWait)
+	assert.Equal(t, 4*time.Minute, stats.MaxWait)
+}
+
+func TestWorkQueue_Close(t *testing.T) {
+	q := newWorkQueue()
+	now := time.Now()
+
+	waiter := make(chan pollOutcome, 1)
+	require.NoError(t, q.pushOnDemand(types.Repository{Name: "hotfix"}, now, waiter))
+	q.close()
+
+	outcome := <-waiter
+	assert.Nil(t, outcome.result)
+	assert.Error(t, outcome.err, "waiting callers are told the poll will not run")
+	assert.Zero(t, q.Len())
+
+	_, err := q.push(types.Repository{Name: "repo"}, now)
+	assert.Error(t, err)
+}
+
+func TestPoller_PollNowThroughWorkers(t *testing.T) {
+	testLogger := logger.GetDefaultLogger().WithField("test", "work_queue")
+	storage := testutils.NewMockStorage()
+	storage.On("CreateEvent", mock.Anything, mock.Anything).Return(nil)
+
+	config := GetDefaultPollerConfig()
+	config.MaxWorkers = 1
+	p := NewPoller(config, storage, gitclient.NewClientFactory(testLogger), nil, nil, testLogger)
+	p.branchMonitor = &stubBranchMonitor{changes: []BranchChange{
+		{Repository: "org/hotfix", Branch: "main", NewCommitSHA: "def456", ChangeType: ChangeTypeNew},
+	}}
+
+	repo := types.Repository{Name: "org/hotfix", Provider: "github", Enabled: true, BranchRegex: ".*"}
+	require.NoError(t, p.AddRepository(repo))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, p.Start(ctx))
+	defer p.Stop(ctx)
+
+	result, err := p.PollNow(ctx, "org/hotfix")
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Len(t, result.Events, 1)
+
+	metrics := p.GetMetrics()
+	assert.Zero(t, metrics.QueueDepth)
+	assert.Equal(t, int64(1), metrics.TotalPolls)
+
+	// A queued poll of a repository that is being polled is skipped
+	require.True(t, p.beginPoll("org/hotfix"))
+	_, err = p.workQueue.push(repo, time.Now())
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return p.GetMetrics().SkippedPolls == 1
+	}, time.Second, 10*time.Millisecond)
+	p.endPoll("org/hotfix")
+}
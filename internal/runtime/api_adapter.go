@@ -2,8 +2,12 @@ package runtime
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/johnnynv/RepoSentry/internal/api"
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/poller"
 )
 
 // runtimeAPIAdapter adapts Runtime interface to api.RuntimeProvider
@@ -11,6 +15,11 @@ type runtimeAPIAdapter struct {
 	runtime Runtime
 }
 
+// pollerSource is implemented by runtimes that expose their poller
+type pollerSource interface {
+	GetPoller() poller.Poller
+}
+
 // newRuntimeAPIAdapter creates a new adapter
 func newRuntimeAPIAdapter(runtime Runtime) api.RuntimeProvider {
 	return &runtimeAPIAdapter{runtime: runtime}
@@ -42,6 +51,8 @@ func (a *runtimeAPIAdapter) Health(ctx context.Context) api.RuntimeHealthStatus
 			Name:     check.Name,
 			Status:   string(check.Status),
 			Duration: check.Duration,
+			Message:  check.Message,
+			Error:    check.Error,
 		}
 	}
 
@@ -71,6 +82,7 @@ func (a *runtimeAPIAdapter) GetStatus() *api.RuntimeStatus {
 			StartedAt: comp.StartedAt,
 			Uptime:    comp.Uptime,
 			Health:    string(comp.Health),
+			Metrics:   comp.Metrics,
 		}
 	}
 
@@ -82,3 +94,112 @@ func (a *runtimeAPIAdapter) GetStatus() *api.RuntimeStatus {
 		Components: components,
 	}
 }
+
+// GetRepositoryStatus implements api.RepositoryStatusProvider
+func (a *runtimeAPIAdapter) GetRepositoryStatus(name string) (api.RepositoryPollStatus, bool) {
+	source, ok := a.runtime.(pollerSource)
+	if !ok || source.GetPoller() == nil {
+		return api.RepositoryPollStatus{}, false
+	}
+
+	for _, repo := range source.GetPoller().GetStatus().Repositories {
+		if repo.Name != name {
+			continue
+		}
+		status := api.RepositoryPollStatus{
+			LastPollTime:        repo.LastPollTime,
+			NextPollTime:        repo.NextPollTime,
+			LastSuccess:         repo.LastSuccess,
+			LastError:           repo.LastError,
+			ConsecutiveFailures: repo.ConsecutiveFailures,
+			FailureReason:       repo.FailureReason,
+			Quarantined:         repo.Quarantined,
+			QuarantinedAt:       repo.QuarantinedAt,
+		}
+		if repo.Blackout != nil {
+			status.Blackout = &api.BlackoutStatus{
+				Window: repo.Blackout.Window,
+				Action: repo.Blackout.Action,
+				Ends:   repo.Blackout.Ends,
+			}
+		}
+		return status, true
+	}
+	return api.RepositoryPollStatus{}, false
+}
+
+// UnquarantineRepository implements api.RepositoryQuarantineManager
+func (a *runtimeAPIAdapter) UnquarantineRepository(name string) error {
+	source, ok := a.runtime.(pollerSource)
+	if !ok || source.GetPoller() == nil {
+		return fmt.Errorf("poller is not available")
+	}
+	return source.GetPoller().GetScheduler().Unquarantine(name)
+}
+
+// PollRepositoryNow implements api.RepositoryPoller
+func (a *runtimeAPIAdapter) PollRepositoryNow(ctx context.Context, name string) (*api.RepositoryPollResult, error) {
+	source, ok := a.runtime.(pollerSource)
+	if !ok || source.GetPoller() == nil {
+		return nil, fmt.Errorf("poller is not available")
+	}
+
+	result, err := source.GetPoller().PollNow(ctx, name)
+	if result == nil {
+		var inProgress *poller.PollInProgressError
+		var rateLimited *gitclient.RateLimitExceededError
+		switch {
+		case errors.As(err, &inProgress):
+			return nil, &api.PollInProgressError{Repository: inProgress.Repository}
+		case errors.As(err, &rateLimited):
+			return nil, &api.PollRateLimitedError{Provider: rateLimited.Provider, ResetTime: rateLimited.ResetTime}
+		}
+		return nil, err
+	}
+
+	// The poll ran; its failure is part of the result
+	return convertPollResult(name, result), nil
+}
+
+// convertPollResult converts a poller result to the API representation
+func convertPollResult(name string, result *poller.PollResult) *api.RepositoryPollResult {
+	apiResult := &api.RepositoryPollResult{
+		Repository: name,
+		Success:    result.Success,
+		Changes:    []api.PolledChange{},
+		EventIDs:   []string{},
+		Duration:   result.Duration,
+		Timestamp:  result.Timestamp,
+	}
+	if result.Error != nil {
+		apiResult.Error = result.Error.Error()
+	}
+	for _, change := range result.Changes {
+		apiResult.Changes = append(apiResult.Changes, api.PolledChange{
+			Branch:       change.Branch,
+			RefType:      change.RefType,
+			ChangeType:   change.ChangeType,
+			OldCommitSHA: change.OldCommitSHA,
+			NewCommitSHA: change.NewCommitSHA,
+		})
+	}
+	for _, change := range result.PullRequests {
+		apiResult.PullRequests = append(apiResult.PullRequests, api.PolledPullRequest{
+			Number:     change.PullRequest.Number,
+			Title:      change.PullRequest.Title,
+			ChangeType: change.ChangeType,
+			HeadSHA:    change.PullRequest.HeadSHA,
+		})
+	}
+	for _, event := range result.Events {
+		apiResult.EventIDs = append(apiResult.EventIDs, event.ID)
+	}
+	if result.Blackout != nil {
+		apiResult.Blackout = &api.BlackoutStatus{
+			Window: result.Blackout.Window,
+			Action: result.Blackout.Action,
+			Ends:   result.Blackout.Ends,
+		}
+	}
+	return apiResult
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/johnnynv/RepoSentry/internal/api"
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/poller"
+	"github.com/johnnynv/RepoSentry/pkg/types"
 )
 
 // Mock runtime for testing
@@ -215,3 +218,41 @@ func TestRuntimeAPIAdapter_GetStatus_WithNil(t *testing.T) {
 		t.Errorf("Expected 0 components when nil status, got %d", len(status.Components))
 	}
 }
+
+func TestConvertPollResult(t *testing.T) {
+	result := &poller.PollResult{
+		Success: false,
+		Error:   &gitclient.AuthenticationError{Provider: "github", Message: "bad credentials"},
+		Changes: []poller.BranchChange{
+			{Branch: "v1.2.0", RefType: "tag", ChangeType: "new", NewCommitSHA: "def456"},
+		},
+		PullRequests: []poller.PullRequestChange{
+			{PullRequest: types.PullRequest{Number: 42, Title: "Fix login", HeadSHA: "abc123"}, ChangeType: "opened"},
+		},
+		Events: []types.Event{{ID: "event-1"}, {ID: "event-2"}},
+	}
+
+	apiResult := convertPollResult("org/app", result)
+
+	if apiResult.Repository != "org/app" || apiResult.Success {
+		t.Errorf("Expected a failed poll of org/app, got %+v", apiResult)
+	}
+	if apiResult.Error != result.Error.Error() {
+		t.Errorf("Expected error %q, got %q", result.Error.Error(), apiResult.Error)
+	}
+	if len(apiResult.Changes) != 1 || apiResult.Changes[0].RefType != "tag" {
+		t.Errorf("Expected one tag change, got %+v", apiResult.Changes)
+	}
+	if len(apiResult.PullRequests) != 1 || apiResult.PullRequests[0].Number != 42 {
+		t.Errorf("Expected pull request #42, got %+v", apiResult.PullRequests)
+	}
+	if len(apiResult.EventIDs) != 2 || apiResult.EventIDs[1] != "event-2" {
+		t.Errorf("Expected two event IDs, got %v", apiResult.EventIDs)
+	}
+
+	// Polls without changes report empty lists rather than null
+	apiResult = convertPollResult("org/app", &poller.PollResult{Success: true})
+	if apiResult.Changes == nil || apiResult.EventIDs == nil {
+		t.Error("Expected empty changes and event IDs")
+	}
+}
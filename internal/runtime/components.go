@@ -282,6 +282,36 @@ func (c *GitClientFactoryComponent) Health(ctx context.Context) error {
 	return nil
 }
 
+// HealthChecks implements HealthCheckReporter with one check per provider API
+// circuit breaker. A circuit that is not closed is reported unhealthy.
+func (c *GitClientFactoryComponent) HealthChecks(ctx context.Context) []HealthCheck {
+	var checks []HealthCheck
+	for _, breaker := range c.factory.CircuitBreakers() {
+		check := HealthCheck{
+			Name:    "git_circuit:" + breaker.BaseURL,
+			Status:  HealthStateHealthy,
+			Message: string(breaker.State),
+		}
+		if breaker.State != gitclient.CircuitClosed {
+			check.Status = HealthStateUnhealthy
+			check.Error = breaker.LastError
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// GetStatus implements Component.GetStatus, reporting the shared rate limiters
+// and circuit breakers
+func (c *GitClientFactoryComponent) GetStatus() ComponentStatus {
+	status := c.BaseComponent.GetStatus()
+	status.Metrics = map[string]interface{}{
+		"rate_limits":      c.factory.RateLimits(),
+		"circuit_breakers": c.factory.CircuitBreakers(),
+	}
+	return status
+}
+
 // TriggerComponent wraps the trigger manager
 type TriggerComponent struct {
 	BaseComponent
@@ -486,6 +516,24 @@ func (c *PollerComponent) Stop(ctx context.Context) error {
 	return nil
 }
 
+// GetStatus implements Component.GetStatus, reporting API budget deferrals
+// and the work queue
+func (c *PollerComponent) GetStatus() ComponentStatus {
+	status := c.BaseComponent.GetStatus()
+	pollerStatus := c.poller.GetStatus()
+	metrics := c.poller.GetMetrics()
+	status.Metrics = map[string]interface{}{
+		"deferred_polls":     pollerStatus.DeferredPolls,
+		"budgets":            pollerStatus.Budgets,
+		"queue_depth":        metrics.QueueDepth,
+		"queue_wait_average": metrics.QueueWaitAverage.String(),
+		"queue_wait_max":     metrics.QueueWaitMax.String(),
+		"deduped_polls":      metrics.DedupedPolls,
+		"skipped_polls":      metrics.SkippedPolls,
+	}
+	return status
+}
+
 // Health implements Component.Health
 func (c *PollerComponent) Health(ctx context.Context) error {
 	status := c.poller.GetStatus()
@@ -1,13 +1,16 @@
 package runtime
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
 	"github.com/johnnynv/RepoSentry/internal/testutils"
+	"github.com/johnnynv/RepoSentry/pkg/types"
 )
 
 // ComponentTestSuite provides a test suite for runtime components
@@ -104,3 +107,55 @@ func (s *ComponentTestSuite) TestComponent_Uptime() {
 func TestComponentSuite(t *testing.T) {
 	suite.Run(t, new(ComponentTestSuite))
 }
+
+// TestGitClientFactoryComponent_RateLimitMetrics tests that rate limiter state is reported
+func (s *ComponentTestSuite) TestGitClientFactoryComponent_RateLimitMetrics() {
+	factory := gitclient.NewClientFactory(s.GetTestLogger().WithField("test", "git_client"))
+	config := gitclient.GetDefaultConfig()
+	config.Token = "test-token"
+	_, err := factory.CreateClient(types.Repository{
+		Name:     "repo",
+		URL:      "https://gitlab.example.com/group/repo",
+		Provider: "gitlab",
+	}, config)
+	s.RequireNoError(err)
+
+	comp := NewGitClientFactoryComponent(factory, s.GetTestLogger().WithField("test", "git_client"))
+	metrics, ok := comp.GetStatus().Metrics.(map[string]interface{})
+	s.Require().True(ok)
+
+	rateLimits, ok := metrics["rate_limits"].([]gitclient.RateLimitStatus)
+	s.Require().True(ok)
+	s.Require().Len(rateLimits, 1)
+	assert.Equal(s.T(), "gitlab", rateLimits[0].Provider)
+	assert.Equal(s.T(), "gitlab.example.com", rateLimits[0].Host)
+	assert.Equal(s.T(), 2000, rateLimits[0].Remaining)
+}
+
+// TestGitClientFactoryComponent_CircuitBreakers tests that breaker state is reported in status and health checks
+func (s *ComponentTestSuite) TestGitClientFactoryComponent_CircuitBreakers() {
+	factory := gitclient.NewClientFactory(s.GetTestLogger().WithField("test", "git_client"))
+	config := gitclient.GetDefaultConfig()
+	config.Token = "test-token"
+	_, err := factory.CreateClient(types.Repository{
+		Name:     "repo",
+		URL:      "https://gitlab.example.com/group/repo",
+		Provider: "gitlab",
+	}, config)
+	s.RequireNoError(err)
+
+	comp := NewGitClientFactoryComponent(factory, s.GetTestLogger().WithField("test", "git_client"))
+	metrics, ok := comp.GetStatus().Metrics.(map[string]interface{})
+	s.Require().True(ok)
+
+	breakers, ok := metrics["circuit_breakers"].([]gitclient.CircuitBreakerStatus)
+	s.Require().True(ok)
+	s.Require().Len(breakers, 1)
+	assert.Equal(s.T(), "https://gitlab.example.com/api/v4", breakers[0].BaseURL)
+
+	checks := comp.HealthChecks(context.Background())
+	s.Require().Len(checks, 1)
+	assert.Equal(s.T(), "git_circuit:https://gitlab.example.com/api/v4", checks[0].Name)
+	assert.Equal(s.T(), HealthStateHealthy, checks[0].Status)
+	assert.Equal(s.T(), "closed", checks[0].Message)
+}
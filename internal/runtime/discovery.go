@@ -0,0 +1,388 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/poller"
+	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// RepositoryListerFactory creates clients that list the repositories of a
+// discovery source. gitclient.ClientFactory implements it.
+type RepositoryListerFactory interface {
+	CreateLister(source types.DiscoverySource) (gitclient.RepositoryLister, error)
+}
+
+// DiscoverySourceStatus is the state of one discovery source
+type DiscoverySourceStatus struct {
+	Name         string    `json:"name"`
+	Provider     string    `json:"provider"`
+	Owner        string    `json:"owner"`
+	Repositories int       `json:"repositories"`
+	LastRefresh  time.Time `json:"last_refresh,omitempty"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+// discoverySource is a configured source and the repositories it has scheduled
+type discoverySource struct {
+	config       types.DiscoverySource
+	include      []*regexp.Regexp
+	exclude      []*regexp.Regexp
+	repositories map[string]types.Repository
+	lastRefresh  time.Time
+	lastError    string
+}
+
+// DiscoveryComponent schedules the repositories of GitHub organizations and
+// users and GitLab groups, refreshing each source's list periodically so
+// repositories are scheduled and unscheduled as they come and go
+type DiscoveryComponent struct {
+	BaseComponent
+	listers   RepositoryListerFactory
+	scheduler poller.Scheduler
+	sources   []*discoverySource
+
+	// Names and URLs of configured repositories, which discovery leaves alone
+	configured map[string]bool
+
+	mu     sync.Mutex
+	owners map[string]string // Discovered repository name to the source that scheduled it
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewDiscoveryComponent creates a new DiscoveryComponent
+func NewDiscoveryComponent(sources []types.DiscoverySource, configured []types.Repository, listers RepositoryListerFactory, scheduler poller.Scheduler, parentLogger *logger.Entry) (*DiscoveryComponent, error) {
+	c := &DiscoveryComponent{
+		BaseComponent: BaseComponent{
+			name:   "discovery",
+			logger: parentLogger.WithField("component", "discovery"),
+			state:  ComponentStateUnknown,
+		},
+		listers:    listers,
+		scheduler:  scheduler,
+		configured: make(map[string]bool),
+		owners:     make(map[string]string),
+	}
+
+	for _, repo := range configured {
+		c.configured[repo.Name] = true
+		c.configured[normalizeRepoURL(repo.URL)] = true
+	}
+
+	for _, config := range sources {
+		source := &discoverySource{
+			config:       config,
+			repositories: make(map[string]types.Repository),
+		}
+		for _, pattern := range config.Include {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid include pattern for discovery source %s: %w", config.Name, err)
+			}
+			source.include = append(source.include, re)
+		}
+		for _, pattern := range config.Exclude {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid exclude pattern for discovery source %s: %w", config.Name, err)
+			}
+			source.exclude = append(source.exclude, re)
+		}
+		c.sources = append(c.sources, source)
+	}
+
+	return c, nil
+}
+
+// Start implements Component.Start. Each source is listed right away and then
+// again every refresh interval.
+func (c *DiscoveryComponent) Start(ctx context.Context) error {
+	c.setState(ComponentStateStarting)
+	c.startedAt = time.Now()
+
+	c.logger.WithFields(logger.Fields{
+		"operation":    "start",
+		"source_count": len(c.sources),
+	}).Info("Starting discovery component")
+
+	ctx, cancel := context.WithCancel(ctx)
+	c.mu.Lock()
+	c.cancel = cancel
+	c.mu.Unlock()
+
+	for _, source := range c.sources {
+		c.wg.Add(1)
+		go c.run(ctx, source)
+	}
+
+	c.setState(ComponentStateRunning)
+	return nil
+}
+
+// Stop implements Component.Stop
+func (c *DiscoveryComponent) Stop(ctx context.Context) error {
+	c.setState(ComponentStateStopping)
+
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.mu.Unlock()
+	c.wg.Wait()
+
+	c.setState(ComponentStateStopped)
+
+	c.logger.WithFields(logger.Fields{
+		"operation": "stop",
+	}).Info("Discovery component stopped successfully")
+
+	return nil
+}
+
+// Health implements Component.Health
+func (c *DiscoveryComponent) Health(ctx context.Context) error {
+	return nil
+}
+
+// GetStatus implements Component.GetStatus, reporting each source's state
+func (c *DiscoveryComponent) GetStatus() ComponentStatus {
+	status := c.BaseComponent.GetStatus()
+	status.Metrics = map[string]interface{}{
+		"sources": c.Sources(),
+	}
+	return status
+}
+
+// Sources returns the state of every discovery source
+func (c *DiscoveryComponent) Sources() []DiscoverySourceStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	statuses := make([]DiscoverySourceStatus, 0, len(c.sources))
+	for _, source := range c.sources {
+		statuses = append(statuses, DiscoverySourceStatus{
+			Name:         source.config.Name,
+			Provider:     source.config.Provider,
+			Owner:        source.config.Owner(),
+			Repositories: len(source.repositories),
+			LastRefresh:  source.lastRefresh,
+			LastError:    source.lastError,
+		})
+	}
+	return statuses
+}
+
+// run refreshes a source until the context is canceled
+func (c *DiscoveryComponent) run(ctx context.Context, source *discoverySource) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(source.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.refresh(ctx, source); err != nil && ctx.Err() == nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation": "refresh",
+				"source":    source.config.Name,
+			}).Error("Failed to refresh discovery source")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh lists a source's repositories and brings the schedule in line: newly
+// matching repositories are scheduled and ones that disappeared or no longer
+// match are unscheduled. When listing fails the schedule is left as it was;
+// when the listing stops at the page cap only new repositories are scheduled.
+func (c *DiscoveryComponent) refresh(ctx context.Context, source *discoverySource) error {
+	discovered, err := c.list(ctx, source)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	source.lastRefresh = time.Now()
+	complete := err == nil
+	switch {
+	case gitclient.IsIncompleteListing(err):
+		// Repositories past the cap still exist: keep them scheduled
+		source.lastError = err.Error()
+		c.logger.WithError(err).WithFields(logger.Fields{
+			"operation": "refresh",
+			"source":    source.config.Name,
+		}).Warn("Repository list is incomplete, not unscheduling repositories")
+	case err != nil:
+		source.lastError = err.Error()
+		return err
+	default:
+		source.lastError = ""
+	}
+
+	wanted := make(map[string]types.Repository)
+	for _, repo := range discovered {
+		if !source.matches(repo) {
+			continue
+		}
+		if c.configured[repo.FullName] || c.configured[normalizeRepoURL(repo.URL)] {
+			continue
+		}
+		if owner, exists := c.owners[repo.FullName]; exists && owner != source.config.Name {
+			continue
+		}
+		wanted[repo.FullName] = source.repository(repo)
+	}
+
+	for name, repo := range source.repositories {
+		if _, keep := wanted[name]; keep || !complete {
+			continue
+		}
+		if err := c.scheduler.Unschedule(repo); err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "refresh",
+				"source":     source.config.Name,
+				"repository": name,
+			}).Warn("Failed to unschedule repository")
+			continue
+		}
+		delete(source.repositories, name)
+		delete(c.owners, name)
+
+		c.logger.WithFields(logger.Fields{
+			"operation":  "refresh",
+			"source":     source.config.Name,
+			"repository": name,
+		}).Info("Unscheduled repository that is no longer discovered")
+	}
+
+	for _, name := range sortedRepositoryNames(wanted) {
+		if _, scheduled := source.repositories[name]; scheduled {
+			continue
+		}
+		repo := wanted[name]
+		if err := c.scheduler.Schedule(repo); err != nil {
+			c.logger.WithError(err).WithFields(logger.Fields{
+				"operation":  "refresh",
+				"source":     source.config.Name,
+				"repository": name,
+			}).Warn("Failed to schedule discovered repository")
+			continue
+		}
+		source.repositories[name] = repo
+		c.owners[name] = source.config.Name
+
+		c.logger.WithFields(logger.Fields{
+			"operation":  "refresh",
+			"source":     source.config.Name,
+			"repository": name,
+			"url":        repo.URL,
+		}).Info("Scheduled discovered repository")
+	}
+
+	c.logger.WithFields(logger.Fields{
+		"operation":        "refresh",
+		"source":           source.config.Name,
+		"discovered_count": len(discovered),
+		"scheduled_count":  len(source.repositories),
+	}).Debug("Refreshed discovery source")
+
+	return nil
+}
+
+// list fetches the current repositories of a source
+func (c *DiscoveryComponent) list(ctx context.Context, source *discoverySource) ([]gitclient.DiscoveredRepository, error) {
+	lister, err := c.listers.CreateLister(source.config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return lister.ListRepositories(ctx, source.config)
+}
+
+// matches applies the source's filters to a discovered repository. Name
+// patterns match the path below the organization, user or group.
+func (s *discoverySource) matches(repo gitclient.DiscoveredRepository) bool {
+	if s.config.SkipArchived && repo.Archived {
+		return false
+	}
+	if s.config.SkipForks && repo.Fork {
+		return false
+	}
+
+	name := strings.TrimPrefix(repo.FullName, s.config.Owner()+"/")
+	if len(s.include) > 0 && !matchesAny(s.include, name) {
+		return false
+	}
+	if matchesAny(s.exclude, name) {
+		return false
+	}
+
+	if len(s.config.Topics) > 0 {
+		for _, topic := range repo.Topics {
+			for _, wantedTopic := range s.config.Topics {
+				if strings.EqualFold(topic, wantedTopic) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// repository builds the repository configuration for a discovered repository
+func (s *discoverySource) repository(repo gitclient.DiscoveredRepository) types.Repository {
+	return types.Repository{
+		Name:            repo.FullName,
+		URL:             repo.URL,
+		Provider:        s.config.Provider,
+		Token:           s.config.Token,
+		APIBaseURL:      s.config.APIBaseURL,
+		BranchRegex:     s.config.BranchRegex,
+		Branches:        s.config.Branches,
+		TagRegex:        s.config.TagRegex,
+		PullRequests:    s.config.PullRequests,
+		PollingInterval: s.config.PollingInterval,
+		Priority:        s.config.Priority,
+		Enabled:         true,
+	}
+}
+
+// matchesAny reports whether any of the patterns matches name
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeRepoURL makes repository URLs comparable regardless of case, a
+// trailing slash or a .git suffix
+func normalizeRepoURL(repoURL string) string {
+	repoURL = strings.ToLower(strings.TrimSpace(repoURL))
+	repoURL = strings.TrimSuffix(repoURL, "/")
+	return strings.TrimSuffix(repoURL, ".git")
+}
+
+// sortedRepositoryNames returns the names of repos in order
+func sortedRepositoryNames(repos map[string]types.Repository) []string {
+	names := make([]string, 0, len(repos))
+	for name := range repos {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
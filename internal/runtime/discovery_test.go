@@ -0,0 +1,174 @@
+package runtime
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
+	"github.com/johnnynv/RepoSentry/internal/poller"
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+// fakeListers serves fixed repository lists per discovery source
+type fakeListers struct {
+	repos map[string][]gitclient.DiscoveredRepository
+	err   error
+}
+
+func (f *fakeListers) CreateLister(source types.DiscoverySource) (gitclient.RepositoryLister, error) {
+	return &fakeLister{listers: f}, nil
+}
+
+type fakeLister struct {
+	listers *fakeListers
+}
+
+func (l *fakeLister) ListRepositories(ctx context.Context, source types.DiscoverySource) ([]gitclient.DiscoveredRepository, error) {
+	if l.listers.err != nil && !gitclient.IsIncompleteListing(l.listers.err) {
+		return nil, l.listers.err
+	}
+	return l.listers.repos[source.Name], l.listers.err
+}
+
+func scheduledNames(scheduler poller.Scheduler) []string {
+	var names []string
+	for _, repo := range scheduler.GetScheduledRepositories() {
+		names = append(names, repo.Repository.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TestDiscoveryComponent_Refresh tests that discovered repositories are filtered and scheduled
+func (s *ComponentTestSuite) TestDiscoveryComponent_Refresh() {
+	testLogger := s.GetTestLogger().WithField("test", "discovery")
+	scheduler := poller.NewScheduler(poller.GetDefaultPollerConfig(), testLogger)
+
+	listers := &fakeListers{repos: map[string][]gitclient.DiscoveredRepository{
+		"platform": {
+			{FullName: "acme/api", URL: "https://github.com/acme/api", Topics: []string{"Service"}},
+			{FullName: "acme/web", URL: "https://github.com/acme/web", Topics: []string{"frontend"}},
+			{FullName: "acme/old-api", URL: "https://github.com/acme/old-api", Topics: []string{"service"}, Archived: true},
+			{FullName: "acme/api-fork", URL: "https://github.com/acme/api-fork", Topics: []string{"service"}, Fork: true},
+			{FullName: "acme/api-sandbox", URL: "https://github.com/acme/api-sandbox", Topics: []string{"service"}},
+			{FullName: "acme/billing", URL: "https://github.com/acme/billing", Topics: []string{"service"}},
+		},
+	}}
+
+	sources := []types.DiscoverySource{{
+		Name:            "platform",
+		Provider:        "github",
+		Organization:    "acme",
+		Token:           "org-token",
+		Exclude:         []string{"-sandbox$"},
+		Topics:          []string{"service"},
+		SkipArchived:    true,
+		SkipForks:       true,
+		RefreshInterval: time.Hour,
+		BranchRegex:     "^main$",
+		PollingInterval: 10 * time.Minute,
+	}}
+	// Configured repositories win over discovered ones with the same URL
+	configured := []types.Repository{{Name: "billing", URL: "https://github.com/acme/billing.git"}}
+
+	comp, err := NewDiscoveryComponent(sources, configured, listers, scheduler, testLogger)
+	s.RequireNoError(err)
+
+	s.RequireNoError(comp.refresh(context.Background(), comp.sources[0]))
+	assert.Equal(s.T(), []string{"acme/api"}, scheduledNames(scheduler))
+
+	repo := scheduler.GetScheduledRepositories()[0].Repository
+	assert.Equal(s.T(), "https://github.com/acme/api", repo.URL)
+	assert.Equal(s.T(), "github", repo.Provider)
+	assert.Equal(s.T(), "org-token", repo.Token)
+	assert.Equal(s.T(), "^main$", repo.BranchRegex)
+	assert.Equal(s.T(), 10*time.Minute, repo.PollingInterval)
+	assert.True(s.T(), repo.Enabled)
+
+	statuses := comp.Sources()
+	s.Require().Len(statuses, 1)
+	assert.Equal(s.T(), "acme", statuses[0].Owner)
+	assert.Equal(s.T(), 1, statuses[0].Repositories)
+	assert.False(s.T(), statuses[0].LastRefresh.IsZero())
+}
+
+// TestDiscoveryComponent_RefreshChanges tests that repositories are unscheduled when they disappear,
+// and that a failed listing leaves the schedule alone
+func (s *ComponentTestSuite) TestDiscoveryComponent_RefreshChanges() {
+	testLogger := s.GetTestLogger().WithField("test", "discovery")
+	scheduler := poller.NewScheduler(poller.GetDefaultPollerConfig(), testLogger)
+
+	listers := &fakeListers{repos: map[string][]gitclient.DiscoveredRepository{
+		"backend": {
+			{FullName: "platform/backend/api", URL: "https://gitlab.com/platform/backend/api"},
+			{FullName: "platform/backend/worker", URL: "https://gitlab.com/platform/backend/worker"},
+		},
+		// Lists a repository the first source already scheduled
+		"everything": {
+			{FullName: "platform/backend/api", URL: "https://gitlab.com/platform/backend/api"},
+			{FullName: "platform/docs", URL: "https://gitlab.com/platform/docs"},
+		},
+	}}
+
+	sources := []types.DiscoverySource{
+		{Name: "backend", Provider: "gitlab", Group: "platform/backend", RefreshInterval: time.Hour, BranchRegex: ".*"},
+		{Name: "everything", Provider: "gitlab", Group: "platform", Include: []string{"^(backend/|docs$)"}, RefreshInterval: time.Hour, BranchRegex: ".*"},
+	}
+
+	comp, err := NewDiscoveryComponent(sources, nil, listers, scheduler, testLogger)
+	s.RequireNoError(err)
+
+	s.RequireNoError(comp.refresh(context.Background(), comp.sources[0]))
+	s.RequireNoError(comp.refresh(context.Background(), comp.sources[1]))
+	assert.Equal(s.T(), []string{"platform/backend/api", "platform/backend/worker", "platform/docs"}, scheduledNames(scheduler))
+	assert.Len(s.T(), comp.sources[1].repositories, 1, "a repository belongs to the first source that scheduled it")
+
+	// A failed listing keeps what is scheduled
+	listers.err = errors.New("gitlab unavailable")
+	assert.Error(s.T(), comp.refresh(context.Background(), comp.sources[0]))
+	assert.Len(s.T(), scheduler.GetScheduledRepositories(), 3)
+	assert.Equal(s.T(), "gitlab unavailable", comp.Sources()[0].LastError)
+
+	// Repositories gone from the listing are unscheduled
+	listers.err = nil
+	listers.repos["backend"] = listers.repos["backend"][:1]
+	s.RequireNoError(comp.refresh(context.Background(), comp.sources[0]))
+	assert.Equal(s.T(), []string{"platform/backend/api", "platform/docs"}, scheduledNames(scheduler))
+	assert.Empty(s.T(), comp.Sources()[0].LastError)
+
+	// A listing cut short by the page cap schedules new repositories but
+	// keeps the ones it did not reach
+	listers.err = &gitclient.IncompleteListingError{Provider: "gitlab", Resource: "projects of platform/backend", MaxPages: 1}
+	listers.repos["backend"] = []gitclient.DiscoveredRepository{
+		{FullName: "platform/backend/jobs", URL: "https://gitlab.com/platform/backend/jobs"},
+	}
+	s.RequireNoError(comp.refresh(context.Background(), comp.sources[0]))
+	assert.Equal(s.T(), []string{"platform/backend/api", "platform/backend/jobs", "platform/docs"}, scheduledNames(scheduler))
+	assert.Contains(s.T(), comp.Sources()[0].LastError, "page limit")
+}
+
+// TestDiscoveryComponent_StartStop tests that sources are refreshed in the background
+func (s *ComponentTestSuite) TestDiscoveryComponent_StartStop() {
+	testLogger := s.GetTestLogger().WithField("test", "discovery")
+	scheduler := poller.NewScheduler(poller.GetDefaultPollerConfig(), testLogger)
+	listers := &fakeListers{repos: map[string][]gitclient.DiscoveredRepository{
+		"acme": {{FullName: "acme/api", URL: "https://github.com/acme/api"}},
+	}}
+
+	comp, err := NewDiscoveryComponent([]types.DiscoverySource{
+		{Name: "acme", Provider: "github", Organization: "acme", RefreshInterval: time.Hour, BranchRegex: ".*"},
+	}, nil, listers, scheduler, testLogger)
+	s.RequireNoError(err)
+
+	s.RequireNoError(comp.Start(context.Background()))
+	assert.Eventually(s.T(), func() bool {
+		return len(scheduler.GetScheduledRepositories()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	s.RequireNoError(comp.Stop(context.Background()))
+	assert.Equal(s.T(), ComponentStateStopped, comp.GetStatus().State)
+}
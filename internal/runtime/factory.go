@@ -54,8 +54,8 @@ func validateRuntimeConfig(config *types.Config) error {
 	}
 
 	// Validate polling configuration
-	if len(config.Repositories) == 0 {
-		return fmt.Errorf("at least one repository must be configured")
+	if len(config.Repositories) == 0 && len(config.Discovery) == 0 {
+		return fmt.Errorf("at least one repository or discovery source must be configured")
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package runtime
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"sync"
 	"time"
 
@@ -119,6 +120,21 @@ func (rm *RuntimeManager) initializeComponents() error {
 
 	// 3. Git Client Factory
 	gitFactory := gitclient.NewClientFactory(rm.loggerManager.ForComponent("gitclient"))
+	gitFactory.SetPagination(rm.config.Polling.PageSize, rm.config.Polling.MaxPages)
+	gitFactory.SetGitHubApp(rm.config.GitHubApp)
+	gitFactory.SetMirrorDir(filepath.Join(rm.config.App.DataDir, "mirrors"))
+	gitFactory.SetHTTPConfig(rm.config.HTTP)
+	gitFactory.SetRateLimits(rm.config.RateLimit)
+	gitFactory.SetCircuitBreaker(rm.config.Polling.CircuitBreaker)
+	if !rm.config.Polling.HTTPCache.Disabled {
+		var cacheStore gitclient.HTTPCacheStore
+		if rm.config.Polling.HTTPCache.Persist {
+			cacheStore = rm.storage
+		}
+		httpCache := gitclient.NewHTTPCache(cacheStore, rm.loggerManager.ForComponent("gitclient"))
+		httpCache.SetMaxAge(rm.config.Polling.HTTPCache.MaxAge)
+		gitFactory.SetHTTPCache(httpCache)
+	}
 	gitComponent := NewGitClientFactoryComponent(gitFactory, rm.loggerManager.ForComponent("gitclient"))
 	rm.addComponent("git_client", gitComponent)
 
@@ -128,6 +144,7 @@ func (rm *RuntimeManager) initializeComponents() error {
 	triggerConfig.Tekton.EventListenerURL = rm.config.Tekton.EventListenerURL
 	triggerConfig.Tekton.Namespace = "default"
 	triggerConfig.Timeout = rm.config.Tekton.Timeout
+	triggerConfig.HTTP = rm.config.HTTP
 
 	rm.triggerManager, err = triggerFactory.Create(triggerConfig, rm.loggerManager.ForComponent("trigger"))
 	if err != nil {
@@ -147,6 +164,17 @@ func (rm *RuntimeManager) initializeComponents() error {
 		gitFactory,
 		rm.triggerManager,
 		rm.loggerManager.ForComponent("tekton"))
+	if !rm.config.Tekton.BlobCache.Disabled {
+		var blobDir string
+		if rm.config.Tekton.BlobCache.Persist {
+			blobDir = filepath.Join(rm.config.App.DataDir, "blobs")
+		}
+		blobCache, err := gitclient.NewBlobCache(blobDir, int64(rm.config.Tekton.BlobCache.MaxMemoryMB)*1024*1024, rm.loggerManager.ForComponent("tekton"))
+		if err != nil {
+			return fmt.Errorf("failed to create blob cache: %w", err)
+		}
+		tektonManager.SetBlobCache(blobCache)
+	}
 
 	rm.logger.Info("Tekton integration enabled with TektonTriggerManager")
 
@@ -156,7 +184,16 @@ func (rm *RuntimeManager) initializeComponents() error {
 	pollerComponent := NewPollerComponent(rm.poller, rm.config.Repositories, rm.loggerManager.ForComponent("poller"))
 	rm.addComponent("poller", pollerComponent)
 
-	// 6. API Server (includes health endpoints)
+	// 7. Repository discovery, scheduling into the poller started before it
+	if len(rm.config.Discovery) > 0 {
+		discoveryComponent, err := NewDiscoveryComponent(rm.config.Discovery, rm.config.Repositories, gitFactory, rm.poller.GetScheduler(), rm.loggerManager.ForComponent("discovery"))
+		if err != nil {
+			return fmt.Errorf("failed to create discovery component: %w", err)
+		}
+		rm.addComponent("discovery", discoveryComponent)
+	}
+
+	// 8. API Server (includes health endpoints)
 	if rm.config.App.HealthCheckPort > 0 {
 		apiComponent := NewAPIComponent(rm.configManager, rm.storage, rm.config.App.HealthCheckPort, rm, rm.loggerManager.ForComponent("api"))
 		rm.addComponent("api_server", apiComponent)
@@ -181,6 +218,20 @@ func pollerConfigFromConfig(config *types.Config) poller.PollerConfig {
 		EnableFallback: config.Polling.EnableAPIFallback,
 		RetryAttempts:  config.Polling.RetryAttempts,
 		RetryBackoff:   config.Polling.RetryBackoff,
+
+		GitHubGraphQL:    config.Polling.GitHubGraphQL,
+		GraphQLBatchSize: config.Polling.GraphQLBatchSize,
+		MaxCommits:       config.Polling.MaxCommits,
+
+		Jitter: config.Polling.Jitter,
+		Splay:  config.Polling.Splay,
+
+		Schedule:        config.Polling.Schedule,
+		BlackoutWindows: config.Polling.BlackoutWindows,
+		BlackoutAction:  config.Polling.BlackoutAction,
+
+		MaxBackoff:      config.Polling.FailureBackoff.MaxBackoff,
+		QuarantineAfter: config.Polling.FailureBackoff.QuarantineAfter,
 	}
 }
 
@@ -352,6 +403,15 @@ func (rm *RuntimeManager) Health(ctx context.Context) (*HealthStatus, error) {
 		}
 
 		healthStatus.Checks = append(healthStatus.Checks, check)
+
+		if reporter, ok := component.(HealthCheckReporter); ok {
+			for _, extra := range reporter.HealthChecks(ctx) {
+				if extra.Status == HealthStateUnhealthy {
+					healthStatus.Status = HealthStateUnhealthy
+				}
+				healthStatus.Checks = append(healthStatus.Checks, extra)
+			}
+		}
 	}
 
 	return healthStatus, nil
@@ -404,6 +464,11 @@ func (rm *RuntimeManager) GetConfig() *types.Config {
 	return rm.config
 }
 
+// GetPoller returns the poller, or nil before the runtime is initialized
+func (rm *RuntimeManager) GetPoller() poller.Poller {
+	return rm.poller
+}
+
 // GetLogger returns the runtime logger
 func (rm *RuntimeManager) GetLogger() *logger.Entry {
 	return rm.logger
@@ -113,6 +113,12 @@ type Component interface {
 	GetStatus() ComponentStatus
 }
 
+// HealthCheckReporter is implemented by components that report health checks
+// for things they depend on in addition to their own health
+type HealthCheckReporter interface {
+	HealthChecks(ctx context.Context) []HealthCheck
+}
+
 // RuntimeConfig holds runtime-specific configuration
 type RuntimeConfig struct {
 	// HealthCheck configuration
@@ -84,7 +84,7 @@ func TestMigrationManager_ApplyMigrations(t *testing.T) {
 	}
 
 	// Check that tables exist
-	tables := []string{"repository_states", "events", "schema_migrations"}
+	tables := []string{"repository_states", "events", "http_cache", "tag_states", "pull_request_states", "schema_migrations"}
 	for _, table := range tables {
 		var exists int
 		query := "SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name=?"
@@ -103,7 +103,7 @@ func TestMigrationManager_ApplyMigrations(t *testing.T) {
 		t.Fatalf("Failed to get applied migrations: %v", err)
 	}
 
-	expectedMigrations := 3 // We have 3 migrations (including error_message column)
+	expectedMigrations := 6 // We have 6 migrations (including error_message column, http_cache, tag_states and pull_request_states)
 	if len(applied) != expectedMigrations {
 		t.Errorf("Expected %d applied migrations, got %d", expectedMigrations, len(applied))
 	}
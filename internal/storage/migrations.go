@@ -111,6 +111,74 @@ func (m *MigrationManager) GetMigrations() []Migration {
 				-- For now, just leave the column but don't use it
 			`,
 		},
+
+		// Migration 4: Add http_cache table for conditional API requests
+		{
+			Version:     4,
+			Name:        "add_http_cache",
+			Description: "Add http_cache table storing ETag / Last-Modified validated API responses",
+			Up: `
+				CREATE TABLE IF NOT EXISTS http_cache (
+					cache_key TEXT PRIMARY KEY,
+					etag TEXT,
+					last_modified TEXT,
+					headers TEXT,
+					body BLOB,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+				);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS http_cache;
+			`,
+		},
+
+		// Migration 5: Add tag_states table for tag monitoring
+		{
+			Version:     5,
+			Name:        "add_tag_states",
+			Description: "Add tag_states table tracking the commit each monitored tag points to",
+			Up: `
+				CREATE TABLE IF NOT EXISTS tag_states (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					repository TEXT NOT NULL,
+					tag TEXT NOT NULL,
+					commit_sha TEXT NOT NULL,
+					last_checked DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE(repository, tag)
+				);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS tag_states;
+			`,
+		},
+
+		// Migration 6: Add pull_request_states table for pull request monitoring
+		{
+			Version:     6,
+			Name:        "add_pull_request_states",
+			Description: "Add pull_request_states table tracking open pull requests and their head commits",
+			Up: `
+				CREATE TABLE IF NOT EXISTS pull_request_states (
+					id INTEGER PRIMARY KEY AUTOINCREMENT,
+					repository TEXT NOT NULL,
+					number INTEGER NOT NULL,
+					head_sha TEXT NOT NULL,
+					source_branch TEXT NOT NULL,
+					target_branch TEXT NOT NULL,
+					title TEXT,
+					author TEXT,
+					last_checked DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+					UNIQUE(repository, number)
+				);
+			`,
+			Down: `
+				DROP TABLE IF EXISTS pull_request_states;
+			`,
+		},
 	}
 }
 
@@ -56,6 +56,32 @@ type RepositoryState struct {
 	UpdatedAt  time.Time `json:"updated_at"`
 }
 
+// TagState represents the commit a monitored tag pointed to when last checked
+type TagState struct {
+	ID         int64     `json:"id"`
+	Repository string    `json:"repository"`
+	Tag        string    `json:"tag"`
+	CommitSHA  string    `json:"commit_sha"`
+	LastCheck  time.Time `json:"last_check"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// PullRequestState represents an open pull request as of the last check
+type PullRequestState struct {
+	ID           int64     `json:"id"`
+	Repository   string    `json:"repository"`
+	Number       int       `json:"number"`
+	HeadSHA      string    `json:"head_sha"`
+	SourceBranch string    `json:"source_branch"`
+	TargetBranch string    `json:"target_branch"`
+	Title        string    `json:"title"`
+	Author       string    `json:"author"`
+	LastCheck    time.Time `json:"last_check"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
 // SQLiteEvent represents event in SQLite
 type SQLiteEvent struct {
 	ID          string       `db:"id"`
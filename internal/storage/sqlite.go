@@ -465,6 +465,219 @@ func (s *SQLiteStorage) UpsertRepoState(ctx context.Context, state RepositorySta
 	return nil
 }
 
+// GetTagStates retrieves all tag states for a repository
+func (s *SQLiteStorage) GetTagStates(ctx context.Context, repository string) ([]TagState, error) {
+	query := `
+		SELECT id, repository, tag, commit_sha, last_checked, created_at, updated_at
+		FROM tag_states
+		WHERE repository = ?
+		ORDER BY tag
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tag states: %w", err)
+	}
+	defer rows.Close()
+
+	var states []TagState
+	for rows.Next() {
+		var state TagState
+		err := rows.Scan(&state.ID, &state.Repository, &state.Tag, &state.CommitSHA,
+			&state.LastCheck, &state.CreatedAt, &state.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan tag state: %w", err)
+		}
+		states = append(states, state)
+	}
+
+	return states, rows.Err()
+}
+
+// UpsertTagState inserts or updates a tag state
+func (s *SQLiteStorage) UpsertTagState(ctx context.Context, state TagState) error {
+	query := `
+		INSERT INTO tag_states (repository, tag, commit_sha, last_checked, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repository, tag) DO UPDATE SET
+			commit_sha = excluded.commit_sha,
+			last_checked = excluded.last_checked,
+			updated_at = excluded.updated_at
+	`
+
+	now := time.Now()
+	if state.CreatedAt.IsZero() {
+		state.CreatedAt = now
+	}
+	state.UpdatedAt = now
+
+	_, err := s.db.ExecContext(ctx, query,
+		state.Repository, state.Tag, state.CommitSHA,
+		state.LastCheck, state.CreatedAt, state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert tag state: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTagState deletes a tag state
+func (s *SQLiteStorage) DeleteTagState(ctx context.Context, repository, tag string) error {
+	query := "DELETE FROM tag_states WHERE repository = ? AND tag = ?"
+	if _, err := s.db.ExecContext(ctx, query, repository, tag); err != nil {
+		return fmt.Errorf("failed to delete tag state: %w", err)
+	}
+	return nil
+}
+
+// GetPullRequestStates retrieves all open pull request states for a repository
+func (s *SQLiteStorage) GetPullRequestStates(ctx context.Context, repository string) ([]PullRequestState, error) {
+	query := `
+		SELECT id, repository, number, head_sha, source_branch, target_branch, title, author,
+			last_checked, created_at, updated_at
+		FROM pull_request_states
+		WHERE repository = ?
+		ORDER BY number
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, repository)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pull request states: %w", err)
+	}
+	defer rows.Close()
+
+	var states []PullRequestState
+	for rows.Next() {
+		var state PullRequestState
+		var title, author sql.NullString
+		err := rows.Scan(&state.ID, &state.Repository, &state.Number, &state.HeadSHA,
+			&state.SourceBranch, &state.TargetBranch, &title, &author,
+			&state.LastCheck, &state.CreatedAt, &state.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan pull request state: %w", err)
+		}
+		state.Title = title.String
+		state.Author = author.String
+		states = append(states, state)
+	}
+
+	return states, rows.Err()
+}
+
+// UpsertPullRequestState inserts or updates a pull request state
+func (s *SQLiteStorage) UpsertPullRequestState(ctx context.Context, state PullRequestState) error {
+	query := `
+		INSERT INTO pull_request_states (repository, number, head_sha, source_branch, target_branch,
+			title, author, last_checked, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(repository, number) DO UPDATE SET
+			head_sha = excluded.head_sha,
+			source_branch = excluded.source_branch,
+			target_branch = excluded.target_branch,
+			title = excluded.title,
+			author = excluded.author,
+			last_checked = excluded.last_checked,
+			updated_at = excluded.updated_at
+	`
+
+	now := time.Now()
+	if state.CreatedAt.IsZero() {
+		state.CreatedAt = now
+	}
+	state.UpdatedAt = now
+
+	_, err := s.db.ExecContext(ctx, query,
+		state.Repository, state.Number, state.HeadSHA, state.SourceBranch, state.TargetBranch,
+		state.Title, state.Author, state.LastCheck, state.CreatedAt, state.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert pull request state: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePullRequestState deletes a pull request state
+func (s *SQLiteStorage) DeletePullRequestState(ctx context.Context, repository string, number int) error {
+	query := "DELETE FROM pull_request_states WHERE repository = ? AND number = ?"
+	if _, err := s.db.ExecContext(ctx, query, repository, number); err != nil {
+		return fmt.Errorf("failed to delete pull request state: %w", err)
+	}
+	return nil
+}
+
+// GetHTTPCacheEntry retrieves a cached API response by key, or nil if none is stored
+func (s *SQLiteStorage) GetHTTPCacheEntry(ctx context.Context, key string) (*types.HTTPCacheEntry, error) {
+	query := `
+		SELECT cache_key, etag, last_modified, headers, body, updated_at
+		FROM http_cache
+		WHERE cache_key = ?
+	`
+
+	entry := &types.HTTPCacheEntry{}
+	var etag, lastModified, headers sql.NullString
+	err := s.db.QueryRowContext(ctx, query, key).Scan(
+		&entry.Key, &etag, &lastModified, &headers, &entry.Body, &entry.UpdatedAt)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get http cache entry: %w", err)
+	}
+
+	entry.ETag = etag.String
+	entry.LastModified = lastModified.String
+	if headers.Valid && headers.String != "" {
+		if err := json.Unmarshal([]byte(headers.String), &entry.Headers); err != nil {
+			return nil, fmt.Errorf("failed to decode http cache headers: %w", err)
+		}
+	}
+
+	return entry, nil
+}
+
+// SaveHTTPCacheEntry inserts or replaces a cached API response
+func (s *SQLiteStorage) SaveHTTPCacheEntry(ctx context.Context, entry *types.HTTPCacheEntry) error {
+	query := `
+		INSERT INTO http_cache (cache_key, etag, last_modified, headers, body, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(cache_key) DO UPDATE SET
+			etag = excluded.etag,
+			last_modified = excluded.last_modified,
+			headers = excluded.headers,
+			body = excluded.body,
+			updated_at = excluded.updated_at
+	`
+
+	headers, err := json.Marshal(entry.Headers)
+	if err != nil {
+		return fmt.Errorf("failed to encode http cache headers: %w", err)
+	}
+
+	if entry.UpdatedAt.IsZero() {
+		entry.UpdatedAt = time.Now()
+	}
+
+	_, err = s.db.ExecContext(ctx, query,
+		entry.Key, entry.ETag, entry.LastModified, string(headers), entry.Body, entry.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save http cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOldHTTPCacheEntries deletes cached API responses last downloaded before the specified time
+func (s *SQLiteStorage) DeleteOldHTTPCacheEntries(ctx context.Context, before time.Time) (int64, error) {
+	query := "DELETE FROM http_cache WHERE updated_at < ?"
+	result, err := s.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete old http cache entries: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
 // GetEvents retrieves events with pagination
 func (s *SQLiteStorage) GetEvents(ctx context.Context, limit, offset int) ([]*types.Event, error) {
 	query := `
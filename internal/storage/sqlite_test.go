@@ -353,6 +353,165 @@ func TestSQLiteStorage_DeleteRepoState(t *testing.T) {
 	}
 }
 
+func TestSQLiteStorage_HTTPCacheEntry(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := storage.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	// Missing entries are not an error
+	entry, err := storage.GetHTTPCacheEntry(ctx, "https://api.github.com/repos/o/r/branches")
+	if err != nil {
+		t.Fatalf("Failed to get missing cache entry: %v", err)
+	}
+	if entry != nil {
+		t.Fatalf("Expected no cache entry, got %+v", entry)
+	}
+
+	saved := &types.HTTPCacheEntry{
+		Key:     "https://api.github.com/repos/o/r/branches",
+		ETag:    `W/"abc"`,
+		Headers: map[string]string{"Link": `<https://api.github.com/x?page=2>; rel="next"`},
+		Body:    []byte(`[{"name":"main"}]`),
+	}
+	if err := storage.SaveHTTPCacheEntry(ctx, saved); err != nil {
+		t.Fatalf("Failed to save cache entry: %v", err)
+	}
+
+	// Saving again replaces the entry
+	saved.ETag = `W/"def"`
+	if err := storage.SaveHTTPCacheEntry(ctx, saved); err != nil {
+		t.Fatalf("Failed to update cache entry: %v", err)
+	}
+
+	entry, err = storage.GetHTTPCacheEntry(ctx, saved.Key)
+	if err != nil {
+		t.Fatalf("Failed to get cache entry: %v", err)
+	}
+	if entry == nil || entry.ETag != `W/"def"` || string(entry.Body) != `[{"name":"main"}]` {
+		t.Errorf("Unexpected cache entry: %+v", entry)
+	}
+	if entry != nil && entry.Headers["Link"] != saved.Headers["Link"] {
+		t.Errorf("Expected Link header to round-trip, got %q", entry.Headers["Link"])
+	}
+
+	// Entries not downloaded again since the cutoff are deleted
+	old := &types.HTTPCacheEntry{Key: "https://api.github.com/old", ETag: `W/"old"`, UpdatedAt: time.Now().Add(-48 * time.Hour)}
+	if err := storage.SaveHTTPCacheEntry(ctx, old); err != nil {
+		t.Fatalf("Failed to save cache entry: %v", err)
+	}
+	deleted, err := storage.DeleteOldHTTPCacheEntries(ctx, time.Now().Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to delete old cache entries: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("Expected 1 deleted cache entry, got %d", deleted)
+	}
+	if entry, _ := storage.GetHTTPCacheEntry(ctx, old.Key); entry != nil {
+		t.Errorf("Expected old cache entry to be deleted, got %+v", entry)
+	}
+	if entry, _ := storage.GetHTTPCacheEntry(ctx, saved.Key); entry == nil {
+		t.Error("Expected recent cache entry to be kept")
+	}
+}
+
+func TestSQLiteStorage_TagStates(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := storage.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	for _, state := range []TagState{
+		{Repository: "repo", Tag: "v1.0.0", CommitSHA: "aaa", LastCheck: time.Now()},
+		{Repository: "repo", Tag: "v1.1.0", CommitSHA: "bbb", LastCheck: time.Now()},
+		{Repository: "repo", Tag: "v1.0.0", CommitSHA: "ccc", LastCheck: time.Now()}, // moved
+		{Repository: "other", Tag: "v1.0.0", CommitSHA: "ddd", LastCheck: time.Now()},
+	} {
+		if err := storage.UpsertTagState(ctx, state); err != nil {
+			t.Fatalf("Failed to upsert tag state: %v", err)
+		}
+	}
+
+	states, err := storage.GetTagStates(ctx, "repo")
+	if err != nil {
+		t.Fatalf("Failed to get tag states: %v", err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("Expected 2 tag states, got %d", len(states))
+	}
+	if states[0].Tag != "v1.0.0" || states[0].CommitSHA != "ccc" {
+		t.Errorf("Expected v1.0.0 to point to ccc, got %+v", states[0])
+	}
+
+	if err := storage.DeleteTagState(ctx, "repo", "v1.0.0"); err != nil {
+		t.Fatalf("Failed to delete tag state: %v", err)
+	}
+	states, err = storage.GetTagStates(ctx, "repo")
+	if err != nil {
+		t.Fatalf("Failed to get tag states: %v", err)
+	}
+	if len(states) != 1 || states[0].Tag != "v1.1.0" {
+		t.Errorf("Expected only v1.1.0 to remain, got %+v", states)
+	}
+
+	// Branch states are not affected by tag states
+	branchStates, err := storage.GetRepoStates(ctx, "repo")
+	if err != nil {
+		t.Fatalf("Failed to get repository states: %v", err)
+	}
+	if len(branchStates) != 0 {
+		t.Errorf("Expected no branch states, got %d", len(branchStates))
+	}
+}
+
+func TestSQLiteStorage_PullRequestStates(t *testing.T) {
+	storage, cleanup := createTestStorage(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	if err := storage.Initialize(ctx); err != nil {
+		t.Fatalf("Failed to initialize storage: %v", err)
+	}
+
+	for _, state := range []PullRequestState{
+		{Repository: "repo", Number: 7, HeadSHA: "aaa", SourceBranch: "feature", TargetBranch: "main", Title: "Add feature", Author: "octocat", LastCheck: time.Now()},
+		{Repository: "repo", Number: 3, HeadSHA: "bbb", SourceBranch: "fix", TargetBranch: "main", LastCheck: time.Now()},
+		{Repository: "repo", Number: 7, HeadSHA: "ccc", SourceBranch: "feature", TargetBranch: "main", Title: "Add feature", Author: "octocat", LastCheck: time.Now()},
+	} {
+		if err := storage.UpsertPullRequestState(ctx, state); err != nil {
+			t.Fatalf("Failed to upsert pull request state: %v", err)
+		}
+	}
+
+	states, err := storage.GetPullRequestStates(ctx, "repo")
+	if err != nil {
+		t.Fatalf("Failed to get pull request states: %v", err)
+	}
+	if len(states) != 2 {
+		t.Fatalf("Expected 2 pull request states, got %d", len(states))
+	}
+	if states[1].Number != 7 || states[1].HeadSHA != "ccc" || states[1].Author != "octocat" {
+		t.Errorf("Unexpected pull request state: %+v", states[1])
+	}
+
+	if err := storage.DeletePullRequestState(ctx, "repo", 3); err != nil {
+		t.Fatalf("Failed to delete pull request state: %v", err)
+	}
+	states, err = storage.GetPullRequestStates(ctx, "repo")
+	if err != nil {
+		t.Fatalf("Failed to get pull request states: %v", err)
+	}
+	if len(states) != 1 || states[0].Number != 7 {
+		t.Errorf("Expected only #7 to remain, got %+v", states)
+	}
+}
+
 // createTestStorage creates a test storage instance with a temporary database
 func createTestStorage(t *testing.T) (*SQLiteStorage, func()) {
 	tempDir := t.TempDir()
@@ -35,6 +35,22 @@ type Storage interface {
 	// Enhanced repository state operations for poller
 	UpsertRepoState(ctx context.Context, state RepositoryState) error
 
+	// Tag state operations
+	GetTagStates(ctx context.Context, repository string) ([]TagState, error)
+	UpsertTagState(ctx context.Context, state TagState) error
+	DeleteTagState(ctx context.Context, repository, tag string) error
+
+	// Pull request state operations
+	GetPullRequestStates(ctx context.Context, repository string) ([]PullRequestState, error)
+	UpsertPullRequestState(ctx context.Context, state PullRequestState) error
+	DeletePullRequestState(ctx context.Context, repository string, number int) error
+
+	// HTTP cache operations for conditional provider API requests.
+	// GetHTTPCacheEntry returns nil without error when no entry exists.
+	GetHTTPCacheEntry(ctx context.Context, key string) (*types.HTTPCacheEntry, error)
+	SaveHTTPCacheEntry(ctx context.Context, entry *types.HTTPCacheEntry) error
+	DeleteOldHTTPCacheEntries(ctx context.Context, before time.Time) (int64, error)
+
 	// Statistics operations
 	GetStats(ctx context.Context) (*StorageStats, error)
 }
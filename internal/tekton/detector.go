@@ -20,6 +20,7 @@ import (
 // It uses GitClient to access remote repository content via GitHub/GitLab APIs.
 type TektonDetector struct {
 	gitClient gitclient.GitClient
+	blobCache *gitclient.BlobCache // Optional, skips downloading files already seen
 	logger    *logger.Entry
 	config    DetectorConfig
 }
@@ -66,6 +67,7 @@ type TektonDetection struct {
 // TektonFile represents a single Tekton YAML file
 type TektonFile struct {
 	Path         string    `json:"path"`
+	SHA          string    `json:"sha,omitempty"` // Blob SHA, when the provider reports it
 	Size         int64     `json:"size"`
 	LastModified time.Time `json:"last_modified,omitempty"`
 	IsValid      bool      `json:"is_valid"`
@@ -174,12 +176,12 @@ func (d *TektonDetector) DetectTektonResources(ctx context.Context, repo types.R
 	detection.TotalFiles = len(files)
 
 	// Process each file
-	for _, filePath := range files {
-		if d.isTektonFile(filePath) {
-			tektonFile, resources, err := d.processFile(ctx, repo, commitSHA, filePath)
+	for _, file := range files {
+		if d.isTektonFile(file.Path) {
+			tektonFile, resources, err := d.processFile(ctx, repo, commitSHA, file)
 			if err != nil {
 				d.logger.WithError(err).WithFields(logger.Fields{
-					"file_path":  filePath,
+					"file_path":  file.Path,
 					"repository": repo.Name,
 				}).Warn("Failed to process Tekton file")
 
@@ -222,14 +224,14 @@ func (d *TektonDetector) isTektonFile(filePath string) bool {
 }
 
 // processFile processes a single Tekton YAML file
-func (d *TektonDetector) processFile(ctx context.Context, repo types.Repository, commitSHA, filePath string) (TektonFile, []TektonResource, error) {
+func (d *TektonDetector) processFile(ctx context.Context, repo types.Repository, commitSHA string, file gitclient.FileEntry) (TektonFile, []TektonResource, error) {
 	tektonFile := TektonFile{
-		Path:    filePath,
+		Path:    file.Path,
+		SHA:     file.SHA,
 		IsValid: false,
 	}
 
-	// Get file content from REMOTE repository
-	content, err := d.gitClient.GetFileContent(ctx, repo, commitSHA, filePath)
+	content, err := d.getFileContent(ctx, repo, commitSHA, file)
 	if err != nil {
 		return tektonFile, nil, fmt.Errorf("failed to get file content: %w", err)
 	}
@@ -242,7 +244,7 @@ func (d *TektonDetector) processFile(ctx context.Context, repo types.Repository,
 	}
 
 	// Parse YAML content
-	resources, err := d.parseYAMLContent(content, filePath)
+	resources, err := d.parseYAMLContent(content, file.Path)
 	if err != nil {
 		return tektonFile, nil, fmt.Errorf("failed to parse YAML: %w", err)
 	}
@@ -251,6 +253,28 @@ func (d *TektonDetector) processFile(ctx context.Context, repo types.Repository,
 	return tektonFile, resources, nil
 }
 
+// getFileContent returns a file's content from the blob cache when its blob
+// has been seen before, and from the REMOTE repository otherwise
+func (d *TektonDetector) getFileContent(ctx context.Context, repo types.Repository, commitSHA string, file gitclient.FileEntry) ([]byte, error) {
+	if content, ok := d.blobCache.Get(file.SHA); ok {
+		d.logger.WithFields(logger.Fields{
+			"operation":  "get_file_content",
+			"repository": repo.Name,
+			"file_path":  file.Path,
+			"sha":        file.SHA,
+		}).Debug("Using cached file content")
+		return content, nil
+	}
+
+	content, err := d.gitClient.GetFileContent(ctx, repo, commitSHA, file.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	d.blobCache.Put(file.SHA, content)
+	return content, nil
+}
+
 // parseYAMLContent parses YAML content and extracts Tekton resources
 func (d *TektonDetector) parseYAMLContent(content []byte, filePath string) ([]TektonResource, error) {
 	var resources []TektonResource
@@ -377,6 +401,11 @@ func (d *TektonDetector) SetConfig(config DetectorConfig) {
 	d.config = config
 }
 
+// SetBlobCache sets the cache consulted before downloading a file by its blob SHA
+func (d *TektonDetector) SetBlobCache(cache *gitclient.BlobCache) {
+	d.blobCache = cache
+}
+
 // GetConfig returns the current detector configuration
 func (d *TektonDetector) GetConfig() DetectorConfig {
 	return d.config
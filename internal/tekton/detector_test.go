@@ -2,11 +2,13 @@ package tekton
 
 import (
 	"context"
+	"crypto/sha1"
 	"fmt"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/johnnynv/RepoSentry/internal/gitclient"
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
 )
@@ -15,7 +17,10 @@ import (
 type MockGitClient struct {
 	directoryExists map[string]bool
 	filesList       map[string][]string
+	fileSHAs        map[string]string // Blob SHAs reported by ListFiles, by file path
 	fileContents    map[string][]byte
+	contentRequests int
+	defaultBranch   string
 	shouldError     bool
 	errorMessage    string
 }
@@ -37,18 +42,23 @@ func (m *MockGitClient) CheckDirectoryExists(ctx context.Context, repo types.Rep
 	return m.directoryExists[key], nil
 }
 
-func (m *MockGitClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]string, error) {
+func (m *MockGitClient) ListFiles(ctx context.Context, repo types.Repository, commitSHA, path string) ([]gitclient.FileEntry, error) {
 	if m.shouldError {
 		return nil, fmt.Errorf("%s", m.errorMessage)
 	}
 	key := fmt.Sprintf("%s:%s:%s", repo.Name, commitSHA, path)
-	return m.filesList[key], nil
+	var files []gitclient.FileEntry
+	for _, filePath := range m.filesList[key] {
+		files = append(files, gitclient.FileEntry{Path: filePath, SHA: m.fileSHAs[filePath]})
+	}
+	return files, nil
 }
 
 func (m *MockGitClient) GetFileContent(ctx context.Context, repo types.Repository, commitSHA, filePath string) ([]byte, error) {
 	if m.shouldError {
 		return nil, fmt.Errorf("%s", m.errorMessage)
 	}
+	m.contentRequests++
 	key := fmt.Sprintf("%s:%s:%s", repo.Name, commitSHA, filePath)
 	content, exists := m.fileContents[key]
 	if !exists {
@@ -62,10 +72,33 @@ func (m *MockGitClient) GetBranches(ctx context.Context, repo types.Repository)
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (m *MockGitClient) GetTags(ctx context.Context, repo types.Repository) ([]types.Tag, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockGitClient) ListPullRequests(ctx context.Context, repo types.Repository) ([]types.PullRequest, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockGitClient) GetPullRequest(ctx context.Context, repo types.Repository, number int) (*types.PullRequest, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (m *MockGitClient) CompareCommits(ctx context.Context, repo types.Repository, base, head string) (*types.CommitComparison, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 func (m *MockGitClient) GetLatestCommit(ctx context.Context, repo types.Repository, branch string) (string, error) {
 	return "", fmt.Errorf("not implemented")
 }
 
+func (m *MockGitClient) GetDefaultBranch(ctx context.Context, repo types.Repository) (string, error) {
+	if m.defaultBranch == "" {
+		return "", fmt.Errorf("not implemented")
+	}
+	return m.defaultBranch, nil
+}
+
 func (m *MockGitClient) CheckPermissions(ctx context.Context, repo types.Repository) error {
 	return fmt.Errorf("not implemented")
 }
@@ -401,6 +434,68 @@ func TestDetectTektonResources_GitClientError(t *testing.T) {
 	}
 }
 
+func TestDetectTektonResources_BlobCache(t *testing.T) {
+	mockClient := NewMockGitClient()
+	testLogger := createTestLogger()
+	detector := NewTektonDetector(mockClient, testLogger)
+
+	cache, err := gitclient.NewBlobCache("", 0, testLogger)
+	if err != nil {
+		t.Fatalf("Failed to create blob cache: %v", err)
+	}
+	detector.SetBlobCache(cache)
+
+	repo := types.Repository{
+		Name:     "test-repo",
+		URL:      "https://github.com/test/repo",
+		Provider: "github",
+	}
+
+	pipelineRunYAML := []byte(`
+apiVersion: tekton.dev/v1beta1
+kind: PipelineRun
+metadata:
+  name: test-pipeline-run
+`)
+	// The object name git gives the file's blob
+	pipelineRunSHA := fmt.Sprintf("%x", sha1.Sum(append([]byte(fmt.Sprintf("blob %d\x00", len(pipelineRunYAML))), pipelineRunYAML...)))
+	mockClient.fileSHAs = map[string]string{".tekton/pipelinerun.yaml": pipelineRunSHA}
+
+	// The same file on two branches
+	for _, commitSHA := range []string{"abc123", "def456"} {
+		mockClient.SetDirectoryExists("test-repo", commitSHA, ".tekton", true)
+		mockClient.SetFilesList("test-repo", commitSHA, ".tekton", []string{".tekton/pipelinerun.yaml"})
+		mockClient.SetFileContent("test-repo", commitSHA, ".tekton/pipelinerun.yaml", pipelineRunYAML)
+	}
+
+	ctx := context.Background()
+	for _, commitSHA := range []string{"abc123", "def456"} {
+		detection, err := detector.DetectTektonResources(ctx, repo, commitSHA, "main")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(detection.Resources) != 1 {
+			t.Errorf("Expected 1 resource for %s, got %d", commitSHA, len(detection.Resources))
+		}
+		if detection.TektonFiles[0].SHA != pipelineRunSHA {
+			t.Errorf("Expected file SHA %s, got %s", pipelineRunSHA, detection.TektonFiles[0].SHA)
+		}
+	}
+
+	if mockClient.contentRequests != 1 {
+		t.Errorf("Expected the blob to be downloaded once, got %d requests", mockClient.contentRequests)
+	}
+
+	// Files without a blob SHA are always downloaded
+	mockClient.fileSHAs = nil
+	if _, err := detector.DetectTektonResources(ctx, repo, "abc123", "main"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if mockClient.contentRequests != 2 {
+		t.Errorf("Expected a file without SHA to be downloaded, got %d requests", mockClient.contentRequests)
+	}
+}
+
 func TestIsTektonResource(t *testing.T) {
 	mockClient := NewMockGitClient()
 	testLogger := createTestLogger()
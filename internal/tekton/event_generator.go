@@ -8,6 +8,7 @@ import (
 
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // TektonEventGenerator generates events from Tekton detection results
@@ -262,15 +263,13 @@ func extractOwnerFromURL(repoURL string) string {
 	// Examples:
 	// https://github.com/owner/repo -> owner
 	// https://gitlab.com/group/project -> group
-
-	// Remove protocol
-	url := repoURL
-	if idx := strings.Index(url, "://"); idx != -1 {
-		url = url[idx+3:]
+	// git@github.com:owner/repo.git -> owner
+	if parsedURL, err := utils.ParseGitURL(repoURL); err == nil && parsedURL.Host != "" {
+		return strings.Split(strings.Trim(parsedURL.Path, "/"), "/")[0]
 	}
 
-	// Split by / and get the second part
-	parts := strings.Split(url, "/")
+	// Without a scheme the host is the first segment: github.com/owner/repo
+	parts := strings.Split(repoURL, "/")
 	if len(parts) >= 2 {
 		return parts[1]
 	}
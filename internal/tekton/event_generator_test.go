@@ -419,6 +419,16 @@ func TestExtractOwnerFromURL(t *testing.T) {
 			url:      "https://github.com/owner/repo.git",
 			expected: "owner",
 		},
+		{
+			name:     "SSH URL",
+			url:      "git@github.com:owner/repo.git",
+			expected: "owner",
+		},
+		{
+			name:     "SSH URL with scheme",
+			url:      "ssh://git@gitlab.example.com:2222/group/project.git",
+			expected: "group",
+		},
 		{
 			name:     "URL without protocol",
 			url:      "github.com/owner/repo",
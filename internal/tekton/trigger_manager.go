@@ -15,6 +15,7 @@ import (
 // This manager only handles detection and triggering, with Bootstrap Pipeline pre-deployed
 type TektonTriggerManager struct {
 	clientFactory  gitclient.GitClientFactory
+	blobCache      *gitclient.BlobCache
 	eventGenerator *TektonEventGenerator
 	trigger        trigger.Trigger
 	logger         *logger.Entry
@@ -34,11 +35,17 @@ func NewTektonTriggerManager(clientFactory gitclient.GitClientFactory, tektonTri
 	}
 }
 
+// SetBlobCache sets the cache shared by detections to skip downloading files whose blobs were seen before
+func (ttm *TektonTriggerManager) SetBlobCache(cache *gitclient.BlobCache) {
+	ttm.blobCache = cache
+}
+
 // TektonProcessRequest represents a simplified request to process a repository
 type TektonProcessRequest struct {
 	Repository types.Repository
 	CommitSHA  string
 	Branch     string
+	Metadata   map[string]string // Metadata of the originating event, e.g. pull request details
 }
 
 // TektonProcessResult represents the simplified result of processing
@@ -75,9 +82,7 @@ func (ttm *TektonTriggerManager) ProcessRepositoryChange(ctx context.Context, re
 	clientConfig.Token = request.Repository.Token
 
 	// Set provider-specific configuration
-	if request.Repository.Provider == "gitlab" && request.Repository.APIBaseURL != "" {
-		clientConfig.BaseURL = request.Repository.APIBaseURL
-	} else if request.Repository.Provider == "github" && request.Repository.APIBaseURL != "" {
+	if request.Repository.APIBaseURL != "" {
 		clientConfig.BaseURL = request.Repository.APIBaseURL
 	}
 
@@ -98,6 +103,7 @@ func (ttm *TektonTriggerManager) ProcessRepositoryChange(ctx context.Context, re
 
 	// Create TektonDetector with the repository-specific GitClient
 	detector := NewTektonDetector(gitClient, ttm.logger)
+	detector.SetBlobCache(ttm.blobCache)
 
 	// Step 1: Detect Tekton resources in remote repository
 	detection, err := detector.DetectTektonResources(ctx, request.Repository, request.CommitSHA, request.Branch)
@@ -212,6 +218,13 @@ func (ttm *TektonTriggerManager) SendBootstrapEvent(ctx context.Context, request
 		UpdatedAt: time.Now().UTC(),
 	}
 
+	// Carry over details of the originating event without overriding detection fields
+	for key, value := range request.Metadata {
+		if _, exists := cloudEvent.Metadata[key]; !exists {
+			cloudEvent.Metadata[key] = value
+		}
+	}
+
 	// Send event using the trigger (which will route to Bootstrap Pipeline)
 	_, err = ttm.trigger.SendEvent(ctx, *cloudEvent)
 	if err != nil {
@@ -241,9 +254,7 @@ func (ttm *TektonTriggerManager) GetDetectionStatus(ctx context.Context, reposit
 	clientConfig.Token = repository.Token
 
 	// Set provider-specific configuration
-	if repository.Provider == "gitlab" && repository.APIBaseURL != "" {
-		clientConfig.BaseURL = repository.APIBaseURL
-	} else if repository.Provider == "github" && repository.APIBaseURL != "" {
+	if repository.APIBaseURL != "" {
 		clientConfig.BaseURL = repository.APIBaseURL
 	}
 
@@ -259,9 +270,21 @@ func (ttm *TektonTriggerManager) GetDetectionStatus(ctx context.Context, reposit
 
 	// Create TektonDetector with the repository-specific GitClient
 	detector := NewTektonDetector(gitClient, ttm.logger)
+	detector.SetBlobCache(ttm.blobCache)
+
+	// The commit is reported against the repository's default branch
+	branch := repository.DefaultBranch
+	if branch == "" {
+		branch, err = gitClient.GetDefaultBranch(ctx, repository)
+		if err != nil {
+			ttm.logger.WithError(err).WithFields(logger.Fields{
+				"repository": repository.Name,
+			}).Warn("Failed to get default branch for detection status")
+		}
+	}
 
 	// Perform detection only (no triggering)
-	detection, err := detector.DetectTektonResources(ctx, repository, commitSHA, "main")
+	detection, err := detector.DetectTektonResources(ctx, repository, commitSHA, branch)
 	if err != nil {
 		ttm.logger.WithError(err).WithFields(logger.Fields{
 			"repository": repository.Name,
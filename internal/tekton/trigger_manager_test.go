@@ -377,6 +377,7 @@ spec:
   - name: echo
     image: ubuntu`),
 		},
+		defaultBranch: "trunk",
 	}
 	trigger := &MockTrigger{}
 
@@ -405,6 +406,10 @@ spec:
 		t.Errorf("Expected 1 resource to be detected, got: %d", len(detection.Resources))
 	}
 
+	if detection.Branch != "trunk" {
+		t.Errorf("Expected detection to report the default branch 'trunk', got: %s", detection.Branch)
+	}
+
 	// Verify no events sent to trigger (status check only)
 	sentEvents := trigger.GetSentEvents()
 	if len(sentEvents) != 0 {
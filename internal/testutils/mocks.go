@@ -132,6 +132,60 @@ func (m *MockStorage) UpsertRepoState(ctx context.Context, state storage.Reposit
 	return args.Error(0)
 }
 
+func (m *MockStorage) GetTagStates(ctx context.Context, repository string) ([]storage.TagState, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]storage.TagState), args.Error(1)
+}
+
+func (m *MockStorage) UpsertTagState(ctx context.Context, state storage.TagState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *MockStorage) DeleteTagState(ctx context.Context, repository, tag string) error {
+	args := m.Called(ctx, repository, tag)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetPullRequestStates(ctx context.Context, repository string) ([]storage.PullRequestState, error) {
+	args := m.Called(ctx, repository)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]storage.PullRequestState), args.Error(1)
+}
+
+func (m *MockStorage) UpsertPullRequestState(ctx context.Context, state storage.PullRequestState) error {
+	args := m.Called(ctx, state)
+	return args.Error(0)
+}
+
+func (m *MockStorage) DeletePullRequestState(ctx context.Context, repository string, number int) error {
+	args := m.Called(ctx, repository, number)
+	return args.Error(0)
+}
+
+func (m *MockStorage) GetHTTPCacheEntry(ctx context.Context, key string) (*types.HTTPCacheEntry, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*types.HTTPCacheEntry), args.Error(1)
+}
+
+func (m *MockStorage) SaveHTTPCacheEntry(ctx context.Context, entry *types.HTTPCacheEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockStorage) DeleteOldHTTPCacheEntries(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockStorage) GetStats(ctx context.Context) (*storage.StorageStats, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
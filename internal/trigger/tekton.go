@@ -3,7 +3,6 @@ package trigger
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"github.com/johnnynv/RepoSentry/pkg/types"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // TektonTrigger implements Trigger interface for Tekton EventListener
@@ -38,11 +38,20 @@ func NewTektonTrigger(config TriggerConfig, parentLogger *logger.Entry) (*Tekton
 		Timeout: config.Timeout,
 	}
 
-	// Configure TLS if needed
-	if config.Tekton.TLSConfig.InsecureSkipVerify {
-		transport := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// Proxy and TLS settings are shared with the Git clients; the EventListener's
+	// own TLS files take precedence
+	tlsConfig := config.Tekton.TLSConfig
+	httpSettings := config.HTTP.Merge(types.HTTPClientConfig{
+		CAFile:   tlsConfig.CAFile,
+		CertFile: tlsConfig.CertFile,
+		KeyFile:  tlsConfig.KeyFile,
+	})
+	if !httpSettings.IsZero() || tlsConfig.InsecureSkipVerify {
+		transport, err := utils.NewHTTPTransport(httpSettings)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP client configuration: %w", err)
 		}
+		transport.TLSClientConfig.InsecureSkipVerify = tlsConfig.InsecureSkipVerify
 		httpClient.Transport = transport
 	}
 
@@ -3,9 +3,12 @@ package trigger
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
 	"github.com/johnnynv/RepoSentry/pkg/logger"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -403,6 +406,56 @@ func TestTektonTrigger_HealthCheck(t *testing.T) {
 	}
 }
 
+func TestTektonTrigger_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	newTrigger := func(config TriggerConfig) *TektonTrigger {
+		config.Type = "tekton"
+		config.Enabled = true
+		config.Timeout = 5 * time.Second
+		config.Tekton.EventListenerURL = server.URL
+		config.Tekton.Namespace = "tekton-pipelines"
+
+		trigger, err := NewTektonTrigger(config, logger.GetDefaultLogger().WithField("test", "tekton"))
+		if err != nil {
+			t.Fatalf("Failed to create trigger: %v", err)
+		}
+		return trigger
+	}
+
+	// The self-signed EventListener certificate is rejected by default
+	if err := newTrigger(TriggerConfig{}).HealthCheck(context.Background()); err == nil {
+		t.Error("Expected certificate verification to fail without the CA bundle")
+	}
+
+	// Both the shared HTTP settings and the EventListener TLS settings can supply the CA
+	if err := newTrigger(TriggerConfig{HTTP: types.HTTPClientConfig{CAFile: caFile}}).HealthCheck(context.Background()); err != nil {
+		t.Errorf("Unexpected error with shared CA bundle: %v", err)
+	}
+	if err := newTrigger(TriggerConfig{Tekton: TektonConfig{TLSConfig: TLSConfig{CAFile: caFile}}}).HealthCheck(context.Background()); err != nil {
+		t.Errorf("Unexpected error with EventListener CA bundle: %v", err)
+	}
+
+	if _, err := NewTektonTrigger(TriggerConfig{
+		Type:    "tekton",
+		Enabled: true,
+		Timeout: 5 * time.Second,
+		Tekton:  TektonConfig{EventListenerURL: server.URL},
+		HTTP:    types.HTTPClientConfig{CAFile: "/nonexistent/ca.pem"},
+	}, logger.GetDefaultLogger().WithField("test", "tekton")); err == nil {
+		t.Error("Expected error for missing CA bundle")
+	}
+}
+
 func TestTektonTrigger_GetMetrics(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusAccepted)
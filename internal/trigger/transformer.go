@@ -1,6 +1,7 @@
 package trigger
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
@@ -48,18 +49,20 @@ func (t *EventTransformerImpl) TransformToCloudEvents(event types.Event) (CloudE
 
 	// Create repository data
 	repository := CloudEventsRepository{
-		Provider:     repoInfo.Provider,
-		Organization: repoInfo.Namespace,   // Use Namespace as Organization
-		Name:         repoInfo.ProjectName, // Use ProjectName as Name
-		FullName:     repoInfo.FullName,
-		URL:          repositoryURL,
-		ID:           fmt.Sprintf("%s-%s", strings.ToLower(repoInfo.Provider), strings.ReplaceAll(strings.ToLower(repoInfo.FullName), "/", "-")),
+		Provider:      repoInfo.Provider,
+		Organization:  repoInfo.Namespace,   // Use Namespace as Organization
+		Name:          repoInfo.ProjectName, // Use ProjectName as Name
+		FullName:      repoInfo.FullName,
+		URL:           repositoryURL,
+		ID:            fmt.Sprintf("%s-%s", strings.ToLower(repoInfo.Provider), strings.ReplaceAll(strings.ToLower(repoInfo.FullName), "/", "-")),
+		DefaultBranch: event.Metadata["repository_default_branch"],
 	}
 
 	// Create branch data
 	branch := CloudEventsBranch{
-		Name: event.Branch,
-		Ref:  fmt.Sprintf("refs/heads/%s", event.Branch),
+		Name:    event.Branch,
+		Ref:     t.getEventRef(event),
+		Default: event.Metadata["default_branch"] == "true",
 	}
 
 	// Create commit data
@@ -77,9 +80,17 @@ func (t *EventTransformerImpl) TransformToCloudEvents(event types.Event) (CloudE
 	}
 
 	commit := CloudEventsCommit{
-		SHA:      event.CommitSHA,
-		ShortSHA: shortSHA,
-		Message:  commitMessage,
+		SHA:       event.CommitSHA,
+		ShortSHA:  shortSHA,
+		Message:   commitMessage,
+		Timestamp: event.Metadata["commit_timestamp"],
+		URL:       event.Metadata["commit_url"],
+	}
+	if authorName := event.Metadata["author_name"]; authorName != "" {
+		commit.Author = &CloudEventsAuthor{
+			Name:  authorName,
+			Email: event.Metadata["author_email"],
+		}
 	}
 
 	// Create previous commit data if available
@@ -106,6 +117,10 @@ func (t *EventTransformerImpl) TransformToCloudEvents(event types.Event) (CloudE
 		eventTypeStr = "branch_created"
 	case types.EventTypeBranchDeleted:
 		eventTypeStr = "branch_deleted"
+	case types.EventTypeTagCreated, types.EventTypeTagDeleted, types.EventTypeTagMoved,
+		types.EventTypePullRequestOpened, types.EventTypePullRequestUpdated,
+		types.EventTypePullRequestClosed, types.EventTypePullRequestMerged:
+		eventTypeStr = string(event.Type)
 	case types.EventTypeTektonDetected:
 		eventTypeStr = "tekton_detected"
 	default:
@@ -133,6 +148,10 @@ func (t *EventTransformerImpl) TransformToCloudEvents(event types.Event) (CloudE
 			Commit:         commit,
 			Event:          eventData,
 			PreviousCommit: previousCommit,
+			PullRequest:    t.getPullRequest(event),
+			Commits:        t.getCloudEventsCommits(event),
+			ChangedFiles:   t.getChangedFiles(event),
+			MatchedPaths:   t.getMatchedPaths(event),
 		},
 	}
 
@@ -167,20 +186,43 @@ func (t *EventTransformerImpl) TransformToGitHub(event types.Event) (GitHubPaylo
 	// Create GitHub-style payload
 	payload := GitHubPayload{
 		Repository: GitHubRepository{
-			Name:     event.Repository,
-			FullName: repo.FullName,
-			CloneURL: repo.CloneURL,
-			HTMLURL:  repo.HTMLURL,
-			Private:  repo.Private,
+			Name:          event.Repository,
+			FullName:      repo.FullName,
+			CloneURL:      repo.CloneURL,
+			HTMLURL:       repo.HTMLURL,
+			Private:       repo.Private,
+			DefaultBranch: event.Metadata["repository_default_branch"],
 		},
 		After:    event.CommitSHA,
 		ShortSHA: t.getShortSHA(event.CommitSHA),
-		Ref:      t.getBranchRef(event.Branch),
+		Ref:      t.getEventRef(event),
 		Before:   event.PrevCommit,
 	}
 
-	// Add commit information if available
-	if commit := t.createCommitFromEvent(event); commit != nil {
+	// Add commit information, preferring the commit range fetched by the poller
+	if commits := t.getCommits(event); len(commits) > 0 {
+		payload.Commits = make([]GitHubCommit, 0, len(commits))
+		for _, commit := range commits {
+			payload.Commits = append(payload.Commits, GitHubCommit{
+				ID:        commit.SHA,
+				Message:   commit.Message,
+				Timestamp: commit.Timestamp,
+				URL:       commit.URL,
+				Author: GitHubUser{
+					Name:  commit.AuthorName,
+					Email: commit.AuthorEmail,
+				},
+				Added:    commit.Added,
+				Removed:  commit.Removed,
+				Modified: commit.Modified,
+			})
+		}
+		if head := payload.Commits[len(payload.Commits)-1]; head.ID == event.CommitSHA {
+			payload.HeadCommit = &head
+		} else {
+			payload.HeadCommit = t.createCommitFromEvent(event)
+		}
+	} else if commit := t.createCommitFromEvent(event); commit != nil {
 		payload.HeadCommit = commit
 		payload.Commits = []GitHubCommit{*commit}
 	}
@@ -240,6 +282,10 @@ func (t *EventTransformerImpl) TransformToTekton(event types.Event) (TektonPaylo
 	// Generate unique identifier for this repository + provider combination
 	metadata["repository_id"] = fmt.Sprintf("%s-%s", event.Provider, strings.ReplaceAll(event.Repository, "/", "-"))
 
+	if matchedPaths := t.getMatchedPaths(event); matchedPaths != nil {
+		metadata["matched_paths"] = matchedPaths
+	}
+
 	// Add trigger context for debugging
 	metadata["trigger_source"] = "reposentry"
 	metadata["trigger_id"] = fmt.Sprintf("reposentry-%s-%d", event.Provider, event.Timestamp.Unix())
@@ -388,6 +434,10 @@ func (t *EventTransformerImpl) getDefaultInstance(provider string) string {
 		return "github.com"
 	case "gitlab":
 		return "gitlab.com"
+	case "bitbucket":
+		return "bitbucket.org"
+	case "gitea":
+		return "gitea.com"
 	default:
 		return "gitlab.com" // Default fallback
 	}
@@ -417,6 +467,131 @@ func (t *EventTransformerImpl) getBranchRef(branch string) string {
 	return "refs/heads/" + branch
 }
 
+// getEventRef returns the Git ref of the branch or tag an event refers to. Events
+// forwarded by the Tekton manager keep the original ref type in their metadata.
+func (t *EventTransformerImpl) getEventRef(event types.Event) string {
+	isTag := event.Type.IsTagEvent() || event.Metadata["ref_type"] == "tag"
+	if isTag && !strings.HasPrefix(event.Branch, "refs/") {
+		return "refs/tags/" + event.Branch
+	}
+	return t.getBranchRef(event.Branch)
+}
+
+// getPullRequest builds pull request data from event metadata, or returns nil for
+// events that do not refer to a pull request
+func (t *EventTransformerImpl) getPullRequest(event types.Event) *CloudEventsPullRequest {
+	number, err := strconv.Atoi(event.Metadata["pr_number"])
+	if err != nil {
+		return nil
+	}
+
+	labels := []string{}
+	if raw := event.Metadata["pr_labels"]; raw != "" {
+		if err := json.Unmarshal([]byte(raw), &labels); err != nil {
+			t.logger.WithError(err).WithFields(logger.Fields{
+				"operation": "get_pull_request",
+				"event_id":  event.ID,
+			}).Warn("Failed to decode pull request labels")
+			labels = []string{}
+		}
+	}
+	draft, _ := strconv.ParseBool(event.Metadata["pr_draft"])
+
+	return &CloudEventsPullRequest{
+		Number:       number,
+		Title:        event.Metadata["pr_title"],
+		Action:       event.Metadata["change_type"],
+		State:        event.Metadata["pr_state"],
+		SourceBranch: event.Metadata["source_branch"],
+		TargetBranch: event.Metadata["target_branch"],
+		Author:       event.Metadata["pr_author"],
+		Labels:       labels,
+		Draft:        draft,
+		URL:          event.Metadata["pr_url"],
+	}
+}
+
+// getCommits decodes the commit range attached to the event by the poller
+func (t *EventTransformerImpl) getCommits(event types.Event) []types.Commit {
+	raw := event.Metadata["commits"]
+	if raw == "" {
+		return nil
+	}
+
+	var commits []types.Commit
+	if err := json.Unmarshal([]byte(raw), &commits); err != nil {
+		t.logger.WithError(err).WithFields(logger.Fields{
+			"operation": "get_commits",
+			"event_id":  event.ID,
+		}).Warn("Failed to decode event commits")
+		return nil
+	}
+	return commits
+}
+
+// getCloudEventsCommits converts the event's commit range to CloudEvents format
+func (t *EventTransformerImpl) getCloudEventsCommits(event types.Event) []CloudEventsCommit {
+	commits := t.getCommits(event)
+	if len(commits) == 0 {
+		return nil
+	}
+
+	result := make([]CloudEventsCommit, 0, len(commits))
+	for _, commit := range commits {
+		result = append(result, CloudEventsCommit{
+			SHA:      commit.SHA,
+			ShortSHA: t.getShortSHA(commit.SHA),
+			Message:  commit.Message,
+			Author: &CloudEventsAuthor{
+				Name:  commit.AuthorName,
+				Email: commit.AuthorEmail,
+			},
+			Timestamp: commit.Timestamp.Format(time.RFC3339),
+			URL:       commit.URL,
+			Added:     commit.Added,
+			Removed:   commit.Removed,
+			Modified:  commit.Modified,
+		})
+	}
+	return result
+}
+
+// getChangedFiles decodes the files changed across the event's commit range
+func (t *EventTransformerImpl) getChangedFiles(event types.Event) *CloudEventsFiles {
+	raw := event.Metadata["changed_files"]
+	if raw == "" {
+		return nil
+	}
+
+	var files CloudEventsFiles
+	if err := json.Unmarshal([]byte(raw), &files); err != nil {
+		t.logger.WithError(err).WithFields(logger.Fields{
+			"operation": "get_changed_files",
+			"event_id":  event.ID,
+		}).Warn("Failed to decode changed files")
+		return nil
+	}
+	return &files
+}
+
+// getMatchedPaths decodes the changed files that passed the repository's path filters
+func (t *EventTransformerImpl) getMatchedPaths(event types.Event) []string {
+	raw := event.Metadata["matched_paths"]
+	if raw == "" {
+		return nil
+	}
+
+	var paths []string
+	if err := json.Unmarshal([]byte(raw), &paths); err != nil {
+		t.logger.WithError(err).WithFields(logger.Fields{
+			"operation": "get_matched_paths",
+			"event_id":  event.ID,
+		}).Warn("Failed to decode matched paths")
+		return nil
+	}
+	return paths
+}
+
 // getBranchProtection extracts branch protection status from metadata
 func (t *EventTransformerImpl) getBranchProtection(event types.Event) bool {
 	if protectedStr, ok := event.Metadata["protected"]; ok {
@@ -437,6 +612,9 @@ func (t *EventTransformerImpl) createCommitFromEvent(event types.Event) *GitHubC
 		ID:        event.CommitSHA,
 		Timestamp: event.Timestamp,
 	}
+	if committedAt, err := time.Parse(time.RFC3339, event.Metadata["commit_timestamp"]); err == nil {
+		commit.Timestamp = committedAt
+	}
 
 	// Extract commit message from metadata if available
 	if message, ok := event.Metadata["commit_message"]; ok {
@@ -2,6 +2,7 @@ package trigger
 
 import (
 	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,15 +30,17 @@ func TestEventTransformer_TransformToGitHub(t *testing.T) {
 				Provider:   "github",
 				Timestamp:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
 				Metadata: map[string]string{
-					"repository_url": "https://github.com/torvalds/linux",
+					"repository_url":            "https://github.com/torvalds/linux",
+					"repository_default_branch": "master",
 				},
 			},
 			expected: GitHubPayload{
 				Repository: GitHubRepository{
-					Name:     "torvalds/linux",
-					FullName: "torvalds/linux",
-					CloneURL: "https://github.com/torvalds/linux.git",
-					HTMLURL:  "https://github.com/torvalds/linux",
+					Name:          "torvalds/linux",
+					FullName:      "torvalds/linux",
+					CloneURL:      "https://github.com/torvalds/linux.git",
+					HTMLURL:       "https://github.com/torvalds/linux",
+					DefaultBranch: "master",
 				},
 				After:    "abcd1234567890abcdef1234567890abcdef1234",
 				ShortSHA: "abcd1234",
@@ -123,6 +126,9 @@ func TestEventTransformer_TransformToGitHub(t *testing.T) {
 			if result.Repository.CloneURL != tt.expected.Repository.CloneURL {
 				t.Errorf("Repository.CloneURL: expected %s, got %s", tt.expected.Repository.CloneURL, result.Repository.CloneURL)
 			}
+			if result.Repository.DefaultBranch != tt.expected.Repository.DefaultBranch {
+				t.Errorf("Repository.DefaultBranch: expected %s, got %s", tt.expected.Repository.DefaultBranch, result.Repository.DefaultBranch)
+			}
 			if result.After != tt.expected.After {
 				t.Errorf("After: expected %s, got %s", tt.expected.After, result.After)
 			}
@@ -385,6 +391,74 @@ func TestEventTransformer_CreateCommitFromEvent(t *testing.T) {
 	}
 }
 
+func TestEventTransformer_CommitRange(t *testing.T) {
+	transformer := NewEventTransformer(logger.GetDefaultLogger().WithField("test", "transformer"))
+
+	event := types.Event{
+		ID:         "event_commits",
+		Type:       types.EventTypeBranchUpdated,
+		Repository: "test-repo",
+		Branch:     "main",
+		CommitSHA:  "cccccccccccccccccccccccccccccccccccccccc",
+		PrevCommit: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		Provider:   "github",
+		Timestamp:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		Metadata: map[string]string{
+			"repository_url": "https://github.com/owner/test-repo",
+			"commits": `[
+				{"sha": "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", "message": "First", "author_name": "Ann", "author_email": "ann@example.com", "timestamp": "2024-01-01T10:00:00Z"},
+				{"sha": "cccccccccccccccccccccccccccccccccccccccc", "message": "Second", "author_name": "Bob", "author_email": "bob@example.com", "timestamp": "2024-01-01T11:00:00Z"}
+			]`,
+			"changed_files":    `{"added": ["new.go"], "removed": [], "modified": ["main.go"]}`,
+			"matched_paths":    `["main.go"]`,
+			"commit_message":   "Second",
+			"author_name":      "Bob",
+			"author_email":     "bob@example.com",
+			"commit_timestamp": "2024-01-01T11:00:00Z",
+		},
+	}
+
+	github, err := transformer.TransformToGitHub(event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(github.Commits) != 2 {
+		t.Fatalf("Expected 2 commits, got %d", len(github.Commits))
+	}
+	if github.Commits[0].Message != "First" || github.Commits[0].Author.Name != "Ann" {
+		t.Errorf("Unexpected first commit: %+v", github.Commits[0])
+	}
+	if github.HeadCommit == nil || github.HeadCommit.ID != event.CommitSHA {
+		t.Fatalf("Expected head commit %s, got %+v", event.CommitSHA, github.HeadCommit)
+	}
+	if !github.HeadCommit.Timestamp.Equal(time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("HeadCommit.Timestamp: expected commit time, got %v", github.HeadCommit.Timestamp)
+	}
+
+	cloudEvent, err := transformer.TransformToCloudEvents(event)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cloudEvent.Data.Commits) != 2 {
+		t.Fatalf("Expected 2 CloudEvents commits, got %d", len(cloudEvent.Data.Commits))
+	}
+	if cloudEvent.Data.Commits[1].ShortSHA != "cccccccc" {
+		t.Errorf("Expected short SHA cccccccc, got %s", cloudEvent.Data.Commits[1].ShortSHA)
+	}
+	if cloudEvent.Data.Commit.Author == nil || cloudEvent.Data.Commit.Author.Name != "Bob" {
+		t.Errorf("Expected head commit author Bob, got %+v", cloudEvent.Data.Commit.Author)
+	}
+	if cloudEvent.Data.Commit.Timestamp != "2024-01-01T11:00:00Z" {
+		t.Errorf("Expected head commit timestamp, got %s", cloudEvent.Data.Commit.Timestamp)
+	}
+	if cloudEvent.Data.ChangedFiles == nil || strings.Join(cloudEvent.Data.ChangedFiles.Modified, ",") != "main.go" {
+		t.Errorf("Unexpected changed files: %+v", cloudEvent.Data.ChangedFiles)
+	}
+	if strings.Join(cloudEvent.Data.MatchedPaths, ",") != "main.go" {
+		t.Errorf("Unexpected matched paths: %v", cloudEvent.Data.MatchedPaths)
+	}
+}
+
 func TestEventTransformer_TransformToCloudEvents(t *testing.T) {
 	transformer := NewEventTransformer(logger.GetDefaultLogger().WithField("test", "cloudevents"))
 
@@ -437,6 +511,92 @@ func TestEventTransformer_TransformToCloudEvents(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Tag event uses tag ref",
+			event: types.Event{
+				ID:         "event_456",
+				Type:       types.EventTypeTagCreated,
+				Repository: "test-repo",
+				Branch:     "v1.2.0",
+				CommitSHA:  "abcd1234567890abcdef1234567890abcdef1234",
+				Provider:   "github",
+				Timestamp:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+				Metadata: map[string]string{
+					"repository_url": "https://github.com/owner/test-repo",
+				},
+			},
+			expected: CloudEventsPayload{
+				SpecVersion: "1.0",
+				Type:        "dev.reposentry.repository.tag_created",
+				Source:      "reposentry/github",
+				Data: CloudEventsData{
+					Repository: CloudEventsRepository{
+						Name:         "test-repo",
+						Organization: "owner",
+					},
+					Branch: CloudEventsBranch{
+						Name: "v1.2.0",
+						Ref:  "refs/tags/v1.2.0",
+					},
+					Commit: CloudEventsCommit{
+						ShortSHA: "abcd1234",
+					},
+					Event: CloudEventsEvent{
+						Type: "tag_created",
+					},
+				},
+			},
+		},
+		{
+			name: "Pull request event carries pull request data",
+			event: types.Event{
+				ID:         "event_789",
+				Type:       types.EventTypePullRequestOpened,
+				Repository: "test-repo",
+				Branch:     "feature/login",
+				CommitSHA:  "abcd1234567890abcdef1234567890abcdef1234",
+				Provider:   "github",
+				Timestamp:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+				Metadata: map[string]string{
+					"repository_url": "https://github.com/owner/test-repo",
+					"ref_type":       "pull_request",
+					"pr_number":      "42",
+					"pr_title":       "Add login",
+					"pr_state":       "open",
+					"pr_labels":      `["ci","ready"]`,
+					"source_branch":  "feature/login",
+					"target_branch":  "main",
+					"change_type":    "opened",
+				},
+			},
+			expected: CloudEventsPayload{
+				SpecVersion: "1.0",
+				Type:        "dev.reposentry.repository.pull_request_opened",
+				Source:      "reposentry/github",
+				Data: CloudEventsData{
+					Repository: CloudEventsRepository{
+						Name:         "test-repo",
+						Organization: "owner",
+					},
+					Branch: CloudEventsBranch{
+						Name: "feature/login",
+						Ref:  "refs/heads/feature/login",
+					},
+					Commit: CloudEventsCommit{
+						ShortSHA: "abcd1234",
+					},
+					Event: CloudEventsEvent{
+						Type: "pull_request_opened",
+					},
+					PullRequest: &CloudEventsPullRequest{
+						Number:       42,
+						Action:       "opened",
+						TargetBranch: "main",
+						Labels:       []string{"ci", "ready"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -479,9 +639,44 @@ func TestEventTransformer_TransformToCloudEvents(t *testing.T) {
 				t.Errorf("Expected Branch.Name %s, got %s", tt.expected.Data.Branch.Name, result.Data.Branch.Name)
 			}
 
+			if result.Data.Branch.Ref != tt.expected.Data.Branch.Ref {
+				t.Errorf("Expected Branch.Ref %s, got %s", tt.expected.Data.Branch.Ref, result.Data.Branch.Ref)
+			}
+
+			if result.Data.Event.Type != tt.expected.Data.Event.Type {
+				t.Errorf("Expected Event.Type %s, got %s", tt.expected.Data.Event.Type, result.Data.Event.Type)
+			}
+
 			if result.Data.Commit.ShortSHA != tt.expected.Data.Commit.ShortSHA {
 				t.Errorf("Expected Commit.ShortSHA %s, got %s", tt.expected.Data.Commit.ShortSHA, result.Data.Commit.ShortSHA)
 			}
+
+			if tt.expected.Data.PullRequest == nil {
+				if result.Data.PullRequest != nil {
+					t.Errorf("Expected no PullRequest, got %+v", result.Data.PullRequest)
+				}
+				return
+			}
+
+			if result.Data.PullRequest == nil {
+				t.Fatal("Expected PullRequest data, got nil")
+			}
+
+			if result.Data.PullRequest.Number != tt.expected.Data.PullRequest.Number {
+				t.Errorf("Expected PullRequest.Number %d, got %d", tt.expected.Data.PullRequest.Number, result.Data.PullRequest.Number)
+			}
+
+			if result.Data.PullRequest.Action != tt.expected.Data.PullRequest.Action {
+				t.Errorf("Expected PullRequest.Action %s, got %s", tt.expected.Data.PullRequest.Action, result.Data.PullRequest.Action)
+			}
+
+			if result.Data.PullRequest.TargetBranch != tt.expected.Data.PullRequest.TargetBranch {
+				t.Errorf("Expected PullRequest.TargetBranch %s, got %s", tt.expected.Data.PullRequest.TargetBranch, result.Data.PullRequest.TargetBranch)
+			}
+
+			if strings.Join(result.Data.PullRequest.Labels, ",") != strings.Join(tt.expected.Data.PullRequest.Labels, ",") {
+				t.Errorf("Expected PullRequest.Labels %v, got %v", tt.expected.Data.PullRequest.Labels, result.Data.PullRequest.Labels)
+			}
 		})
 	}
 }
@@ -65,6 +65,7 @@ type TriggerConfig struct {
 	Retry    RetryConfig            `yaml:"retry" json:"retry"`
 	Timeout  time.Duration          `yaml:"timeout" json:"timeout"`
 	Metadata map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`
+	HTTP     types.HTTPClientConfig `yaml:"http,omitempty" json:"http,omitempty"` // Proxy and TLS settings shared with the Git clients
 }
 
 // TektonConfig represents Tekton EventListener configuration
@@ -154,27 +155,33 @@ type CloudEventsPayload struct {
 
 // CloudEventsData represents the data section of CloudEvents payload
 type CloudEventsData struct {
-	Repository     CloudEventsRepository `json:"repository"`
-	Branch         CloudEventsBranch     `json:"branch"`
-	Commit         CloudEventsCommit     `json:"commit"`
-	Event          CloudEventsEvent      `json:"event"`
-	PreviousCommit *CloudEventsCommit    `json:"previous_commit,omitempty"`
+	Repository     CloudEventsRepository   `json:"repository"`
+	Branch         CloudEventsBranch       `json:"branch"`
+	Commit         CloudEventsCommit       `json:"commit"`
+	Event          CloudEventsEvent        `json:"event"`
+	PreviousCommit *CloudEventsCommit      `json:"previous_commit,omitempty"`
+	PullRequest    *CloudEventsPullRequest `json:"pull_request,omitempty"`
+	Commits        []CloudEventsCommit     `json:"commits,omitempty"`       // Commits since the previous commit, oldest first
+	ChangedFiles   *CloudEventsFiles       `json:"changed_files,omitempty"` // Files changed across Commits
+	MatchedPaths   []string                `json:"matched_paths,omitempty"` // Changed files selected by the repository's path filters
 }
 
 // CloudEventsRepository represents repository information in CloudEvents format
 type CloudEventsRepository struct {
-	Provider     string `json:"provider"`
-	Organization string `json:"organization"`
-	Name         string `json:"name"`
-	FullName     string `json:"full_name"`
-	URL          string `json:"url"`
-	ID           string `json:"id"`
+	Provider      string `json:"provider"`
+	Organization  string `json:"organization"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	URL           string `json:"url"`
+	ID            string `json:"id"`
+	DefaultBranch string `json:"default_branch,omitempty"`
 }
 
 // CloudEventsBranch represents branch information in CloudEvents format
 type CloudEventsBranch struct {
-	Name string `json:"name"`
-	Ref  string `json:"ref"`
+	Name    string `json:"name"`
+	Ref     string `json:"ref"`
+	Default bool   `json:"default,omitempty"` // The branch is the repository's default branch
 }
 
 // CloudEventsCommit represents commit information in CloudEvents format
@@ -184,6 +191,17 @@ type CloudEventsCommit struct {
 	Message   string             `json:"message,omitempty"`
 	Author    *CloudEventsAuthor `json:"author,omitempty"`
 	Timestamp string             `json:"timestamp,omitempty"`
+	URL       string             `json:"url,omitempty"`
+	Added     []string           `json:"added,omitempty"`
+	Removed   []string           `json:"removed,omitempty"`
+	Modified  []string           `json:"modified,omitempty"`
+}
+
+// CloudEventsFiles represents changed files in CloudEvents format
+type CloudEventsFiles struct {
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	Modified []string `json:"modified"`
 }
 
 // CloudEventsAuthor represents author information in CloudEvents format
@@ -192,6 +210,20 @@ type CloudEventsAuthor struct {
 	Email string `json:"email"`
 }
 
+// CloudEventsPullRequest represents pull request information in CloudEvents format
+type CloudEventsPullRequest struct {
+	Number       int      `json:"number"`
+	Title        string   `json:"title,omitempty"`
+	Action       string   `json:"action"` // opened, updated, closed or merged
+	State        string   `json:"state"`
+	SourceBranch string   `json:"source_branch"`
+	TargetBranch string   `json:"target_branch"`
+	Author       string   `json:"author,omitempty"`
+	Labels       []string `json:"labels"`
+	Draft        bool     `json:"draft"`
+	URL          string   `json:"url,omitempty"`
+}
+
 // CloudEventsEvent represents event information in CloudEvents format
 type CloudEventsEvent struct {
 	Type          string `json:"type"`
@@ -219,12 +251,13 @@ type GenericPayload struct {
 
 // GitHubRepository represents repository information in GitHub format
 type GitHubRepository struct {
-	ID       int64  `json:"id,omitempty"`
-	Name     string `json:"name"`
-	FullName string `json:"full_name,omitempty"`
-	CloneURL string `json:"clone_url"`
-	HTMLURL  string `json:"html_url,omitempty"`
-	Private  bool   `json:"private,omitempty"`
+	ID            int64  `json:"id,omitempty"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name,omitempty"`
+	CloneURL      string `json:"clone_url"`
+	HTMLURL       string `json:"html_url,omitempty"`
+	Private       bool   `json:"private,omitempty"`
+	DefaultBranch string `json:"default_branch,omitempty"`
 }
 
 // GitHubUser represents user information in GitHub format
@@ -7,11 +7,12 @@ import (
 	"strings"
 
 	"github.com/johnnynv/RepoSentry/pkg/logger"
+	"github.com/johnnynv/RepoSentry/pkg/utils"
 )
 
 // RepositoryInfo represents parsed repository information
 type RepositoryInfo struct {
-	Provider     string `json:"provider"`      // "github" or "gitlab"
+	Provider     string `json:"provider"`      // "github", "gitlab", "bitbucket" or "gitea"
 	Instance     string `json:"instance"`      // "github.com", "gitlab-master.nvidia.com"
 	Namespace    string `json:"namespace"`     // "owner" or "group/subgroup"
 	ProjectName  string `json:"project_name"`  // "repo"
@@ -58,7 +59,7 @@ func (p *URLParser) ParseRepositoryURL(repoURL string) (*RepositoryInfo, error)
 	}
 
 	// Parse URL
-	parsedURL, err := url.Parse(repoURL)
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		p.logger.WithFields(logger.Fields{
 			"operation": "parse_repository_url",
@@ -67,15 +68,26 @@ func (p *URLParser) ParseRepositoryURL(repoURL string) (*RepositoryInfo, error)
 		}).Error("Failed to parse repository URL")
 		return nil, fmt.Errorf("invalid repository URL: %w", err)
 	}
+	isSSH := parsedURL.Scheme == "ssh"
 
-	// Normalize URL (remove .git suffix, HTTPS only)
+	// Normalize URL (remove .git suffix, map SSH to HTTPS)
 	normalizedURL := p.normalizeURL(parsedURL)
 
 	// Detect provider type
 	provider := p.detectProvider(normalizedURL.Host)
 
 	// Parse path components
-	namespace, projectName, err := p.parseRepoPath(normalizedURL.Path)
+	var namespace, projectName, contextPath string
+	var isBitbucketServerPath bool
+	if provider == "bitbucket" && isSSH && !strings.EqualFold(normalizedURL.Host, "bitbucket.org") {
+		// Data Center SSH URLs are ssh://git@host:7999/key/slug.git
+		namespace, projectName, err = p.parseRepoPath(normalizedURL.Path)
+		isBitbucketServerPath = true
+	} else if provider == "bitbucket" {
+		namespace, projectName, contextPath, isBitbucketServerPath, err = p.parseBitbucketRepoPath(normalizedURL.Path)
+	} else {
+		namespace, projectName, err = p.parseRepoPath(normalizedURL.Path)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse repository path: %w", err)
 	}
@@ -106,6 +118,16 @@ func (p *URLParser) ParseRepositoryURL(repoURL string) (*RepositoryInfo, error)
 		IsEnterprise: isEnterprise,
 	}
 
+	// Bitbucket Data Center uses distinct clone (/scm) and web (/projects) layouts
+	if isBitbucketServerPath {
+		repoInfo.CloneURL, repoInfo.HTMLURL, repoInfo.APIBaseURL = p.bitbucketServerURLs(normalizedURL.Host, contextPath, namespace, projectName)
+	}
+
+	// Repositories configured over SSH are cloned over SSH, with their deploy key
+	if isSSH {
+		repoInfo.CloneURL = repoURL
+	}
+
 	p.logger.WithFields(logger.Fields{
 		"operation":     "parse_repository_url",
 		"original_url":  repoURL,
@@ -118,10 +140,17 @@ func (p *URLParser) ParseRepositoryURL(repoURL string) (*RepositoryInfo, error)
 	return repoInfo, nil
 }
 
-// normalizeURL normalizes the repository URL (HTTPS only)
+// normalizeURL normalizes the repository URL. SSH URLs become HTTPS URLs on the
+// same host, without the user and SSH port.
 func (p *URLParser) normalizeURL(parsedURL *url.URL) *url.URL {
 	normalized := *parsedURL
 
+	if normalized.Scheme == "ssh" {
+		normalized.Scheme = "https"
+		normalized.Host = parsedURL.Hostname()
+		normalized.User = nil
+	}
+
 	// Remove .git suffix from path
 	if strings.HasSuffix(normalized.Path, ".git") {
 		normalized.Path = strings.TrimSuffix(normalized.Path, ".git")
@@ -149,6 +178,16 @@ func (p *URLParser) detectProvider(hostname string) string {
 		return "gitlab"
 	}
 
+	// Bitbucket detection (Cloud and Data Center)
+	if hostname == "bitbucket.org" || strings.Contains(hostname, "bitbucket") {
+		return "bitbucket"
+	}
+
+	// Gitea detection (Forgejo and Codeberg serve the same API)
+	if strings.Contains(hostname, "gitea") || strings.Contains(hostname, "forgejo") || hostname == "codeberg.org" {
+		return "gitea"
+	}
+
 	// Default fallback based on common patterns
 	if strings.Contains(hostname, "git") {
 		// If hostname contains "git", assume GitLab (more common for enterprise)
@@ -185,14 +224,41 @@ func (p *URLParser) parseRepoPath(repoPath string) (namespace, projectName strin
 	return namespace, projectName, nil
 }
 
+// parseBitbucketRepoPath parses Bitbucket paths. Data Center URLs carry extra
+// segments (/projects/KEY/repos/slug, /scm/key/slug) and may be served under a
+// context path, which is returned separately. Cloud paths are plain workspace/slug.
+func (p *URLParser) parseBitbucketRepoPath(repoPath string) (namespace, projectName, contextPath string, isServerPath bool, err error) {
+	if namespace, projectName, contextPath, ok := utils.ParseBitbucketServerPath(repoPath); ok {
+		return namespace, projectName, contextPath, true, nil
+	}
+
+	namespace, projectName, err = p.parseRepoPath(repoPath)
+	return namespace, projectName, "", false, err
+}
+
+// bitbucketServerURLs builds clone, web and API URLs for a Bitbucket Data Center repository
+func (p *URLParser) bitbucketServerURLs(host, contextPath, namespace, projectName string) (cloneURL, htmlURL, apiBaseURL string) {
+	base := fmt.Sprintf("https://%s%s", host, contextPath)
+	cloneURL = fmt.Sprintf("%s/scm/%s/%s.git", base, strings.ToLower(namespace), projectName)
+	if strings.HasPrefix(namespace, "~") {
+		htmlURL = fmt.Sprintf("%s/users/%s/repos/%s", base, strings.TrimPrefix(namespace, "~"), projectName)
+	} else {
+		htmlURL = fmt.Sprintf("%s/projects/%s/repos/%s", base, namespace, projectName)
+	}
+	return cloneURL, htmlURL, base + "/rest/api/1.0"
+}
+
 // isEnterpriseInstance determines if the instance is enterprise/self-hosted
 func (p *URLParser) isEnterpriseInstance(hostname, provider string) bool {
 	hostname = strings.ToLower(hostname)
 
 	// Public instances
 	publicInstances := map[string]bool{
-		"github.com": true,
-		"gitlab.com": true,
+		"github.com":    true,
+		"gitlab.com":    true,
+		"bitbucket.org": true,
+		"gitea.com":     true,
+		"codeberg.org":  true,
 	}
 
 	return !publicInstances[hostname]
@@ -224,6 +290,15 @@ func (p *URLParser) buildAPIBaseURL(hostname, provider string) string {
 	case "gitlab":
 		// GitLab (both .com and self-hosted): https://hostname/api/v4
 		return fmt.Sprintf("https://%s/api/v4", hostname)
+	case "bitbucket":
+		if hostname == "bitbucket.org" {
+			return "https://api.bitbucket.org/2.0"
+		}
+		// Bitbucket Data Center: https://hostname/rest/api/1.0
+		return fmt.Sprintf("https://%s/rest/api/1.0", hostname)
+	case "gitea":
+		// Gitea and Forgejo: https://hostname/api/v1
+		return fmt.Sprintf("https://%s/api/v1", hostname)
 	default:
 		// Default to GitLab API format
 		return fmt.Sprintf("https://%s/api/v4", hostname)
@@ -251,7 +326,7 @@ func (p *URLParser) GetProviderType(repoURL string) string {
 		return "unknown"
 	}
 
-	parsedURL, err := url.Parse(repoURL)
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		p.logger.WithFields(logger.Fields{
 			"operation": "get_provider_type",
@@ -261,7 +336,7 @@ func (p *URLParser) GetProviderType(repoURL string) string {
 		return "unknown"
 	}
 
-	return p.detectProvider(parsedURL.Host)
+	return p.detectProvider(parsedURL.Hostname())
 }
 
 // BuildRepoURLs builds various repository URLs from components
@@ -301,18 +376,13 @@ func (p *URLParser) validateURLFormat(repoURL string) error {
 		return fmt.Errorf("repository URL cannot be empty")
 	}
 
-	// Check for SSH format (not supported)
-	if strings.Contains(repoURL, "@") && strings.Contains(repoURL, ":") && !strings.Contains(repoURL, "://") {
-		return fmt.Errorf("SSH URLs are not supported, please use HTTPS format (e.g., https://gitlab-master.nvidia.com/owner/repo)")
-	}
-
-	// Must start with https://
-	if !strings.HasPrefix(repoURL, "https://") {
-		return fmt.Errorf("only HTTPS URLs are supported, URL must start with 'https://' (got: %s)", repoURL)
+	// Must be an SSH URL (ssh:// or git@host:owner/repo) or start with https://
+	if !utils.IsSSHURL(repoURL) && !strings.HasPrefix(repoURL, "https://") {
+		return fmt.Errorf("only HTTPS and SSH URLs are supported, URL must start with 'https://' or 'ssh://' or use git@host:owner/repo (got: %s)", repoURL)
 	}
 
 	// Basic URL parsing check
-	parsedURL, err := url.Parse(repoURL)
+	parsedURL, err := utils.ParseGitURL(repoURL)
 	if err != nil {
 		return fmt.Errorf("malformed URL: %w", err)
 	}
@@ -106,8 +106,113 @@ func TestURLParser_ParseRepositoryURL(t *testing.T) {
 			},
 		},
 		{
-			name:    "SSH URL (not supported)",
-			url:     "git@gitlab-master.nvidia.com:chat-labs/OpenSource/rag.git",
+			name: "Bitbucket Cloud repository",
+			url:  "https://bitbucket.org/workspace/repo.git",
+			expected: &RepositoryInfo{
+				Provider:     "bitbucket",
+				Instance:     "bitbucket.org",
+				Namespace:    "workspace",
+				ProjectName:  "repo",
+				FullName:     "workspace/repo",
+				CloneURL:     "https://bitbucket.org/workspace/repo.git",
+				HTMLURL:      "https://bitbucket.org/workspace/repo",
+				APIBaseURL:   "https://api.bitbucket.org/2.0",
+				IsEnterprise: false,
+			},
+		},
+		{
+			name: "Bitbucket Data Center web URL",
+			url:  "https://bitbucket.company.com/projects/PROJ/repos/service/browse",
+			expected: &RepositoryInfo{
+				Provider:     "bitbucket",
+				Instance:     "bitbucket.company.com",
+				Namespace:    "PROJ",
+				ProjectName:  "service",
+				FullName:     "PROJ/service",
+				CloneURL:     "https://bitbucket.company.com/scm/proj/service.git",
+				HTMLURL:      "https://bitbucket.company.com/projects/PROJ/repos/service",
+				APIBaseURL:   "https://bitbucket.company.com/rest/api/1.0",
+				IsEnterprise: true,
+			},
+		},
+		{
+			name: "Bitbucket Data Center clone URL with context path",
+			url:  "https://bitbucket.company.com/stash/scm/proj/service.git",
+			expected: &RepositoryInfo{
+				Provider:     "bitbucket",
+				Instance:     "bitbucket.company.com",
+				Namespace:    "proj",
+				ProjectName:  "service",
+				FullName:     "proj/service",
+				CloneURL:     "https://bitbucket.company.com/stash/scm/proj/service.git",
+				HTMLURL:      "https://bitbucket.company.com/stash/projects/proj/repos/service",
+				APIBaseURL:   "https://bitbucket.company.com/stash/rest/api/1.0",
+				IsEnterprise: true,
+			},
+		},
+		{
+			name: "Gitea self-hosted repository",
+			url:  "https://gitea.company.com/tools/deployer.git",
+			expected: &RepositoryInfo{
+				Provider:     "gitea",
+				Instance:     "gitea.company.com",
+				Namespace:    "tools",
+				ProjectName:  "deployer",
+				FullName:     "tools/deployer",
+				CloneURL:     "https://gitea.company.com/tools/deployer.git",
+				HTMLURL:      "https://gitea.company.com/tools/deployer",
+				APIBaseURL:   "https://gitea.company.com/api/v1",
+				IsEnterprise: true,
+			},
+		},
+		{
+			name: "SCP-style SSH URL",
+			url:  "git@gitlab-master.nvidia.com:chat-labs/OpenSource/rag.git",
+			expected: &RepositoryInfo{
+				Provider:     "gitlab",
+				Instance:     "gitlab-master.nvidia.com",
+				Namespace:    "chat-labs/OpenSource",
+				ProjectName:  "rag",
+				FullName:     "chat-labs/OpenSource/rag",
+				CloneURL:     "git@gitlab-master.nvidia.com:chat-labs/OpenSource/rag.git",
+				HTMLURL:      "https://gitlab-master.nvidia.com/chat-labs/OpenSource/rag",
+				APIBaseURL:   "https://gitlab-master.nvidia.com/api/v4",
+				IsEnterprise: true,
+			},
+		},
+		{
+			name: "SSH URL with port",
+			url:  "ssh://git@github.com:22/torvalds/linux.git",
+			expected: &RepositoryInfo{
+				Provider:     "github",
+				Instance:     "github.com",
+				Namespace:    "torvalds",
+				ProjectName:  "linux",
+				FullName:     "torvalds/linux",
+				CloneURL:     "ssh://git@github.com:22/torvalds/linux.git",
+				HTMLURL:      "https://github.com/torvalds/linux",
+				APIBaseURL:   "https://api.github.com",
+				IsEnterprise: false,
+			},
+		},
+		{
+			name: "Bitbucket Data Center SSH URL",
+			url:  "ssh://git@bitbucket.company.com:7999/proj/service.git",
+			expected: &RepositoryInfo{
+				Provider:     "bitbucket",
+				Instance:     "bitbucket.company.com",
+				Namespace:    "proj",
+				ProjectName:  "service",
+				FullName:     "proj/service",
+				CloneURL:     "ssh://git@bitbucket.company.com:7999/proj/service.git",
+				HTMLURL:      "https://bitbucket.company.com/projects/proj/repos/service",
+				APIBaseURL:   "https://bitbucket.company.com/rest/api/1.0",
+				IsEnterprise: true,
+			},
+		},
+		{
+			name:    "SSH URL without repository",
+			url:     "git@github.com:owner",
 			wantErr: true,
 		},
 		{
@@ -218,15 +323,35 @@ func TestURLParser_GetProviderType(t *testing.T) {
 			url:      "https://github.enterprise.com/org/repo",
 			expected: "github",
 		},
+		{
+			name:     "Bitbucket Cloud",
+			url:      "https://bitbucket.org/workspace/repo",
+			expected: "bitbucket",
+		},
+		{
+			name:     "Bitbucket Data Center",
+			url:      "https://bitbucket.company.com/projects/PROJ/repos/service",
+			expected: "bitbucket",
+		},
+		{
+			name:     "Forgejo",
+			url:      "https://forgejo.example.com/owner/repo",
+			expected: "gitea",
+		},
+		{
+			name:     "Codeberg",
+			url:      "https://codeberg.org/owner/repo",
+			expected: "gitea",
+		},
 		{
 			name:     "Unknown git provider",
 			url:      "https://git.example.com/owner/repo",
 			expected: "gitlab", // Default fallback
 		},
 		{
-			name:     "SSH URL (not supported)",
-			url:      "git@gitlab-master.nvidia.com:chat-labs/OpenSource/rag.git",
-			expected: "unknown",
+			name:     "SSH URL",
+			url:      "git@github.com:owner/repo.git",
+			expected: "github",
 		},
 		{
 			name:     "HTTP URL (not supported)",
@@ -258,11 +383,12 @@ func TestURLParser_ValidateRepositoryURL(t *testing.T) {
 		"https://gitlab.com/gitlab-org/gitlab",
 		"https://gitlab-master.nvidia.com/chat-labs/OpenSource/rag",
 		"https://github.enterprise.com/org/repo",
+		"git@gitlab-master.nvidia.com:chat-labs/OpenSource/rag.git",
+		"ssh://git@github.com/owner/repo.git",
 	}
 
 	invalidURLs := []string{
-		"git@gitlab-master.nvidia.com:chat-labs/OpenSource/rag.git", // SSH not supported
-		"http://github.com/owner/repo",                              // HTTP not supported
+		"http://github.com/owner/repo", // HTTP not supported
 		"not-a-url",
 		"https://github.com/",
 		"https://github.com/owner",
@@ -1,19 +1,23 @@
 package types
 
 import (
+	"strings"
 	"time"
 )
 
 // Config represents the main application configuration
 type Config struct {
-	App                AppConfig       `yaml:"app" json:"app"`
-	Polling            PollingConfig   `yaml:"polling" json:"polling"`
-	Storage            StorageConfig   `yaml:"storage" json:"storage"`
-	Tekton             TektonConfig    `yaml:"tekton" json:"tekton"`
-	RateLimit          RateLimitConfig `yaml:"rate_limit" json:"rate_limit"`
-	Security           SecurityConfig  `yaml:"security" json:"security"`
-	Repositories       []Repository    `yaml:"repositories,omitempty" json:"repositories,omitempty"`               // Legacy: repositories in main config
-	RepositoriesConfig string          `yaml:"repositories_config,omitempty" json:"repositories_config,omitempty"` // New: path to repositories config file
+	App                AppConfig         `yaml:"app" json:"app"`
+	Polling            PollingConfig     `yaml:"polling" json:"polling"`
+	Storage            StorageConfig     `yaml:"storage" json:"storage"`
+	Tekton             TektonConfig      `yaml:"tekton" json:"tekton"`
+	RateLimit          RateLimitConfig   `yaml:"rate_limit" json:"rate_limit"`
+	Security           SecurityConfig    `yaml:"security" json:"security"`
+	GitHubApp          *GitHubAppConfig  `yaml:"github_app,omitempty" json:"github_app,omitempty"`                   // Default GitHub App credentials for GitHub repositories without a token
+	HTTP               HTTPClientConfig  `yaml:"http,omitempty" json:"http,omitempty"`                               // Proxy and TLS settings for outbound requests
+	Repositories       []Repository      `yaml:"repositories,omitempty" json:"repositories,omitempty"`               // Legacy: repositories in main config
+	Discovery          []DiscoverySource `yaml:"discovery,omitempty" json:"discovery,omitempty"`                     // Organizations, users and groups whose repositories are polled automatically
+	RepositoriesConfig string            `yaml:"repositories_config,omitempty" json:"repositories_config,omitempty"` // New: path to repositories config file
 }
 
 // AppConfig represents application-level configuration
@@ -37,13 +41,48 @@ type LogFileConfig struct {
 
 // PollingConfig represents polling-related configuration
 type PollingConfig struct {
-	Interval          time.Duration `yaml:"interval" json:"interval"`
-	Timeout           time.Duration `yaml:"timeout" json:"timeout"`
-	MaxWorkers        int           `yaml:"max_workers" json:"max_workers"`
-	BatchSize         int           `yaml:"batch_size" json:"batch_size"`
-	EnableAPIFallback bool          `yaml:"enable_api_fallback" json:"enable_api_fallback"`
-	RetryAttempts     int           `yaml:"retry_attempts" json:"retry_attempts"`
-	RetryBackoff      time.Duration `yaml:"retry_backoff" json:"retry_backoff"`
+	Interval          time.Duration        `yaml:"interval" json:"interval"`
+	Timeout           time.Duration        `yaml:"timeout" json:"timeout"`
+	MaxWorkers        int                  `yaml:"max_workers" json:"max_workers"`
+	BatchSize         int                  `yaml:"batch_size" json:"batch_size"`
+	EnableAPIFallback bool                 `yaml:"enable_api_fallback" json:"enable_api_fallback"`
+	RetryAttempts     int                  `yaml:"retry_attempts" json:"retry_attempts"`
+	RetryBackoff      time.Duration        `yaml:"retry_backoff" json:"retry_backoff"`
+	PageSize          int                  `yaml:"page_size" json:"page_size"` // Items per page for provider list APIs
+	MaxPages          int                  `yaml:"max_pages" json:"max_pages"` // Safety cap on pages fetched per listing
+	HTTPCache         HTTPCacheConfig      `yaml:"http_cache" json:"http_cache"`
+	GitHubGraphQL     bool                 `yaml:"github_graphql" json:"github_graphql"`         // Batch GitHub branch queries through GraphQL
+	GraphQLBatchSize  int                  `yaml:"graphql_batch_size" json:"graphql_batch_size"` // Repositories per GraphQL query
+	MaxCommits        int                  `yaml:"max_commits" json:"max_commits"`               // Commits attached to an event, negative disables
+	CircuitBreaker    CircuitBreakerConfig `yaml:"circuit_breaker" json:"circuit_breaker"`       // Fail fast while a provider API is down
+	Jitter            float64              `yaml:"jitter" json:"jitter"`                         // Fraction of the interval poll times vary by, negative disables
+	Splay             time.Duration        `yaml:"splay" json:"splay"`                           // Window first polls are spread over (0 = the repository's interval)
+	Schedule          *PollSchedule        `yaml:"schedule,omitempty" json:"schedule,omitempty"` // Poll at cron times; repositories may override
+	BlackoutWindows   []BlackoutWindow     `yaml:"blackout_windows,omitempty" json:"blackout_windows,omitempty"`
+	BlackoutAction    string               `yaml:"blackout_action,omitempty" json:"blackout_action,omitempty"` // queue (default) or drop
+	FailureBackoff    FailureBackoffConfig `yaml:"failure_backoff" json:"failure_backoff"`                     // Slow down and quarantine repositories that keep failing
+}
+
+// HTTPCacheConfig controls conditional (ETag / Last-Modified) requests to provider APIs
+type HTTPCacheConfig struct {
+	Disabled bool          `yaml:"disabled" json:"disabled"` // Always re-download responses
+	Persist  bool          `yaml:"persist" json:"persist"`   // Keep cached responses in storage across restarts
+	MaxAge   time.Duration `yaml:"max_age" json:"max_age"`   // Cached responses not downloaded again for this long are dropped
+}
+
+// CircuitBreakerConfig controls the per-API circuit breakers of the Git clients
+type CircuitBreakerConfig struct {
+	Disabled         bool          `yaml:"disabled" json:"disabled"`
+	FailureThreshold int           `yaml:"failure_threshold" json:"failure_threshold"`   // Consecutive failures that open the circuit
+	OpenTimeout      time.Duration `yaml:"open_timeout" json:"open_timeout"`             // How long an open circuit fails fast before probing
+	HalfOpenRequests int           `yaml:"half_open_requests" json:"half_open_requests"` // Probe requests allowed while half-open
+}
+
+// FailureBackoffConfig controls how repositories that keep failing to poll
+// are slowed down and eventually quarantined
+type FailureBackoffConfig struct {
+	MaxBackoff      time.Duration `yaml:"max_backoff" json:"max_backoff"`           // Cap on the delay between polls of a failing repository
+	QuarantineAfter int           `yaml:"quarantine_after" json:"quarantine_after"` // Consecutive failures before polling stops, negative disables
 }
 
 // StorageConfig represents storage configuration
@@ -68,6 +107,14 @@ type TektonConfig struct {
 	RetryAttempts     int               `yaml:"retry_attempts" json:"retry_attempts"`
 	RetryBackoff      time.Duration     `yaml:"retry_backoff" json:"retry_backoff"`
 	Headers           map[string]string `yaml:"headers" json:"headers"`
+	BlobCache         BlobCacheConfig   `yaml:"blob_cache" json:"blob_cache"` // Reuse .tekton file contents by blob SHA
+}
+
+// BlobCacheConfig controls the cache of repository file contents keyed by blob SHA
+type BlobCacheConfig struct {
+	Disabled    bool `yaml:"disabled" json:"disabled"`           // Always download file contents
+	Persist     bool `yaml:"persist" json:"persist"`             // Keep blobs under the data directory across restarts
+	MaxMemoryMB int  `yaml:"max_memory_mb" json:"max_memory_mb"` // In-memory size limit
 }
 
 // RateLimitConfig represents rate limiting configuration for different providers
@@ -88,6 +135,52 @@ type GitLabRateLimit struct {
 	Burst             int `yaml:"burst" json:"burst"`
 }
 
+// GitHubAppConfig represents GitHub App credentials used to obtain installation tokens
+type GitHubAppConfig struct {
+	AppID          int64  `yaml:"app_id" json:"app_id"`
+	PrivateKeyPath string `yaml:"private_key_path" json:"private_key_path"`
+	InstallationID int64  `yaml:"installation_id,omitempty" json:"installation_id,omitempty"` // 0 = discover from repository owner
+}
+
+// HTTPClientConfig represents proxy and TLS settings for outbound HTTP clients.
+// Empty fields keep the Go defaults, including HTTPS_PROXY/NO_PROXY from the environment.
+type HTTPClientConfig struct {
+	ProxyURL      string   `yaml:"proxy_url,omitempty" json:"proxy_url,omitempty"`             // http(s) or socks5 proxy for all requests
+	NoProxy       []string `yaml:"no_proxy,omitempty" json:"no_proxy,omitempty"`               // Hosts, domains (.example.com) or CIDRs reached directly
+	CAFile        string   `yaml:"ca_file,omitempty" json:"ca_file,omitempty"`                 // PEM bundle trusted in addition to the system roots
+	CertFile      string   `yaml:"cert_file,omitempty" json:"cert_file,omitempty"`             // Client certificate for mutual TLS
+	KeyFile       string   `yaml:"key_file,omitempty" json:"key_file,omitempty"`               // Client key for mutual TLS
+	TLSMinVersion string   `yaml:"tls_min_version,omitempty" json:"tls_min_version,omitempty"` // "1.2" or "1.3"
+}
+
+// IsZero reports whether no setting is configured
+func (c HTTPClientConfig) IsZero() bool {
+	return c.ProxyURL == "" && len(c.NoProxy) == 0 && c.CAFile == "" && c.CertFile == "" && c.KeyFile == "" && c.TLSMinVersion == ""
+}
+
+// Merge returns the settings with the non-empty fields of override applied
+func (c HTTPClientConfig) Merge(override HTTPClientConfig) HTTPClientConfig {
+	merged := c
+	if override.ProxyURL != "" {
+		merged.ProxyURL = override.ProxyURL
+	}
+	if len(override.NoProxy) > 0 {
+		merged.NoProxy = override.NoProxy
+	}
+	if override.CAFile != "" {
+		merged.CAFile = override.CAFile
+	}
+	// The certificate and key are a pair
+	if override.CertFile != "" || override.KeyFile != "" {
+		merged.CertFile = override.CertFile
+		merged.KeyFile = override.KeyFile
+	}
+	if override.TLSMinVersion != "" {
+		merged.TLSMinVersion = override.TLSMinVersion
+	}
+	return merged
+}
+
 // SecurityConfig represents security-related configuration
 type SecurityConfig struct {
 	AllowedEnvVars []string `yaml:"allowed_env_vars" json:"allowed_env_vars"`
@@ -97,9 +190,63 @@ type SecurityConfig struct {
 // RepositoriesConfig represents a separate repositories configuration file
 type RepositoriesConfig struct {
 	Repositories   []Repository             `yaml:"repositories" json:"repositories"`
+	Discovery      []DiscoverySource        `yaml:"discovery,omitempty" json:"discovery,omitempty"`
 	GlobalSettings RepositoryGlobalSettings `yaml:"global_settings,omitempty" json:"global_settings,omitempty"`
 }
 
+// DiscoverySource is a GitHub organization or user, or a GitLab group, whose
+// repositories are scheduled for polling without being listed one by one.
+// Discovered repositories take their token and polling settings from the source.
+type DiscoverySource struct {
+	Name             string        `yaml:"name" json:"name"`
+	Provider         string        `yaml:"provider" json:"provider"`                                       // github or gitlab
+	Organization     string        `yaml:"organization,omitempty" json:"organization,omitempty"`           // GitHub organization
+	User             string        `yaml:"user,omitempty" json:"user,omitempty"`                           // GitHub user
+	Group            string        `yaml:"group,omitempty" json:"group,omitempty"`                         // GitLab group path, e.g. platform/backend
+	IncludeSubgroups bool          `yaml:"include_subgroups,omitempty" json:"include_subgroups,omitempty"` // Also list projects of the group's subgroups
+	URL              string        `yaml:"url,omitempty" json:"url,omitempty"`                             // Web URL of a self-hosted instance, defaults to github.com or gitlab.com
+	APIBaseURL       string        `yaml:"api_base_url,omitempty" json:"api_base_url,omitempty"`
+	Token            string        `yaml:"token" json:"-"`                             // Hidden in JSON output
+	Include          []string      `yaml:"include,omitempty" json:"include,omitempty"` // Repository name regexes; empty includes every repository
+	Exclude          []string      `yaml:"exclude,omitempty" json:"exclude,omitempty"` // Repository name regexes to leave out
+	Topics           []string      `yaml:"topics,omitempty" json:"topics,omitempty"`   // Only repositories with at least one of these topics
+	SkipArchived     bool          `yaml:"skip_archived,omitempty" json:"skip_archived,omitempty"`
+	SkipForks        bool          `yaml:"skip_forks,omitempty" json:"skip_forks,omitempty"`
+	RefreshInterval  time.Duration `yaml:"refresh_interval,omitempty" json:"refresh_interval,omitempty"` // How often the repository list is fetched again
+
+	// Settings given to every discovered repository
+	BranchRegex     string        `yaml:"branch_regex" json:"branch_regex"`
+	Branches        string        `yaml:"branches,omitempty" json:"branches,omitempty"` // "default" monitors only each repository's default branch
+	TagRegex        string        `yaml:"tag_regex,omitempty" json:"tag_regex,omitempty"`
+	PullRequests    bool          `yaml:"pull_requests,omitempty" json:"pull_requests,omitempty"`
+	PollingInterval time.Duration `yaml:"polling_interval,omitempty" json:"polling_interval,omitempty"`
+	Priority        string        `yaml:"priority,omitempty" json:"priority,omitempty"`
+}
+
+// Owner returns the organization, user or group the source lists
+func (s DiscoverySource) Owner() string {
+	switch {
+	case s.Organization != "":
+		return s.Organization
+	case s.User != "":
+		return s.User
+	default:
+		return s.Group
+	}
+}
+
+// OwnerURL returns the web URL of the source's organization, user or group
+func (s DiscoverySource) OwnerURL() string {
+	base := strings.TrimSuffix(s.URL, "/")
+	if base == "" {
+		base = "https://github.com"
+		if s.Provider == "gitlab" {
+			base = "https://gitlab.com"
+		}
+	}
+	return base + "/" + s.Owner()
+}
+
 // RepositoryGlobalSettings represents global repository settings
 type RepositoryGlobalSettings struct {
 	DefaultPollingEnabled   bool `yaml:"default_polling_enabled" json:"default_polling_enabled"`
@@ -11,9 +11,34 @@ const (
 	EventTypeBranchUpdated  EventType = "branch_updated"
 	EventTypeBranchCreated  EventType = "branch_created"
 	EventTypeBranchDeleted  EventType = "branch_deleted"
+	EventTypeTagCreated     EventType = "tag_created"
+	EventTypeTagDeleted     EventType = "tag_deleted"
+	EventTypeTagMoved       EventType = "tag_moved" // Tag re-pointed to a different commit
 	EventTypeTektonDetected EventType = "tekton_detected"
+
+	// Pull request (merge request on GitLab) events
+	EventTypePullRequestOpened  EventType = "pull_request_opened"
+	EventTypePullRequestUpdated EventType = "pull_request_updated" // New head commit pushed
+	EventTypePullRequestClosed  EventType = "pull_request_closed"  // Closed without merging
+	EventTypePullRequestMerged  EventType = "pull_request_merged"
 )
 
+// IsPullRequestEvent reports whether the event refers to a pull request.
+// For pull request events Event.Branch holds the source branch.
+func (t EventType) IsPullRequestEvent() bool {
+	switch t {
+	case EventTypePullRequestOpened, EventTypePullRequestUpdated, EventTypePullRequestClosed, EventTypePullRequestMerged:
+		return true
+	}
+	return false
+}
+
+// IsTagEvent reports whether the event refers to a tag rather than a branch.
+// For tag events Event.Branch holds the tag name.
+func (t EventType) IsTagEvent() bool {
+	return t == EventTypeTagCreated || t == EventTypeTagDeleted || t == EventTypeTagMoved
+}
+
 // Event represents a Git repository event
 type Event struct {
 	ID           string            `json:"id" db:"id"`
@@ -36,10 +61,11 @@ type Event struct {
 type EventStatus string
 
 const (
-	EventStatusPending   EventStatus = "pending"
-	EventStatusProcessed EventStatus = "processed"
-	EventStatusFailed    EventStatus = "failed"
-	EventStatusRetrying  EventStatus = "retrying"
+	EventStatusPending    EventStatus = "pending"
+	EventStatusProcessed  EventStatus = "processed"
+	EventStatusFailed     EventStatus = "failed"
+	EventStatusRetrying   EventStatus = "retrying"
+	EventStatusSuppressed EventStatus = "suppressed" // Filtered out by path filters, never dispatched
 )
 
 // TektonEvent represents the payload sent to Tekton EventListener
@@ -6,14 +6,76 @@ import (
 
 // Repository represents a Git repository configuration
 type Repository struct {
-	Name            string        `yaml:"name" json:"name"`
-	URL             string        `yaml:"url" json:"url"`
-	Provider        string        `yaml:"provider" json:"provider"` // github, gitlab
-	Token           string        `yaml:"token" json:"-"`           // Hidden in JSON output
-	BranchRegex     string        `yaml:"branch_regex" json:"branch_regex"`
-	Enabled         bool          `yaml:"enabled" json:"enabled"`
-	PollingInterval time.Duration `yaml:"polling_interval,omitempty" json:"polling_interval,omitempty"`
-	APIBaseURL      string        `yaml:"api_base_url,omitempty" json:"api_base_url,omitempty"`
+	Name            string           `yaml:"name" json:"name"`
+	URL             string           `yaml:"url" json:"url"`
+	Provider        string           `yaml:"provider" json:"provider"` // github, gitlab
+	Token           string           `yaml:"token" json:"-"`           // Hidden in JSON output
+	BranchRegex     string           `yaml:"branch_regex" json:"branch_regex"`
+	Branches        string           `yaml:"branches,omitempty" json:"branches,omitempty"`             // "default" monitors only the default branch instead of branch_regex
+	DefaultBranch   string           `yaml:"default_branch,omitempty" json:"default_branch,omitempty"` // Looked up from the provider when not set
+	TagRegex        string           `yaml:"tag_regex,omitempty" json:"tag_regex,omitempty"`           // Tags to monitor; empty disables tag monitoring
+	PullRequests    bool             `yaml:"pull_requests,omitempty" json:"pull_requests,omitempty"`   // Monitor open pull/merge requests
+	IncludePaths    []string         `yaml:"include_paths,omitempty" json:"include_paths,omitempty"`   // Only trigger when a changed file matches one of these globs
+	ExcludePaths    []string         `yaml:"exclude_paths,omitempty" json:"exclude_paths,omitempty"`   // Ignore changed files matching these globs
+	Enabled         bool             `yaml:"enabled" json:"enabled"`
+	PollingInterval time.Duration    `yaml:"polling_interval,omitempty" json:"polling_interval,omitempty"`
+	Priority        string           `yaml:"priority,omitempty" json:"priority,omitempty"` // critical or normal (default); critical repositories keep polling when API budget runs low
+	APIBaseURL      string           `yaml:"api_base_url,omitempty" json:"api_base_url,omitempty"`
+	GitHubApp       *GitHubAppConfig `yaml:"github_app,omitempty" json:"github_app,omitempty"`                     // GitHub App auth instead of a token
+	SSHKeyFile      string           `yaml:"ssh_key_file,omitempty" json:"ssh_key_file,omitempty"`                 // Deploy key used by the git fallback for SSH URLs
+	SSHKnownHosts   string           `yaml:"ssh_known_hosts_file,omitempty" json:"ssh_known_hosts_file,omitempty"` // known_hosts file pinning the SSH host key
+	HTTP            HTTPClientConfig `yaml:"http,omitempty" json:"http,omitempty"`                                 // Overrides the global proxy and TLS settings
+	Schedule        *PollSchedule    `yaml:"schedule,omitempty" json:"schedule,omitempty"`                         // Poll at cron times instead of every polling_interval
+	BlackoutWindows []BlackoutWindow `yaml:"blackout_windows,omitempty" json:"blackout_windows,omitempty"`         // Added to the global blackout windows
+	BlackoutAction  string           `yaml:"blackout_action,omitempty" json:"blackout_action,omitempty"`           // queue (default) or drop
+}
+
+// Repository priorities
+const (
+	PriorityCritical = "critical"
+	PriorityNormal   = "normal"
+)
+
+// PollSchedule restricts polling to the times matching a cron expression
+type PollSchedule struct {
+	Cron     string `yaml:"cron" json:"cron"`                             // e.g. "*/10 8-18 * * MON-FRI"
+	Timezone string `yaml:"timezone,omitempty" json:"timezone,omitempty"` // IANA time zone, defaults to UTC
+}
+
+// BlackoutWindow is a period during which no pipelines are triggered. It is
+// either a fixed range from Start to End, or recurs at every Cron time for Duration.
+type BlackoutWindow struct {
+	Name     string        `yaml:"name,omitempty" json:"name,omitempty"`
+	Start    time.Time     `yaml:"start,omitempty" json:"start,omitempty"` // RFC 3339
+	End      time.Time     `yaml:"end,omitempty" json:"end,omitempty"`
+	Cron     string        `yaml:"cron,omitempty" json:"cron,omitempty"`
+	Duration time.Duration `yaml:"duration,omitempty" json:"duration,omitempty"`
+	Timezone string        `yaml:"timezone,omitempty" json:"timezone,omitempty"` // Time zone of Cron, defaults to UTC
+}
+
+// Blackout actions: what happens to changes made during a blackout window
+const (
+	BlackoutActionQueue = "queue" // Stop polling, so changes trigger once the window ends
+	BlackoutActionDrop  = "drop"  // Keep polling but suppress the events
+)
+
+// BranchesDefault is the branches setting that monitors only the default branch
+const BranchesDefault = "default"
+
+// DefaultBranchOnly reports whether only the repository's default branch is monitored
+func (r Repository) DefaultBranchOnly() bool {
+	return r.Branches == BranchesDefault
+}
+
+// IsCritical reports whether the repository is polled ahead of others when the
+// API budget runs low
+func (r Repository) IsCritical() bool {
+	return r.Priority == PriorityCritical
+}
+
+// HasPathFilters reports whether events are filtered by the files they change
+func (r Repository) HasPathFilters() bool {
+	return len(r.IncludePaths) > 0 || len(r.ExcludePaths) > 0
 }
 
 // Branch represents a Git branch
@@ -21,6 +83,85 @@ type Branch struct {
 	Name      string `json:"name"`
 	CommitSHA string `json:"commit_sha"`
 	Protected bool   `json:"protected"`
+	Default   bool   `json:"default"` // The repository's default branch
+}
+
+// Tag represents a Git tag
+type Tag struct {
+	Name      string `json:"name"`
+	CommitSHA string `json:"commit_sha"` // Commit the tag points to, peeled for annotated tags
+}
+
+// Pull request states
+const (
+	PullRequestStateOpen   = "open"
+	PullRequestStateClosed = "closed"
+	PullRequestStateMerged = "merged"
+)
+
+// PullRequest represents a pull request (merge request on GitLab)
+type PullRequest struct {
+	Number       int      `json:"number"` // Per-repository number (IID on GitLab)
+	Title        string   `json:"title"`
+	State        string   `json:"state"` // open, closed or merged
+	HeadSHA      string   `json:"head_sha"`
+	SourceBranch string   `json:"source_branch"`
+	TargetBranch string   `json:"target_branch"`
+	Author       string   `json:"author"`
+	Labels       []string `json:"labels,omitempty"`
+	Draft        bool     `json:"draft"`
+	URL          string   `json:"url,omitempty"`
+}
+
+// Commit represents a single commit and the files it changed
+type Commit struct {
+	SHA         string    `json:"sha"`
+	Message     string    `json:"message"`
+	AuthorName  string    `json:"author_name"`
+	AuthorEmail string    `json:"author_email"`
+	Timestamp   time.Time `json:"timestamp"`
+	URL         string    `json:"url,omitempty"`
+	Added       []string  `json:"added,omitempty"`
+	Removed     []string  `json:"removed,omitempty"`
+	Modified    []string  `json:"modified,omitempty"`
+}
+
+// CommitComparison represents the commits between two revisions, oldest first.
+// Providers only report changed files for the range as a whole, so per-commit
+// file lists are filled in only when the range holds a single commit.
+type CommitComparison struct {
+	Commits      []Commit `json:"commits"`
+	TotalCommits int      `json:"total_commits"`
+	Added        []string `json:"added,omitempty"`
+	Removed      []string `json:"removed,omitempty"`
+	Modified     []string `json:"modified,omitempty"`
+
+	// FilesTruncated is set when the provider listed only part of the changed
+	// files, as it does for large comparisons
+	FilesTruncated bool `json:"files_truncated,omitempty"`
+}
+
+// AddFile records a changed file under the list matching its change status
+func (c *CommitComparison) AddFile(path, status string) {
+	switch status {
+	case "added":
+		c.Added = append(c.Added, path)
+	case "removed", "deleted":
+		c.Removed = append(c.Removed, path)
+	default:
+		c.Modified = append(c.Modified, path)
+	}
+}
+
+// AssignFilesToSingleCommit copies the range's changed files onto its commit
+// when the comparison holds exactly one commit and lists every changed file
+func (c *CommitComparison) AssignFilesToSingleCommit() {
+	if len(c.Commits) != 1 || c.FilesTruncated {
+		return
+	}
+	c.Commits[0].Added = c.Added
+	c.Commits[0].Removed = c.Removed
+	c.Commits[0].Modified = c.Modified
 }
 
 // RepoState represents the stored state of a repository branch
@@ -34,6 +175,16 @@ type RepoState struct {
 	UpdatedAt   time.Time `db:"updated_at" json:"updated_at"`
 }
 
+// HTTPCacheEntry represents a cached provider API response used for conditional requests
+type HTTPCacheEntry struct {
+	Key          string            `db:"cache_key" json:"key"`
+	ETag         string            `db:"etag" json:"etag,omitempty"`
+	LastModified string            `db:"last_modified" json:"last_modified,omitempty"`
+	Headers      map[string]string `db:"headers" json:"headers,omitempty"` // Response headers needed to replay the response, e.g. pagination links
+	Body         []byte            `db:"body" json:"-"`
+	UpdatedAt    time.Time         `db:"updated_at" json:"updated_at"`
+}
+
 // GitProvider defines the interface for Git providers
 type GitProvider interface {
 	GetBranches(repo Repository) ([]Branch, error)
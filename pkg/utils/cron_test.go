@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/15 8-18 * * MON-FRI",
+		"0 9 1,15 * *",
+		"30 2 * jan-mar sun",
+		"5/10 * * * 7",
+		"@daily",
+		"@Hourly",
+	}
+	for _, expr := range valid {
+		if _, err := ParseCron(expr, nil); err != nil {
+			t.Errorf("ParseCron(%q): unexpected error %v", expr, err)
+		}
+	}
+
+	invalid := []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "*/0 * * * *", "5-1 * * * *", "* * * * funday"}
+	for _, expr := range invalid {
+		if _, err := ParseCron(expr, nil); err == nil {
+			t.Errorf("ParseCron(%q): expected error", expr)
+		}
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	// 2024-03-01 is a Friday
+	from := time.Date(2024, 3, 1, 17, 50, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 1, 17, 51, 0, 0, time.UTC)},
+		{"*/15 8-18 * * MON-FRI", time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)},
+		{"0 9 * * MON-FRI", time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2024, 3, 1, 18, 5, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// Day of month and day of week restricted together match either one
+		{"0 12 15 * SUN", time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		schedule, err := ParseCron(tt.expr, nil)
+		if err != nil {
+			t.Fatalf("ParseCron(%q): %v", tt.expr, err)
+		}
+		if next := schedule.Next(from); !next.Equal(tt.expected) {
+			t.Errorf("Next(%q): expected %v, got %v", tt.expr, tt.expected, next)
+		}
+	}
+}
+
+func TestCronSchedule_Location(t *testing.T) {
+	location := time.FixedZone("UTC+8", 8*60*60)
+	schedule, err := ParseCron("0 9 * * *", location)
+	if err != nil {
+		t.Fatalf("ParseCron: %v", err)
+	}
+
+	// 09:00 at UTC+8 is 01:00 UTC
+	next := schedule.Next(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	if expected := time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC); !next.Equal(expected) {
+		t.Errorf("expected %v, got %v", expected, next)
+	}
+	if !schedule.Matches(next) {
+		t.Errorf("expected %v to match", next)
+	}
+	if schedule.Matches(next.Add(time.Minute)) {
+		t.Errorf("expected %v not to match", next.Add(time.Minute))
+	}
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ParseGitURL parses a repository URL. Besides http(s):// and ssh:// URLs it
+// accepts the SCP-like syntax git uses for SSH (git@github.com:owner/repo.git),
+// which is returned as the equivalent ssh:// URL.
+func ParseGitURL(repoURL string) (*url.URL, error) {
+	repoURL = strings.TrimSpace(repoURL)
+
+	if host, repoPath, ok := splitSCPURL(repoURL); ok {
+		parsed := &url.URL{Scheme: "ssh", Path: "/" + strings.TrimPrefix(repoPath, "/")}
+		if at := strings.LastIndex(host, "@"); at >= 0 {
+			parsed.User = url.User(host[:at])
+			host = host[at+1:]
+		}
+		if host == "" {
+			return nil, fmt.Errorf("missing host in SSH URL: %s", repoURL)
+		}
+		parsed.Host = host
+		return parsed, nil
+	}
+
+	return url.Parse(repoURL)
+}
+
+// IsSSHURL reports whether a repository is reached over SSH, either through an
+// ssh:// URL or the SCP-like syntax
+func IsSSHURL(repoURL string) bool {
+	repoURL = strings.TrimSpace(repoURL)
+	if _, _, ok := splitSCPURL(repoURL); ok {
+		return true
+	}
+	return strings.HasPrefix(strings.ToLower(repoURL), "ssh://")
+}
+
+// WebBaseURL returns the scheme and host a repository's web UI and API are
+// served from. SSH URLs map to https on the same host, without the SSH port.
+func WebBaseURL(parsed *url.URL) string {
+	switch parsed.Scheme {
+	case "http", "https":
+		return parsed.Scheme + "://" + parsed.Host
+	default:
+		return "https://" + parsed.Hostname()
+	}
+}
+
+// ParseBitbucketServerPath splits a Bitbucket Data Center repository path in
+// one of the layouts it uses:
+//
+//	[/context]/projects/{KEY}/repos/{slug}[/..]  (web UI)
+//	[/context]/users/{user}/repos/{slug}[/..]    (personal repository)
+//	[/context]/scm/{key}/{slug}.git              (clone URL)
+//
+// It returns the owner, prefixed with "~" for personal repositories, the
+// repository slug and the context path the server is served under. ok is false
+// for other paths, such as Bitbucket Cloud's /{workspace}/{slug}.
+func ParseBitbucketServerPath(repoPath string) (owner, slug, contextPath string, ok bool) {
+	parts := strings.Split(strings.Trim(repoPath, "/"), "/")
+
+	for i, part := range parts {
+		switch part {
+		case "projects", "users":
+			if i+3 < len(parts) && parts[i+2] == "repos" {
+				owner = parts[i+1]
+				if part == "users" {
+					owner = "~" + owner
+				}
+				return owner, strings.TrimSuffix(parts[i+3], ".git"), joinContextPath(parts[:i]), true
+			}
+		case "scm":
+			if i+2 < len(parts) {
+				return parts[i+1], strings.TrimSuffix(parts[i+2], ".git"), joinContextPath(parts[:i]), true
+			}
+		}
+	}
+
+	return "", "", "", false
+}
+
+// joinContextPath turns leading path segments into a context path prefix
+func joinContextPath(parts []string) string {
+	if len(parts) == 0 {
+		return ""
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// splitSCPURL splits "[user@]host:path" into host and path. Following git, the
+// form applies only when there is no scheme and the colon comes before any slash.
+func splitSCPURL(repoURL string) (string, string, bool) {
+	if strings.Contains(repoURL, "://") {
+		return "", "", false
+	}
+	colon := strings.Index(repoURL, ":")
+	if colon <= 0 || colon == len(repoURL)-1 {
+		return "", "", false
+	}
+	if slash := strings.Index(repoURL, "/"); slash >= 0 && slash < colon {
+		return "", "", false
+	}
+	return repoURL[:colon], repoURL[colon+1:], true
+}
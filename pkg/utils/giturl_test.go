@@ -0,0 +1,101 @@
+package utils
+
+import "testing"
+
+func TestParseGitURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		scheme   string
+		host     string
+		path     string
+		username string
+	}{
+		{"https://github.com/owner/repo.git", "https", "github.com", "/owner/repo.git", ""},
+		{"git@github.com:owner/repo.git", "ssh", "github.com", "/owner/repo.git", "git"},
+		{"gitlab.example.com:group/sub/project", "ssh", "gitlab.example.com", "/group/sub/project", ""},
+		{"ssh://git@bitbucket.example.com:7999/proj/repo.git", "ssh", "bitbucket.example.com:7999", "/proj/repo.git", "git"},
+		{"  git@github.com:/owner/repo  ", "ssh", "github.com", "/owner/repo", "git"},
+	}
+
+	for _, tt := range tests {
+		parsed, err := ParseGitURL(tt.url)
+		if err != nil {
+			t.Errorf("ParseGitURL(%q): unexpected error %v", tt.url, err)
+			continue
+		}
+		if parsed.Scheme != tt.scheme || parsed.Host != tt.host || parsed.Path != tt.path || parsed.User.Username() != tt.username {
+			t.Errorf("ParseGitURL(%q): got scheme=%q host=%q path=%q user=%q", tt.url, parsed.Scheme, parsed.Host, parsed.Path, parsed.User.Username())
+		}
+	}
+
+	if _, err := ParseGitURL("git@:owner/repo"); err == nil {
+		t.Error("ParseGitURL: expected error for SSH URL without host")
+	}
+}
+
+func TestIsSSHURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected bool
+	}{
+		{"git@github.com:owner/repo.git", true},
+		{"ssh://git@github.com/owner/repo.git", true},
+		{"SSH://git@github.com/owner/repo.git", true},
+		{"https://github.com/owner/repo", false},
+		{"file:///tmp/repo", false},
+		{"./local:repo", false},
+		{"not-a-url", false},
+	}
+
+	for _, tt := range tests {
+		if result := IsSSHURL(tt.url); result != tt.expected {
+			t.Errorf("IsSSHURL(%q): expected %v, got %v", tt.url, tt.expected, result)
+		}
+	}
+}
+
+func TestWebBaseURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://gitlab.example.com/group/project", "https://gitlab.example.com"},
+		{"http://localhost:3000/owner/repo", "http://localhost:3000"},
+		{"git@github.com:owner/repo.git", "https://github.com"},
+		{"ssh://git@gitea.example.com:2222/owner/repo.git", "https://gitea.example.com"},
+	}
+
+	for _, tt := range tests {
+		parsed, err := ParseGitURL(tt.url)
+		if err != nil {
+			t.Fatalf("ParseGitURL(%q): %v", tt.url, err)
+		}
+		if result := WebBaseURL(parsed); result != tt.expected {
+			t.Errorf("WebBaseURL(%q): expected %q, got %q", tt.url, tt.expected, result)
+		}
+	}
+}
+
+func TestParseBitbucketServerPath(t *testing.T) {
+	tests := []struct {
+		path        string
+		owner       string
+		slug        string
+		contextPath string
+		ok          bool
+	}{
+		{"/projects/PROJ/repos/service/browse", "PROJ", "service", "", true},
+		{"/users/jdoe/repos/dotfiles", "~jdoe", "dotfiles", "", true},
+		{"/scm/proj/service.git", "proj", "service", "", true},
+		{"/bitbucket/scm/proj/service.git", "proj", "service", "/bitbucket", true},
+		{"/workspace/repo", "", "", "", false},
+		{"/projects/PROJ", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		owner, slug, contextPath, ok := ParseBitbucketServerPath(tt.path)
+		if owner != tt.owner || slug != tt.slug || contextPath != tt.contextPath || ok != tt.ok {
+			t.Errorf("ParseBitbucketServerPath(%q): got owner=%q slug=%q context=%q ok=%v", tt.path, owner, slug, contextPath, ok)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// MatchGlob reports whether a slash-separated file path matches a glob pattern.
+// Segments follow path.Match syntax, and a "**" segment matches any number of
+// directories, so "services/api/**" matches everything below services/api.
+func MatchGlob(pattern, filePath string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(filePath, "/"))
+}
+
+// ValidateGlob checks that a pattern can be used with MatchGlob
+func ValidateGlob(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("pattern must not be empty")
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		if segment == "**" {
+			continue
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return fmt.Errorf("invalid pattern segment %q: %w", segment, err)
+		}
+	}
+	return nil
+}
+
+// matchGlobSegments matches pattern segments against path segments
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,46 @@
+package utils
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		path     string
+		expected bool
+	}{
+		{"services/api/**", "services/api/main.go", true},
+		{"services/api/**", "services/api/internal/handler.go", true},
+		{"services/api/**", "services/web/main.go", false},
+		{"**/*.md", "README.md", true},
+		{"**/*.md", "docs/zh/guide.md", true},
+		{"**/*.md", "docs/guide.go", false},
+		{"*.go", "main.go", true},
+		{"*.go", "cmd/main.go", false},
+		{"cmd/*/main.go", "cmd/server/main.go", true},
+		{"cmd/**/main.go", "cmd/main.go", true},
+		{"Makefile", "Makefile", true},
+		{"Makefile", "build/Makefile", false},
+	}
+
+	for _, tt := range tests {
+		if result := MatchGlob(tt.pattern, tt.path); result != tt.expected {
+			t.Errorf("MatchGlob(%q, %q): expected %v, got %v", tt.pattern, tt.path, tt.expected, result)
+		}
+	}
+}
+
+func TestValidateGlob(t *testing.T) {
+	valid := []string{"**", "docs/**", "**/*.md", "src/[a-z]*.go"}
+	for _, pattern := range valid {
+		if err := ValidateGlob(pattern); err != nil {
+			t.Errorf("ValidateGlob(%q): unexpected error %v", pattern, err)
+		}
+	}
+
+	invalid := []string{"", "src/[a-z.go"}
+	for _, pattern := range invalid {
+		if err := ValidateGlob(pattern); err == nil {
+			t.Errorf("ValidateGlob(%q): expected error", pattern)
+		}
+	}
+}
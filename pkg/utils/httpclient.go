@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// tlsVersions maps configured TLS versions to their crypto/tls constants
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// NewHTTPTransport builds a transport with the given proxy and TLS settings on
+// top of http.DefaultTransport's defaults. The extra CA bundle is trusted in
+// addition to the system roots.
+func NewHTTPTransport(cfg types.HTTPClientConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" || len(cfg.NoProxy) > 0 {
+		proxyConfig := httpproxy.FromEnvironment()
+		if cfg.ProxyURL != "" {
+			if err := ValidateProxyURL(cfg.ProxyURL); err != nil {
+				return nil, err
+			}
+			proxyConfig.HTTPProxy = cfg.ProxyURL
+			proxyConfig.HTTPSProxy = cfg.ProxyURL
+		}
+		if len(cfg.NoProxy) > 0 {
+			proxyConfig.NoProxy = strings.Join(cfg.NoProxy, ",")
+		}
+
+		proxyFunc := proxyConfig.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	tlsConfig := &tls.Config{}
+	if cfg.TLSMinVersion != "" {
+		version, ok := tlsVersions[cfg.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported TLS version %q, must be one of 1.0, 1.1, 1.2, 1.3", cfg.TLSMinVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" || cfg.KeyFile != "" {
+		if cfg.CertFile == "" || cfg.KeyFile == "" {
+			return nil, fmt.Errorf("client certificate and key must be configured together")
+		}
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+	return transport, nil
+}
+
+// ValidateProxyURL checks that a proxy URL has a supported scheme and a host
+func ValidateProxyURL(proxyURL string) error {
+	parsed, err := url.Parse(proxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL: %w", err)
+	}
+	switch parsed.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("proxy URL scheme must be http, https or socks5")
+	}
+	if parsed.Host == "" {
+		return fmt.Errorf("proxy URL must have a host")
+	}
+	return nil
+}
+
+// ValidTLSVersion reports whether a TLS version can be used as tls_min_version
+func ValidTLSVersion(version string) bool {
+	_, ok := tlsVersions[version]
+	return ok
+}
@@ -0,0 +1,160 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/johnnynv/RepoSentry/pkg/types"
+)
+
+func TestNewHTTPTransport_Proxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.Host)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	transport, err := NewHTTPTransport(types.HTTPClientConfig{
+		ProxyURL: proxy.URL,
+		NoProxy:  []string{".internal.example"},
+	})
+	if err != nil {
+		t.Fatalf("NewHTTPTransport: %v", err)
+	}
+	client := &http.Client{Transport: transport, Timeout: 2 * time.Second}
+
+	resp, err := client.Get("http://github.example.com/api/v3")
+	if err != nil {
+		t.Fatalf("request through proxy failed: %v", err)
+	}
+	resp.Body.Close()
+	if len(proxied) != 1 || proxied[0] != "github.example.com" {
+		t.Errorf("expected request to be proxied, got %v", proxied)
+	}
+
+	// Hosts on the no-proxy list are dialled directly
+	proxyURL, err := transport.Proxy(httptestRequest(t, "http://git.internal.example/repo"))
+	if err != nil || proxyURL != nil {
+		t.Errorf("expected no proxy for no_proxy host, got %v (%v)", proxyURL, err)
+	}
+}
+
+func TestNewHTTPTransport_CAAndClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	clientCert, clientKey := writeClientCertificate(t, dir)
+
+	clientPool := x509.NewCertPool()
+	certPEM, err := os.ReadFile(clientCert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientPool.AppendCertsFromPEM(certPEM)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientPool}
+	server.StartTLS()
+	defer server.Close()
+
+	caFile := filepath.Join(dir, "ca.pem")
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, caPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the client certificate the server rejects the handshake
+	transport, err := NewHTTPTransport(types.HTTPClientConfig{CAFile: caFile, TLSMinVersion: "1.2"})
+	if err != nil {
+		t.Fatalf("NewHTTPTransport: %v", err)
+	}
+	if resp, err := (&http.Client{Transport: transport}).Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Error("expected handshake without client certificate to fail")
+	}
+
+	transport, err = NewHTTPTransport(types.HTTPClientConfig{CAFile: caFile, CertFile: clientCert, KeyFile: clientKey})
+	if err != nil {
+		t.Fatalf("NewHTTPTransport: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("mTLS request failed: %v", err)
+	}
+	resp.Body.Close()
+}
+
+func TestNewHTTPTransport_InvalidSettings(t *testing.T) {
+	invalid := []types.HTTPClientConfig{
+		{ProxyURL: "ftp://proxy.example.com"},
+		{TLSMinVersion: "1.4"},
+		{CAFile: "/nonexistent/ca.pem"},
+		{CertFile: "/tmp/client.pem"},
+	}
+
+	for _, cfg := range invalid {
+		if _, err := NewHTTPTransport(cfg); err == nil {
+			t.Errorf("NewHTTPTransport(%+v): expected error", cfg)
+		}
+	}
+}
+
+func httptestRequest(t *testing.T, target string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+// writeClientCertificate writes a self-signed client certificate and key to dir
+func writeClientCertificate(t *testing.T, dir string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "reposentry"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile := filepath.Join(dir, "client.pem")
+	keyFile := filepath.Join(dir, "client-key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}